@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// benchmarkSuites are the standardized prompt sets. The latency suite uses
+// short prompts so timings are dominated by provider overhead; the
+// throughput suite asks for long generations to measure tokens/sec.
+var benchmarkSuites = map[string][]string{
+	"latency": {
+		"Reply with the single word: pong",
+		"What is 17 * 23? Answer with just the number.",
+		"Name the capital of France in one word.",
+	},
+	"throughput": {
+		"Write a 500 word essay about the history of computing.",
+		"Explain how a B-tree works in detail, with examples.",
+	},
+}
+
+// modelPricing maps model ID prefixes to USD per million input/output
+// tokens. Unknown models report token counts without a cost estimate.
+var modelPricing = map[string][2]float64{
+	"gpt-4o-mini":       {0.15, 0.60},
+	"gpt-4o":            {2.50, 10.00},
+	"gpt-4.1-mini":      {0.40, 1.60},
+	"gpt-4.1":           {2.00, 8.00},
+	"o3-mini":           {1.10, 4.40},
+	"claude-3-5-haiku":  {0.80, 4.00},
+	"claude-3-5-sonnet": {3.00, 15.00},
+	"deepseek-chat":     {0.27, 1.10},
+}
+
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Benchmark a Mind with a standardized prompt suite",
+	Long: `Run standardized prompts through a Mind and report latency
+percentiles, tokens/sec and estimated cost. Suites: latency, throughput.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mind, _ := cmd.Flags().GetString("mind")
+		suite, _ := cmd.Flags().GetString("suite")
+		runs, _ := cmd.Flags().GetInt("runs")
+		workspaceID, _ := cmd.Flags().GetUint("workspace")
+
+		if workspaceID == 0 {
+			workspaceID = contextWorkspace
+		}
+		if mind == "" {
+			fmt.Fprintln(os.Stderr, "--mind is required")
+			os.Exit(1)
+		}
+		prompts, ok := benchmarkSuites[suite]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown suite %q (available: latency, throughput)\n", suite)
+			os.Exit(1)
+		}
+
+		runBenchmark(mind, suite, prompts, runs, workspaceID)
+	},
+}
+
+// completeResult is the server's response to one benchmark prompt
+type completeResult struct {
+	Content    string `json:"content"`
+	Provider   string `json:"provider"`
+	Model      string `json:"model"`
+	DurationMS int64  `json:"duration_ms"`
+	Usage      struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func runBenchmark(mind, suite string, prompts []string, runs int, workspaceID uint) {
+	if runs <= 0 {
+		runs = 3
+	}
+	total := runs * len(prompts)
+	fmt.Printf("Benchmarking mind %q, suite %q: %d prompts x %d runs\n", mind, suite, len(prompts), runs)
+
+	var (
+		durations    []float64 // seconds
+		promptToks   int
+		completeToks int
+		genSeconds   float64
+		failures     int
+		provider     string
+		model        string
+	)
+
+	done := 0
+	for run := 0; run < runs; run++ {
+		for _, prompt := range prompts {
+			body := map[string]interface{}{"prompt": prompt}
+			if workspaceID != 0 {
+				body["workspace_id"] = workspaceID
+			}
+
+			started := time.Now()
+			resp, err := makeRequest("POST", "/api/v1/minds/"+mind+"/complete", body, token)
+			elapsed := time.Since(started).Seconds()
+			done++
+			fmt.Printf("\r  %d/%d", done, total)
+
+			if err != nil {
+				failures++
+				fmt.Fprintf(os.Stderr, "\nRequest failed: %v\n", err)
+				continue
+			}
+
+			var result completeResult
+			if err := json.Unmarshal(resp, &result); err != nil {
+				failures++
+				continue
+			}
+
+			durations = append(durations, elapsed)
+			promptToks += result.Usage.PromptTokens
+			completeToks += result.Usage.CompletionTokens
+			if result.Usage.CompletionTokens > 0 {
+				genSeconds += float64(result.DurationMS) / 1000
+			}
+			provider = result.Provider
+			model = result.Model
+		}
+	}
+	fmt.Println()
+
+	if len(durations) == 0 {
+		fmt.Fprintln(os.Stderr, "All requests failed")
+		os.Exit(1)
+	}
+
+	sort.Float64s(durations)
+	fmt.Printf("\nProvider: %s, model: %s\n", provider, model)
+	fmt.Printf("Requests: %d ok, %d failed\n", len(durations), failures)
+	fmt.Printf("Latency:  p50 %.2fs  p90 %.2fs  p99 %.2fs\n",
+		percentile(durations, 50), percentile(durations, 90), percentile(durations, 99))
+
+	if completeToks > 0 && genSeconds > 0 {
+		fmt.Printf("Tokens:   %d prompt, %d completion (%.1f tokens/sec)\n",
+			promptToks, completeToks, float64(completeToks)/genSeconds)
+	} else {
+		fmt.Printf("Tokens:   %d prompt, %d completion\n", promptToks, completeToks)
+	}
+
+	if in, out, ok := pricingFor(model); ok {
+		cost := float64(promptToks)/1e6*in + float64(completeToks)/1e6*out
+		fmt.Printf("Cost:     $%.4f for this run ($%.2f/$%.2f per 1M tokens)\n", cost, in, out)
+	} else {
+		fmt.Println("Cost:     n/a (no pricing data for this model)")
+	}
+}
+
+// percentile returns the p-th percentile of an ascending-sorted sample
+func percentile(sorted []float64, p int) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (p*len(sorted) + 99) / 100
+	if idx < 1 {
+		idx = 1
+	}
+	return sorted[idx-1]
+}
+
+// pricingFor finds pricing by the longest matching model ID prefix
+func pricingFor(model string) (in, out float64, ok bool) {
+	best := ""
+	for prefix, price := range modelPricing {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			best = prefix
+			in, out = price[0], price[1]
+		}
+	}
+	return in, out, best != ""
+}
+
+func init() {
+	benchmarkCmd.Flags().String("mind", "", "Mind resource name (required)")
+	benchmarkCmd.Flags().String("suite", "latency", "Prompt suite: latency or throughput")
+	benchmarkCmd.Flags().Int("runs", 3, "Times to repeat the suite")
+	benchmarkCmd.Flags().Uint("workspace", 0, "Workspace ID (defaults to your first workspace)")
+}
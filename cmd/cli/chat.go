@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// chatCmd opens a REPL-style conversation against a robot's chat endpoint,
+// streaming tokens as they arrive
+var chatCmd = &cobra.Command{
+	Use:   "chat [bot-name]",
+	Short: "Start an interactive chat with a bot",
+	Long: `Start an interactive chat with a bot. Commands inside the session:
+  /reset           start a new conversation
+  /history         print the conversation so far
+  /attach <file>   include a file's contents with the next message
+  /quit            leave the chat`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runChat(args[0])
+	},
+}
+
+// chatTranscript is one turn kept for /history
+type chatTranscript struct {
+	role    string
+	content string
+}
+
+func runChat(botName string) {
+	fmt.Printf("Chatting with %s (type /quit to leave, /reset to start over)\n", botName)
+
+	var (
+		sessionID  string
+		transcript []chatTranscript
+		pending    string // file contents queued by /attach
+	)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case line == "/quit" || line == "/exit":
+			return
+
+		case line == "/reset":
+			sessionID = ""
+			transcript = nil
+			pending = ""
+			fmt.Println("Conversation reset.")
+			continue
+
+		case line == "/history":
+			if len(transcript) == 0 {
+				fmt.Println("No messages yet.")
+			}
+			for _, turn := range transcript {
+				fmt.Printf("[%s] %s\n", turn.role, turn.content)
+			}
+			continue
+
+		case strings.HasPrefix(line, "/attach "):
+			file := strings.TrimSpace(strings.TrimPrefix(line, "/attach "))
+			data, err := os.ReadFile(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read file: %v\n", err)
+				continue
+			}
+			pending += fmt.Sprintf("\n\n--- %s ---\n%s", file, data)
+			fmt.Printf("Attached %s (%d bytes); it will be sent with your next message.\n", file, len(data))
+			continue
+		}
+
+		message := line + pending
+		pending = ""
+
+		reply, newSessionID, err := streamChatTurn(botName, sessionID, message)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Chat failed: %v\n", err)
+			continue
+		}
+		sessionID = newSessionID
+
+		transcript = append(transcript,
+			chatTranscript{role: "user", content: message},
+			chatTranscript{role: "assistant", content: reply},
+		)
+	}
+}
+
+// streamChatTurn sends one message to the streaming chat endpoint, printing
+// tokens as they arrive, and returns the full reply and session ID
+func streamChatTurn(botName, sessionID, message string) (string, string, error) {
+	payload, _ := json.Marshal(map[string]string{
+		"message":    message,
+		"session_id": sessionID,
+	})
+
+	req, err := http.NewRequest("POST", apiURL+"/api/v1/bots/"+botName+"/chat/stream", bytes.NewBuffer(payload))
+	if err != nil {
+		return "", sessionID, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", sessionID, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var buf bytes.Buffer
+		buf.ReadFrom(resp.Body)
+		return "", sessionID, fmt.Errorf("HTTP %d: %s", resp.StatusCode, buf.String())
+	}
+
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+		if data == "[DONE]" {
+			break
+		}
+
+		var event struct {
+			SessionID string `json:"session_id"`
+			Delta     string `json:"delta"`
+			Error     string `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			continue
+		}
+		if event.SessionID != "" {
+			sessionID = event.SessionID
+		}
+		if event.Error != "" {
+			fmt.Println()
+			return reply.String(), sessionID, fmt.Errorf("%s", event.Error)
+		}
+		if event.Delta != "" {
+			fmt.Print(event.Delta)
+			reply.WriteString(event.Delta)
+		}
+	}
+	fmt.Println()
+
+	return reply.String(), sessionID, scanner.Err()
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// CLIContext is one named server connection, kubeconfig style
+type CLIContext struct {
+	API       string `yaml:"api"`
+	Token     string `yaml:"token,omitempty"`
+	Workspace uint   `yaml:"workspace,omitempty"` // default workspace ID
+}
+
+// CLIConfig is the on-disk CLI configuration at ~/.xgent/config.yaml
+type CLIConfig struct {
+	CurrentContext string                 `yaml:"current-context,omitempty"`
+	Contexts       map[string]*CLIContext `yaml:"contexts,omitempty"`
+}
+
+// configPath returns the CLI config file location
+func configPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".xgent", "config.yaml")
+}
+
+// loadCLIConfig reads the config file; a missing file yields an empty config
+func loadCLIConfig() *CLIConfig {
+	cfg := &CLIConfig{Contexts: map[string]*CLIContext{}}
+	path := configPath()
+	if path == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg
+	}
+	yaml.Unmarshal(data, cfg)
+	if cfg.Contexts == nil {
+		cfg.Contexts = map[string]*CLIContext{}
+	}
+	return cfg
+}
+
+// saveCLIConfig writes the config file, owner-readable only since it holds
+// tokens
+func saveCLIConfig(cfg *CLIConfig) error {
+	path := configPath()
+	if path == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// currentContext returns the active context, or nil when none is configured
+func currentContext() *CLIContext {
+	cfg := loadCLIConfig()
+	if cfg.CurrentContext == "" {
+		return nil
+	}
+	return cfg.Contexts[cfg.CurrentContext]
+}
+
+// applyContextDefaults fills in apiURL, token and the default workspace from
+// the active context for values not set via flag or environment
+func applyContextDefaults(cmd *cobra.Command) {
+	context := currentContext()
+	if context == nil {
+		return
+	}
+	if !cmd.Root().PersistentFlags().Changed("api") && context.API != "" {
+		apiURL = context.API
+	}
+	if token == "" {
+		token = context.Token
+	}
+	if contextWorkspace == 0 {
+		contextWorkspace = context.Workspace
+	}
+}
+
+// saveLoginToken stores a freshly minted token in the active context so
+// subsequent commands authenticate automatically. Without any context a
+// "default" one is created pointing at the server just logged into.
+func saveLoginToken(newToken string) error {
+	cfg := loadCLIConfig()
+	name := cfg.CurrentContext
+	if name == "" {
+		name = "default"
+		cfg.CurrentContext = name
+	}
+	context := cfg.Contexts[name]
+	if context == nil {
+		context = &CLIContext{API: apiURL}
+		cfg.Contexts[name] = context
+	}
+	context.Token = newToken
+	return saveCLIConfig(cfg)
+}
+
+var setContextCmd = &cobra.Command{
+	Use:   "set-context [name]",
+	Short: "Create or update a named context",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadCLIConfig()
+		context := cfg.Contexts[args[0]]
+		if context == nil {
+			context = &CLIContext{API: "http://localhost:8080"}
+			cfg.Contexts[args[0]] = context
+		}
+
+		if cmd.Flags().Changed("api") {
+			context.API, _ = cmd.Flags().GetString("api")
+		}
+		if cmd.Flags().Changed("token") {
+			context.Token, _ = cmd.Flags().GetString("token")
+		}
+		if cmd.Flags().Changed("workspace") {
+			context.Workspace, _ = cmd.Flags().GetUint("workspace")
+		}
+		if cfg.CurrentContext == "" {
+			cfg.CurrentContext = args[0]
+		}
+
+		if err := saveCLIConfig(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Context %q saved\n", args[0])
+	},
+}
+
+var useContextCmd = &cobra.Command{
+	Use:   "use-context [name]",
+	Short: "Switch the active context",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadCLIConfig()
+		if _, ok := cfg.Contexts[args[0]]; !ok {
+			fmt.Fprintf(os.Stderr, "No context named %q\n", args[0])
+			os.Exit(1)
+		}
+		cfg.CurrentContext = args[0]
+		if err := saveCLIConfig(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Switched to context %q\n", args[0])
+	},
+}
+
+var getContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "List configured contexts",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadCLIConfig()
+		if len(cfg.Contexts) == 0 {
+			fmt.Println("No contexts configured")
+			return
+		}
+		for name, context := range cfg.Contexts {
+			marker := " "
+			if name == cfg.CurrentContext {
+				marker = "*"
+			}
+			authed := "no token"
+			if context.Token != "" {
+				authed = "token stored"
+			}
+			workspace := ""
+			if context.Workspace != 0 {
+				workspace = fmt.Sprintf(", workspace %d", context.Workspace)
+			}
+			fmt.Printf("%s %s\t%s (%s%s)\n", marker, name, context.API, authed, workspace)
+		}
+	},
+}
+
+func init() {
+	setContextCmd.Flags().String("api", "", "API server URL for this context")
+	setContextCmd.Flags().String("token", "", "API token for this context")
+	setContextCmd.Flags().Uint("workspace", 0, "Default workspace ID for this context")
+}
@@ -5,20 +5,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	apiURL   string
-	token    string
-	rootCmd  = &cobra.Command{
+	apiURL string
+	token  string
+
+	// contextWorkspace is the active context's default workspace ID
+	contextWorkspace uint
+
+	rootCmd = &cobra.Command{
 		Use:   "xgent-cli",
 		Short: "Xgent-Go CLI tool",
 		Long:  "Command line interface for Xgent-Go AI Agent platform",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			applyContextDefaults(cmd)
+		},
 	}
 )
 
@@ -38,23 +50,30 @@ func main() {
 		Use:   "resource",
 		Short: "Resource management commands",
 	}
-	resourceCmd.AddCommand(applyCmd, listResourcesCmd, getResourceCmd, deleteResourceCmd)
+	resourceCmd.AddCommand(applyCmd, diffCmd, listResourcesCmd, getResourceCmd, deleteResourceCmd)
 
 	// Task commands
 	taskCmd := &cobra.Command{
 		Use:   "task",
 		Short: "Task management commands",
 	}
-	taskCmd.AddCommand(createTaskCmd, listTasksCmd, getTaskCmd, logsCmd)
+	taskCmd.AddCommand(createTaskCmd, listTasksCmd, getTaskCmd, logsCmd, artifactsCmd, downloadArtifactCmd, attachTaskCmd)
+
+	// Config commands (kubeconfig-style contexts)
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage CLI contexts in ~/.xgent/config.yaml",
+	}
+	configCmd.AddCommand(setContextCmd, useContextCmd, getContextsCmd)
 
 	// Workspace commands
 	workspaceCmd := &cobra.Command{
 		Use:   "workspace",
 		Short: "Workspace management commands",
 	}
-	workspaceCmd.AddCommand(createWorkspaceCmd, listWorkspacesCmd)
+	workspaceCmd.AddCommand(createWorkspaceCmd, listWorkspacesCmd, useWorkspaceCmd, exportWorkspaceCmd, importWorkspaceCmd)
 
-	rootCmd.AddCommand(authCmd, resourceCmd, taskCmd, workspaceCmd)
+	rootCmd.AddCommand(authCmd, configCmd, resourceCmd, taskCmd, workspaceCmd, chatCmd, benchmarkCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -80,11 +99,14 @@ var loginCmd = &cobra.Command{
 		var result map[string]interface{}
 		json.Unmarshal(resp, &result)
 		
-		if tokenVal, ok := result["token"]; ok {
+		if tokenVal, ok := result["token"].(string); ok {
 			fmt.Printf("Login successful!\n")
-			fmt.Printf("Token: %s\n", tokenVal)
-			fmt.Printf("\nSet environment variable:\n")
-			fmt.Printf("export XGENT_TOKEN=%s\n", tokenVal)
+			if err := saveLoginToken(tokenVal); err != nil {
+				fmt.Fprintf(os.Stderr, "Could not store token (%v); set it manually:\n", err)
+				fmt.Printf("export XGENT_TOKEN=%s\n", tokenVal)
+				return
+			}
+			fmt.Printf("Token stored in %s\n", configPath())
 		}
 	},
 }
@@ -128,17 +150,66 @@ var applyCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		resp, err := makeRequest("POST", "/api/v1/resources/apply", string(data), token)
+		path := "/api/v1/resources/apply"
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			path += "?dryRun=true"
+		}
+
+		resp, err := makeRequest("POST", path, string(data), token)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Apply failed: %v\n", err)
 			os.Exit(1)
 		}
 
+		if dryRun {
+			printJSON(resp)
+			return
+		}
 		fmt.Printf("Resource applied successfully!\n")
 		printJSON(resp)
 	},
 }
 
+var diffCmd = &cobra.Command{
+	Use:   "diff -f [file]",
+	Short: "Show what applying a YAML file would change",
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			fmt.Fprintln(os.Stderr, "Error: -f flag is required")
+			os.Exit(1)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read file: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := makeRequest("POST", "/api/v1/resources/apply?diff=true", string(data), token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Diff failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		var result struct {
+			Name   string `json:"name"`
+			Action string `json:"action"`
+			Diff   string `json:"diff"`
+		}
+		if err := json.Unmarshal(resp, &result); err != nil {
+			printJSON(resp)
+			return
+		}
+		if result.Action == "unchanged" {
+			fmt.Printf("%s is unchanged\n", result.Name)
+			return
+		}
+		fmt.Print(result.Diff)
+	},
+}
+
 var listResourcesCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List resources",
@@ -199,18 +270,59 @@ var createTaskCmd = &cobra.Command{
 		prompt, _ := cmd.Flags().GetString("prompt")
 		resourceType, _ := cmd.Flags().GetString("resource-type")
 		resourceName, _ := cmd.Flags().GetString("resource-name")
+		template, _ := cmd.Flags().GetString("template")
+		varFlags, _ := cmd.Flags().GetStringSlice("var")
+
+		variables := make(map[string]string)
+		for _, pair := range varFlags {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: --var expects key=value, got %q\n", pair)
+				os.Exit(1)
+			}
+			variables[key] = value
+		}
+
+		// --template instantiates a stored template; other flags override it
+		if template != "" {
+			body := map[string]interface{}{}
+			if title != "" {
+				body["title"] = title
+			}
+			if prompt != "" {
+				body["prompt"] = prompt
+			}
+			if len(variables) > 0 {
+				body["variables"] = variables
+			}
+
+			resp, err := makeRequest("POST", "/api/v1/tasks/from-template/"+template, body, token)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Create task failed: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Task created successfully!\n")
+			printJSON(resp)
+			return
+		}
 
 		if title == "" || prompt == "" || resourceType == "" || resourceName == "" {
 			fmt.Fprintln(os.Stderr, "Error: --title, --prompt, --resource-type, and --resource-name are required")
 			os.Exit(1)
 		}
 
-		resp, err := makeRequest("POST", "/api/v1/tasks", map[string]interface{}{
+		body := map[string]interface{}{
 			"title":         title,
 			"prompt":        prompt,
 			"resource_type": resourceType,
 			"resource_name": resourceName,
-		}, token)
+		}
+		if len(variables) > 0 {
+			body["variables"] = variables
+		}
+
+		resp, err := makeRequest("POST", "/api/v1/tasks", body, token)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Create task failed: %v\n", err)
 			os.Exit(1)
@@ -265,6 +377,82 @@ var logsCmd = &cobra.Command{
 	},
 }
 
+var attachTaskCmd = &cobra.Command{
+	Use:   "attach [task-id] [file...]",
+	Short: "Upload files and attach them to a task",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := uploadFiles("/api/v1/attachments/upload", args[0], args[1:], token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Attach failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		printJSON(resp)
+	},
+}
+
+var artifactsCmd = &cobra.Command{
+	Use:   "artifacts [task-id]",
+	Short: "List artifacts produced by a task",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := makeRequest("GET", "/api/v1/tasks/"+args[0]+"/artifacts", nil, token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "List artifacts failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		printJSON(resp)
+	},
+}
+
+var downloadArtifactCmd = &cobra.Command{
+	Use:   "download [task-id] [artifact-id]",
+	Short: "Download a task artifact",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		// Without -o, name the file after the artifact record
+		if output == "" {
+			resp, err := makeRequest("GET", "/api/v1/tasks/"+args[0]+"/artifacts", nil, token)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Download failed: %v\n", err)
+				os.Exit(1)
+			}
+			var listing struct {
+				Artifacts []struct {
+					ID   uint   `json:"id"`
+					Name string `json:"name"`
+				} `json:"artifacts"`
+			}
+			json.Unmarshal(resp, &listing)
+			for _, artifact := range listing.Artifacts {
+				if fmt.Sprint(artifact.ID) == args[1] {
+					output = artifact.Name
+				}
+			}
+			if output == "" {
+				fmt.Fprintf(os.Stderr, "Artifact %s not found on task %s\n", args[1], args[0])
+				os.Exit(1)
+			}
+		}
+
+		data, err := makeRequest("GET", "/api/v1/tasks/"+args[0]+"/artifacts/"+args[1]+"/download", nil, token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Download failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := os.WriteFile(output, data, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", output, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Saved %s (%d bytes)\n", output, len(data))
+	},
+}
+
 // Workspace commands
 var createWorkspaceCmd = &cobra.Command{
 	Use:   "create [name]",
@@ -287,6 +475,57 @@ var createWorkspaceCmd = &cobra.Command{
 	},
 }
 
+var exportWorkspaceCmd = &cobra.Command{
+	Use:   "export [workspace-id]",
+	Short: "Export a workspace's resources as a YAML bundle",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := makeRequest("GET", "/api/v1/workspaces/"+args[0]+"/export", nil, token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		if output, _ := cmd.Flags().GetString("output"); output != "" {
+			if err := os.WriteFile(output, resp, 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write bundle: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Bundle written to %s\n", output)
+			return
+		}
+		fmt.Print(string(resp))
+	},
+}
+
+var importWorkspaceCmd = &cobra.Command{
+	Use:   "import [workspace-id] -f [bundle]",
+	Short: "Import a YAML resource bundle into a workspace",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			fmt.Fprintln(os.Stderr, "Error: -f flag is required")
+			os.Exit(1)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read file: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := makeRequest("POST", "/api/v1/workspaces/"+args[0]+"/import", string(data), token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Bundle imported successfully!\n")
+		printJSON(resp)
+	},
+}
+
 var listWorkspacesCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List workspaces",
@@ -301,14 +540,54 @@ var listWorkspacesCmd = &cobra.Command{
 	},
 }
 
+var useWorkspaceCmd = &cobra.Command{
+	Use:   "use [workspace-id]",
+	Short: "Select the workspace sent with subsequent requests",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil || id == 0 {
+			fmt.Fprintf(os.Stderr, "Invalid workspace ID %q\n", args[0])
+			os.Exit(1)
+		}
+
+		cfg := loadCLIConfig()
+		name := cfg.CurrentContext
+		if name == "" {
+			name = "default"
+			cfg.CurrentContext = name
+		}
+		context := cfg.Contexts[name]
+		if context == nil {
+			context = &CLIContext{API: apiURL}
+			cfg.Contexts[name] = context
+		}
+		context.Workspace = uint(id)
+
+		if err := saveCLIConfig(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Using workspace %d in context %q\n", id, name)
+	},
+}
+
 func init() {
 	applyCmd.Flags().StringP("file", "f", "", "YAML file path")
+	applyCmd.Flags().Bool("dry-run", false, "Validate and report what would change without writing")
+	diffCmd.Flags().StringP("file", "f", "", "YAML file path")
+	exportWorkspaceCmd.Flags().StringP("output", "o", "", "Write the bundle to a file instead of stdout")
+	importWorkspaceCmd.Flags().StringP("file", "f", "", "Bundle file path")
 	listResourcesCmd.Flags().String("type", "", "Resource type filter")
 
 	createTaskCmd.Flags().String("title", "", "Task title")
 	createTaskCmd.Flags().String("prompt", "", "Task prompt")
 	createTaskCmd.Flags().String("resource-type", "", "Resource type (bot or team)")
 	createTaskCmd.Flags().String("resource-name", "", "Resource name")
+	createTaskCmd.Flags().String("template", "", "Task template ID to instantiate")
+	createTaskCmd.Flags().StringSlice("var", nil, "Template variable as key=value (repeatable)")
+
+	downloadArtifactCmd.Flags().StringP("output", "o", "", "Output file (defaults to the artifact name)")
 
 	createWorkspaceCmd.Flags().String("description", "", "Workspace description")
 }
@@ -334,6 +613,9 @@ func makeRequest(method, path string, body interface{}, authToken string) ([]byt
 	if authToken != "" {
 		req.Header.Set("Authorization", "Bearer "+authToken)
 	}
+	if contextWorkspace != 0 {
+		req.Header.Set("X-Xgent-Workspace", strconv.FormatUint(uint64(contextWorkspace), 10))
+	}
 
 	if body != nil {
 		if _, ok := body.(string); ok && filepath.Ext(path) != "" {
@@ -362,6 +644,75 @@ func makeRequest(method, path string, body interface{}, authToken string) ([]byt
 	return respBody, nil
 }
 
+// uploadFiles posts local files as a multipart upload linked to a task. The
+// per-part content type comes from the file extension because the server
+// validates it against its supported MIME types.
+func uploadFiles(path, taskID string, names []string, authToken string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	writer.WriteField("task_id", taskID)
+
+	for _, name := range names {
+		contentType := mime.TypeByExtension(filepath.Ext(name))
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		if media, _, err := mime.ParseMediaType(contentType); err == nil {
+			contentType = media
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition",
+			fmt.Sprintf(`form-data; name="files"; filename="%s"`, filepath.Base(name)))
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(part, file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer.Close()
+
+	req, err := http.NewRequest("POST", apiURL+path, &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+	if contextWorkspace != 0 {
+		req.Header.Set("X-Xgent-Workspace", strconv.FormatUint(uint64(contextWorkspace), 10))
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return respBody, nil
+}
+
 func printJSON(data []byte) {
 	var result interface{}
 	if err := json.Unmarshal(data, &result); err != nil {
@@ -0,0 +1,132 @@
+// Command migrate-attachments moves attachment files from local disk into
+// the configured blob store, rewriting their storage keys. Run it once after
+// switching attachment.backend to s3; it is safe to re-run.
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	// Initialize logger
+	log, err := logger.New(logger.Config{
+		Level:      "info",
+		OutputPath: "stdout",
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer log.Sync()
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal("failed to load config", zap.Error(err))
+	}
+
+	// Initialize storage
+	store, err := storage.New(&storage.Config{
+		Driver:   cfg.Database.Driver,
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		Database: cfg.Database.Database,
+		Username: cfg.Database.Username,
+		Password: cfg.Database.Password,
+
+		EncryptionKey: cfg.Security.EncryptionKey,
+	}, log)
+	if err != nil {
+		log.Fatal("failed to initialize storage", zap.Error(err))
+	}
+
+	// Initialize attachment service with the target backend
+	service, err := attachment.NewService(store, attachment.Config{
+		Backend:   cfg.Attachment.Backend,
+		UploadDir: cfg.Attachment.UploadDir,
+		S3: attachment.S3Config{
+			Endpoint:  cfg.Attachment.S3.Endpoint,
+			Region:    cfg.Attachment.S3.Region,
+			Bucket:    cfg.Attachment.S3.Bucket,
+			AccessKey: cfg.Attachment.S3.AccessKey,
+			SecretKey: cfg.Attachment.S3.SecretKey,
+		},
+	}, log)
+	if err != nil {
+		log.Fatal("failed to initialize attachment storage", zap.Error(err))
+	}
+
+	migrated, err := service.MigrateLocalFiles()
+	if err != nil {
+		log.Fatal("migration failed", zap.Int("migrated", migrated), zap.Error(err))
+	}
+
+	log.Info("Migration complete", zap.Int("migrated", migrated))
+}
+
+// AppConfig represents application configuration
+type AppConfig struct {
+	Database struct {
+		Driver   string `mapstructure:"driver"`
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Database string `mapstructure:"database"`
+		Username string `mapstructure:"username"`
+		Password string `mapstructure:"password"`
+	} `mapstructure:"database"`
+
+	Security struct {
+		EncryptionKey string `mapstructure:"encryption_key"`
+	} `mapstructure:"security"`
+
+	Attachment struct {
+		Backend   string `mapstructure:"backend"`
+		UploadDir string `mapstructure:"upload_dir"`
+		S3        struct {
+			Endpoint  string `mapstructure:"endpoint"`
+			Region    string `mapstructure:"region"`
+			Bucket    string `mapstructure:"bucket"`
+			AccessKey string `mapstructure:"access_key"`
+			SecretKey string `mapstructure:"secret_key"`
+		} `mapstructure:"s3"`
+	} `mapstructure:"attachment"`
+}
+
+func loadConfig() (*AppConfig, error) {
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./configs")
+	viper.AddConfigPath(".")
+
+	// Set defaults
+	viper.SetDefault("database.driver", "mysql")
+	viper.SetDefault("database.host", "localhost")
+	viper.SetDefault("database.port", 3306)
+	viper.SetDefault("attachment.backend", "local")
+	viper.SetDefault("attachment.upload_dir", "/tmp/xgent-uploads")
+
+	// Read environment variables
+	viper.AutomaticEnv()
+	viper.BindEnv("security.encryption_key", "XGENT_ENCRYPTION_KEY")
+	viper.BindEnv("attachment.s3.access_key", "S3_ACCESS_KEY")
+	viper.BindEnv("attachment.s3.secret_key", "S3_SECRET_KEY")
+
+	// Read config file
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+
+	var cfg AppConfig
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
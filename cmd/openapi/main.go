@@ -0,0 +1,23 @@
+// Command openapi prints the server's OpenAPI 3 document as JSON. CI keeps
+// docs/openapi.json in sync by regenerating it and failing on a diff:
+//
+//	go run ./cmd/openapi > docs/openapi.json
+//	git diff --exit-code docs/openapi.json
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/xcode-ai/xgent-go/internal/api"
+)
+
+func main() {
+	data, err := json.MarshalIndent(api.BuildOpenAPISpec(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal spec: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+	"github.com/xcode-ai/xgent-go/internal/runner"
+	"github.com/xcode-ai/xgent-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const runnerVersion = "1.0.0"
+
+func main() {
+	// Initialize logger
+	log, err := logger.New(logger.Config{
+		Level:      "info",
+		OutputPath: "stdout",
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer log.Sync()
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal("failed to load config", zap.Error(err))
+	}
+	if cfg.Runner.Name == "" {
+		hostname, _ := os.Hostname()
+		cfg.Runner.Name = hostname
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Handle interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-quit
+		log.Info("Shutting down runner...")
+		cancel()
+	}()
+
+	// Connect and serve, reconnecting on failure
+	for {
+		if err := serve(ctx, cfg, log); err != nil {
+			log.Error("Runner connection lost", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Runner exited")
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// serve connects to the server, registers, and executes assignments until the
+// connection drops or the context is cancelled
+func serve(ctx context.Context, cfg *AppConfig, log *zap.Logger) error {
+	header := http.Header{}
+	if cfg.Runner.Token != "" {
+		header.Set("Authorization", "Bearer "+cfg.Runner.Token)
+	}
+
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, cfg.Runner.ServerURL, header)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", cfg.Runner.ServerURL, err)
+	}
+	defer ws.Close()
+
+	// Register with the server
+	var writeMu sync.Mutex
+	send := func(v interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return ws.WriteJSON(v)
+	}
+	if err := send(runner.Hello{Name: cfg.Runner.Name, Version: runnerVersion}); err != nil {
+		return fmt.Errorf("failed to register: %w", err)
+	}
+
+	log.Info("Runner connected",
+		zap.String("name", cfg.Runner.Name),
+		zap.String("server", cfg.Runner.ServerURL))
+
+	// Close the socket when the context is cancelled so ReadJSON unblocks
+	go func() {
+		<-ctx.Done()
+		ws.Close()
+	}()
+
+	var (
+		cancelMu sync.Mutex
+		cancels  = make(map[string]context.CancelFunc)
+	)
+
+	for {
+		var assignment runner.Assignment
+		if err := ws.ReadJSON(&assignment); err != nil {
+			return err
+		}
+
+		if assignment.Cancel {
+			cancelMu.Lock()
+			if cancelRun, ok := cancels[assignment.ID]; ok {
+				cancelRun()
+			}
+			cancelMu.Unlock()
+			continue
+		}
+
+		runCtx, cancelRun := context.WithCancel(ctx)
+		cancelMu.Lock()
+		cancels[assignment.ID] = cancelRun
+		cancelMu.Unlock()
+
+		go func(assignment runner.Assignment) {
+			defer func() {
+				cancelRun()
+				cancelMu.Lock()
+				delete(cancels, assignment.ID)
+				cancelMu.Unlock()
+			}()
+
+			log.Info("Executing assignment",
+				zap.String("assignment", assignment.ID),
+				zap.Uint("task_id", assignment.TaskID))
+
+			err := execute(runCtx, cfg, assignment, send, log)
+			done := runner.Message{Type: "done", AssignmentID: assignment.ID}
+			if err != nil {
+				done.Error = err.Error()
+				log.Error("Assignment failed",
+					zap.String("assignment", assignment.ID),
+					zap.Error(err))
+			} else {
+				log.Info("Assignment completed", zap.String("assignment", assignment.ID))
+			}
+			send(done)
+		}(assignment)
+	}
+}
+
+// execute runs the bridge script for an assignment and streams its event
+// lines back to the server
+func execute(ctx context.Context, cfg *AppConfig, assignment runner.Assignment, send func(interface{}) error, log *zap.Logger) error {
+	scriptPath := filepath.Join(cfg.Runner.ScriptsDir, "agno_runner.py")
+	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
+		return fmt.Errorf("agno runner script not found at %s", scriptPath)
+	}
+
+	cmd := exec.CommandContext(ctx, cfg.Runner.Python, scriptPath)
+	cmd.Env = os.Environ()
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start python script: %w", err)
+	}
+
+	go func() {
+		defer stdin.Close()
+		stdin.Write(assignment.Config)
+	}()
+
+	go func() {
+		stderrScanner := bufio.NewScanner(stderr)
+		for stderrScanner.Scan() {
+			log.Error("Python script stderr", zap.String("line", stderrScanner.Text()))
+		}
+	}()
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		send(runner.Message{
+			Type:         "event",
+			AssignmentID: assignment.ID,
+			Line:         scanner.Text(),
+		})
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("python script finished with error: %w", err)
+	}
+	return nil
+}
+
+// AppConfig represents runner configuration
+type AppConfig struct {
+	Runner struct {
+		Name       string `mapstructure:"name"`
+		ServerURL  string `mapstructure:"server_url"`
+		Token      string `mapstructure:"token"`
+		ScriptsDir string `mapstructure:"scripts_dir"`
+		Python     string `mapstructure:"python"`
+	} `mapstructure:"runner"`
+}
+
+func loadConfig() (*AppConfig, error) {
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./configs")
+	viper.AddConfigPath(".")
+
+	// Set defaults
+	viper.SetDefault("runner.server_url", "ws://localhost:8080/api/v1/runners/connect")
+	viper.SetDefault("runner.scripts_dir", "./scripts")
+	viper.SetDefault("runner.python", "python3")
+
+	// Read environment variables
+	viper.AutomaticEnv()
+	viper.BindEnv("runner.name", "XGENT_RUNNER_NAME")
+	viper.BindEnv("runner.server_url", "XGENT_RUNNER_SERVER_URL")
+	viper.BindEnv("runner.token", "XGENT_RUNNER_TOKEN")
+
+	// Read config file
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+
+	var cfg AppConfig
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
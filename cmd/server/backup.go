@@ -0,0 +1,325 @@
+// Backup and restore subcommands for disaster recovery of self-hosted
+// installs. `xgent-server backup <file>` writes every database entity plus
+// the attachment blobs into a single gzipped tar archive; `xgent-server
+// restore <file>` loads one back. Database rows are dumped inside a single
+// transaction and restored inside a single transaction, so each side sees a
+// consistent snapshot.
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// backupFormatVersion is recorded in the archive manifest so restore can
+// reject archives written by an incompatible version
+const backupFormatVersion = 1
+
+// backupManifest describes the archive contents
+type backupManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// backupTable pairs a table's archive name with a factory for a slice of its
+// model type. Tables are listed in dependency order: restore inserts top to
+// bottom and clears bottom to top.
+type backupTable struct {
+	name string
+	rows func() interface{}
+}
+
+func backupTables() []backupTable {
+	return []backupTable{
+		{"users", func() interface{} { return &[]models.User{} }},
+		{"workspaces", func() interface{} { return &[]models.Workspace{} }},
+		{"workspace_members", func() interface{} { return &[]models.WorkspaceMember{} }},
+		{"resources", func() interface{} { return &[]models.Resource{} }},
+		{"tasks", func() interface{} { return &[]models.Task{} }},
+		{"sub_tasks", func() interface{} { return &[]models.SubTask{} }},
+		{"task_logs", func() interface{} { return &[]models.TaskLog{} }},
+		{"task_events", func() interface{} { return &[]models.TaskEvent{} }},
+		{"sessions", func() interface{} { return &[]models.Session{} }},
+		{"login_sessions", func() interface{} { return &[]models.LoginSession{} }},
+		{"messages", func() interface{} { return &[]models.Message{} }},
+		{"api_keys", func() interface{} { return &[]models.APIKey{} }},
+		{"attachments", func() interface{} { return &[]models.Attachment{} }},
+		{"artifacts", func() interface{} { return &[]models.Artifact{} }},
+		{"secrets", func() interface{} { return &[]models.Secret{} }},
+		{"webhooks", func() interface{} { return &[]models.Webhook{} }},
+		{"audit_logs", func() interface{} { return &[]models.AuditLog{} }},
+	}
+}
+
+// runBackup implements `xgent-server backup <file>`
+func runBackup(store *storage.Storage, cfg *AppConfig, log *zap.Logger, args []string) {
+	if len(args) != 1 {
+		log.Fatal("usage: xgent-server backup <file.tar.gz>")
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		log.Fatal("failed to create backup file", zap.Error(err))
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	if err := writeBackup(store, cfg, log, tw); err != nil {
+		os.Remove(args[0])
+		log.Fatal("backup failed", zap.Error(err))
+	}
+
+	if err := tw.Close(); err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		os.Remove(args[0])
+		log.Fatal("failed to finalize backup file", zap.Error(err))
+	}
+
+	log.Info("Backup complete", zap.String("file", args[0]))
+}
+
+// writeBackup dumps all tables and attachment blobs into the archive
+func writeBackup(store *storage.Storage, cfg *AppConfig, log *zap.Logger, tw *tar.Writer) error {
+	manifest, err := json.Marshal(backupManifest{
+		Version:   backupFormatVersion,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, "manifest.json", manifest); err != nil {
+		return err
+	}
+
+	// Dump every table inside one transaction so the archive is a
+	// consistent snapshot even while the server keeps writing
+	var attachments []models.Attachment
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		for _, table := range backupTables() {
+			rows := table.rows()
+			if err := tx.Unscoped().Find(rows).Error; err != nil {
+				return fmt.Errorf("failed to dump %s: %w", table.name, err)
+			}
+			encoded, err := json.Marshal(rows)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", table.name, err)
+			}
+			if err := writeTarFile(tw, "db/"+table.name+".json", encoded); err != nil {
+				return err
+			}
+			log.Info("Dumped table", zap.String("table", table.name))
+		}
+		return tx.Unscoped().Find(&attachments).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeBlobEntries(cfg, log, tw, attachments)
+}
+
+// writeBlobEntries copies attachment blobs from the configured blob store
+// into the archive. Missing blobs are logged and skipped so one lost file
+// does not abort the whole backup.
+func writeBlobEntries(cfg *AppConfig, log *zap.Logger, tw *tar.Writer, attachments []models.Attachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	blobs, err := attachment.NewBlobStore(attachmentConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to initialize blob store: %w", err)
+	}
+
+	for _, att := range attachments {
+		if att.StoragePath == "" {
+			continue
+		}
+		reader, err := blobs.Open(att.StoragePath)
+		if err != nil {
+			log.Warn("Skipping missing attachment blob",
+				zap.Uint("attachment_id", att.ID),
+				zap.String("storage_path", att.StoragePath),
+				zap.Error(err))
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read blob %s: %w", att.StoragePath, err)
+		}
+		if err := writeTarFile(tw, "blobs/"+att.StoragePath, data); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Dumped attachment blobs", zap.Int("count", len(attachments)))
+	return nil
+}
+
+// runRestore implements `xgent-server restore <file>`
+func runRestore(store *storage.Storage, cfg *AppConfig, log *zap.Logger, args []string) {
+	if len(args) != 1 {
+		log.Fatal("usage: xgent-server restore <file.tar.gz>")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		log.Fatal("failed to open backup file", zap.Error(err))
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		log.Fatal("backup file is not a gzipped archive", zap.Error(err))
+	}
+
+	if err := readBackup(store, cfg, log, tar.NewReader(gz)); err != nil {
+		log.Fatal("restore failed", zap.Error(err))
+	}
+
+	log.Info("Restore complete", zap.String("file", args[0]))
+}
+
+// readBackup loads the archive: blobs are written to the blob store as they
+// stream past, database tables are buffered and applied in one transaction
+// at the end so a truncated archive cannot leave a half-restored database.
+func readBackup(store *storage.Storage, cfg *AppConfig, log *zap.Logger, tr *tar.Reader) error {
+	tables := make(map[string][]byte)
+	var blobs attachment.BlobStore
+	sawManifest := false
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case header.Name == "manifest.json":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			var manifest backupManifest
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				return fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			if manifest.Version != backupFormatVersion {
+				return fmt.Errorf("unsupported backup format version %d", manifest.Version)
+			}
+			sawManifest = true
+
+		case strings.HasPrefix(header.Name, "db/"):
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSuffix(path.Base(header.Name), ".json")
+			tables[name] = data
+
+		case strings.HasPrefix(header.Name, "blobs/"):
+			if blobs == nil {
+				if blobs, err = attachment.NewBlobStore(attachmentConfig(cfg)); err != nil {
+					return fmt.Errorf("failed to initialize blob store: %w", err)
+				}
+			}
+			key := strings.TrimPrefix(header.Name, "blobs/")
+			if err := blobs.Save(key, tr, header.Size, ""); err != nil {
+				return fmt.Errorf("failed to restore blob %s: %w", key, err)
+			}
+		}
+	}
+
+	if !sawManifest {
+		return fmt.Errorf("archive has no manifest.json; not an xgent backup")
+	}
+
+	return restoreTables(store, log, tables)
+}
+
+// restoreTables replaces the database contents with the dumped rows inside a
+// single transaction
+func restoreTables(store *storage.Storage, log *zap.Logger, tables map[string][]byte) error {
+	ordered := backupTables()
+
+	return store.DB().Transaction(func(tx *gorm.DB) error {
+		// Clear in reverse dependency order before inserting
+		for i := len(ordered) - 1; i >= 0; i-- {
+			rows := ordered[i].rows()
+			if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(rows).Error; err != nil {
+				return fmt.Errorf("failed to clear %s: %w", ordered[i].name, err)
+			}
+		}
+
+		for _, table := range ordered {
+			data, ok := tables[table.name]
+			if !ok {
+				log.Warn("Archive has no dump for table, leaving it empty",
+					zap.String("table", table.name))
+				continue
+			}
+			rows := table.rows()
+			if err := json.Unmarshal(data, rows); err != nil {
+				return fmt.Errorf("failed to decode %s: %w", table.name, err)
+			}
+			if err := tx.CreateInBatches(rows, 200).Error; err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table.name, err)
+			}
+			log.Info("Restored table", zap.String("table", table.name))
+		}
+		return nil
+	})
+}
+
+// writeTarFile adds one regular file to the archive
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// attachmentConfig maps the app config onto the attachment service config
+func attachmentConfig(cfg *AppConfig) attachment.Config {
+	return attachment.Config{
+		Backend:   cfg.Attachment.Backend,
+		UploadDir: cfg.Attachment.UploadDir,
+		S3: attachment.S3Config{
+			Endpoint:  cfg.Attachment.S3.Endpoint,
+			Region:    cfg.Attachment.S3.Region,
+			Bucket:    cfg.Attachment.S3.Bucket,
+			AccessKey: cfg.Attachment.S3.AccessKey,
+			SecretKey: cfg.Attachment.S3.SecretKey,
+		},
+	}
+}
@@ -10,8 +10,11 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/xcode-ai/xgent-go/internal/api"
+	"github.com/xcode-ai/xgent-go/internal/llm"
 	"github.com/xcode-ai/xgent-go/internal/orchestrator"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
 	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/tracing"
 	"github.com/xcode-ai/xgent-go/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -33,6 +36,11 @@ func main() {
 		log.Fatal("failed to load config", zap.Error(err))
 	}
 
+	// Distributed tracing (no-op unless enabled in config)
+	if err := tracing.Init(cfg.Tracing, log); err != nil {
+		log.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
 	// Initialize storage
 	store, err := storage.New(&storage.Config{
 		Driver:   cfg.Database.Driver,
@@ -41,6 +49,9 @@ func main() {
 		Database: cfg.Database.Database,
 		Username: cfg.Database.Username,
 		Password: cfg.Database.Password,
+
+		EncryptionKey:      cfg.Security.EncryptionKey,
+		SlowQueryThreshold: time.Duration(cfg.Database.SlowQueryThresholdMS) * time.Millisecond,
 	}, log)
 	if err != nil {
 		log.Fatal("failed to initialize storage", zap.Error(err))
@@ -51,11 +62,37 @@ func main() {
 		log.Fatal("failed to run migrations", zap.Error(err))
 	}
 
+	// Maintenance subcommands run against the initialized storage and exit
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backup":
+			runBackup(store, cfg, log, os.Args[2:])
+		case "restore":
+			runRestore(store, cfg, log, os.Args[2:])
+		default:
+			log.Fatal("unknown subcommand", zap.String("command", os.Args[1]))
+		}
+		return
+	}
+
+	// The executor picks the default bridge proxy up from the environment
+	if cfg.Agno.Proxy != "" {
+		os.Setenv("XGENT_PROXY", cfg.Agno.Proxy)
+	}
+
 	// Initialize orchestrator
 	orch := orchestrator.New(&orchestrator.Config{
-		Workers:      cfg.Orchestrator.Workers,
-		QueueSize:    cfg.Orchestrator.QueueSize,
-		WorkspaceDir: cfg.Orchestrator.WorkspaceDir,
+		Workers:           cfg.Orchestrator.Workers,
+		QueueSize:         cfg.Orchestrator.QueueSize,
+		WorkspaceDir:      cfg.Orchestrator.WorkspaceDir,
+		QueueBackend:      cfg.Orchestrator.QueueBackend,
+		RecoveryPolicy:    cfg.Orchestrator.RecoveryPolicy,
+		RedisAddr:         fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		RedisPassword:     cfg.Redis.Password,
+		RedisDB:           cfg.Redis.DB,
+		VisibilityTimeout: time.Duration(cfg.Orchestrator.VisibilityTimeout) * time.Second,
+		Attachment:        attachmentConfig(cfg),
+		Embeddings:        embeddingsConfig(cfg),
 	}, store, log)
 
 	// Start orchestrator
@@ -71,6 +108,26 @@ func main() {
 		Mode:         cfg.Server.Mode,
 		JWTSecret:    cfg.Server.JWTSecret,
 		AllowOrigins: cfg.Server.AllowOrigins,
+
+		AccessTokenTTL: time.Duration(cfg.Server.AccessTokenTTLMinutes) * time.Minute,
+
+		GitHubClientID:     cfg.OAuth.GitHub.ClientID,
+		GitHubClientSecret: cfg.OAuth.GitHub.ClientSecret,
+		GitHubRedirectURL:  cfg.OAuth.GitHub.RedirectURL,
+
+		Attachment: attachment.Config{
+			Backend:   cfg.Attachment.Backend,
+			UploadDir: cfg.Attachment.UploadDir,
+			S3: attachment.S3Config{
+				Endpoint:  cfg.Attachment.S3.Endpoint,
+				Region:    cfg.Attachment.S3.Region,
+				Bucket:    cfg.Attachment.S3.Bucket,
+				AccessKey: cfg.Attachment.S3.AccessKey,
+				SecretKey: cfg.Attachment.S3.SecretKey,
+			},
+		},
+
+		Embeddings: embeddingsConfig(cfg),
 	}, store, orch, log)
 
 	// Start server in goroutine
@@ -100,6 +157,9 @@ func main() {
 		log.Error("server forced to shutdown", zap.Error(err))
 	}
 
+	// Flush buffered trace spans before exit
+	tracing.Shutdown(ctx)
+
 	log.Info("Server exited")
 }
 
@@ -111,6 +171,8 @@ type AppConfig struct {
 		Mode         string   `mapstructure:"mode"`
 		JWTSecret    string   `mapstructure:"jwt_secret"`
 		AllowOrigins []string `mapstructure:"allow_origins"`
+
+		AccessTokenTTLMinutes int `mapstructure:"access_token_ttl_minutes"` // 0 uses the default of 24h
 	} `mapstructure:"server"`
 
 	Database struct {
@@ -120,17 +182,63 @@ type AppConfig struct {
 		Database string `mapstructure:"database"`
 		Username string `mapstructure:"username"`
 		Password string `mapstructure:"password"`
+
+		SlowQueryThresholdMS int `mapstructure:"slow_query_threshold_ms"` // 0 uses the default of 200ms
 	} `mapstructure:"database"`
 
+	Security struct {
+		EncryptionKey string `mapstructure:"encryption_key"`
+	} `mapstructure:"security"`
+
+	Attachment struct {
+		Backend   string `mapstructure:"backend"`
+		UploadDir string `mapstructure:"upload_dir"`
+		S3        struct {
+			Endpoint  string `mapstructure:"endpoint"`
+			Region    string `mapstructure:"region"`
+			Bucket    string `mapstructure:"bucket"`
+			AccessKey string `mapstructure:"access_key"`
+			SecretKey string `mapstructure:"secret_key"`
+		} `mapstructure:"s3"`
+	} `mapstructure:"attachment"`
+
+	Embeddings struct {
+		Provider string `mapstructure:"provider"`
+		Model    string `mapstructure:"model"`
+		APIKey   string `mapstructure:"api_key"`
+		BaseURL  string `mapstructure:"base_url"`
+	} `mapstructure:"embeddings"`
+
+	OAuth struct {
+		GitHub struct {
+			ClientID     string `mapstructure:"client_id"`
+			ClientSecret string `mapstructure:"client_secret"`
+			RedirectURL  string `mapstructure:"redirect_url"`
+		} `mapstructure:"github"`
+	} `mapstructure:"oauth"`
+
 	Orchestrator struct {
-		Workers      int    `mapstructure:"workers"`
-		QueueSize    int    `mapstructure:"queue_size"`
-		WorkspaceDir string `mapstructure:"workspace_dir"`
+		Workers           int    `mapstructure:"workers"`
+		QueueSize         int    `mapstructure:"queue_size"`
+		WorkspaceDir      string `mapstructure:"workspace_dir"`
+		QueueBackend      string `mapstructure:"queue_backend"`
+		RecoveryPolicy    string `mapstructure:"recovery_policy"`
+		VisibilityTimeout int    `mapstructure:"visibility_timeout"` // seconds
 	} `mapstructure:"orchestrator"`
 
+	Redis struct {
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Password string `mapstructure:"password"`
+		DB       int    `mapstructure:"db"`
+	} `mapstructure:"redis"`
+
+	Tracing tracing.Config `mapstructure:"tracing"`
+
 	Agno struct {
 		OpenAIKey    string `mapstructure:"openai_key"`
 		AnthropicKey string `mapstructure:"anthropic_key"`
+		Proxy        string `mapstructure:"proxy"`
 	} `mapstructure:"agno"`
 }
 
@@ -145,12 +253,22 @@ func loadConfig() (*AppConfig, error) {
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("server.mode", "debug")
 	viper.SetDefault("server.allow_origins", []string{"*"})
+	viper.SetDefault("attachment.backend", "local")
+	viper.SetDefault("attachment.upload_dir", "/tmp/xgent-uploads")
 	viper.SetDefault("database.driver", "mysql")
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", 3306)
 	viper.SetDefault("orchestrator.workers", 10)
 	viper.SetDefault("orchestrator.queue_size", 100)
 	viper.SetDefault("orchestrator.workspace_dir", "/tmp/xgent-workspaces")
+	viper.SetDefault("orchestrator.queue_backend", "memory")
+	viper.SetDefault("orchestrator.recovery_policy", "requeue")
+	viper.SetDefault("orchestrator.visibility_timeout", 300)
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.endpoint", "http://localhost:4318")
+	viper.SetDefault("tracing.service_name", "xgent")
+	viper.SetDefault("redis.host", "localhost")
+	viper.SetDefault("redis.port", 6379)
 
 	// Read environment variables
 	viper.AutomaticEnv()
@@ -163,8 +281,16 @@ func loadConfig() (*AppConfig, error) {
 	viper.BindEnv("database.username", "DATABASE_USER")
 	viper.BindEnv("database.password", "DATABASE_PASSWORD")
 	viper.BindEnv("server.jwt_secret", "JWT_SECRET")
+	viper.BindEnv("security.encryption_key", "XGENT_ENCRYPTION_KEY")
+	viper.BindEnv("oauth.github.client_id", "GITHUB_CLIENT_ID")
+	viper.BindEnv("oauth.github.client_secret", "GITHUB_CLIENT_SECRET")
+	viper.BindEnv("oauth.github.redirect_url", "GITHUB_REDIRECT_URL")
+	viper.BindEnv("attachment.s3.access_key", "S3_ACCESS_KEY")
+	viper.BindEnv("attachment.s3.secret_key", "S3_SECRET_KEY")
+	viper.BindEnv("embeddings.api_key", "EMBEDDINGS_API_KEY")
 	viper.BindEnv("agno.openai_key", "OPENAI_API_KEY")
 	viper.BindEnv("agno.anthropic_key", "ANTHROPIC_API_KEY")
+	viper.BindEnv("agno.proxy", "XGENT_PROXY")
 
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -180,3 +306,14 @@ func loadConfig() (*AppConfig, error) {
 
 	return &cfg, nil
 }
+
+// embeddingsConfig maps the app config's embeddings section onto an LLM
+// client config
+func embeddingsConfig(cfg *AppConfig) llm.Config {
+	return llm.Config{
+		Provider: cfg.Embeddings.Provider,
+		Model:    cfg.Embeddings.Model,
+		APIKey:   cfg.Embeddings.APIKey,
+		BaseURL:  cfg.Embeddings.BaseURL,
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/viper"
+	"github.com/xcode-ai/xgent-go/internal/llm"
 	"github.com/xcode-ai/xgent-go/internal/orchestrator"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/pkg/logger"
 	"go.uber.org/zap"
@@ -38,16 +41,46 @@ func main() {
 		Database: cfg.Database.Database,
 		Username: cfg.Database.Username,
 		Password: cfg.Database.Password,
+
+		EncryptionKey: cfg.Security.EncryptionKey,
 	}, log)
 	if err != nil {
 		log.Fatal("failed to initialize storage", zap.Error(err))
 	}
 
+	// The executor picks the default bridge proxy up from the environment
+	if cfg.Agno.Proxy != "" {
+		os.Setenv("XGENT_PROXY", cfg.Agno.Proxy)
+	}
+
 	// Initialize orchestrator
 	orch := orchestrator.New(&orchestrator.Config{
-		Workers:      cfg.Orchestrator.Workers,
-		QueueSize:    cfg.Orchestrator.QueueSize,
-		WorkspaceDir: cfg.Orchestrator.WorkspaceDir,
+		Workers:           cfg.Orchestrator.Workers,
+		QueueSize:         cfg.Orchestrator.QueueSize,
+		WorkspaceDir:      cfg.Orchestrator.WorkspaceDir,
+		QueueBackend:      cfg.Orchestrator.QueueBackend,
+		RecoveryPolicy:    cfg.Orchestrator.RecoveryPolicy,
+		RedisAddr:         fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		RedisPassword:     cfg.Redis.Password,
+		RedisDB:           cfg.Redis.DB,
+		VisibilityTimeout: time.Duration(cfg.Orchestrator.VisibilityTimeout) * time.Second,
+		Attachment: attachment.Config{
+			Backend:   cfg.Attachment.Backend,
+			UploadDir: cfg.Attachment.UploadDir,
+			S3: attachment.S3Config{
+				Endpoint:  cfg.Attachment.S3.Endpoint,
+				Region:    cfg.Attachment.S3.Region,
+				Bucket:    cfg.Attachment.S3.Bucket,
+				AccessKey: cfg.Attachment.S3.AccessKey,
+				SecretKey: cfg.Attachment.S3.SecretKey,
+			},
+		},
+		Embeddings: llm.Config{
+			Provider: cfg.Embeddings.Provider,
+			Model:    cfg.Embeddings.Model,
+			APIKey:   cfg.Embeddings.APIKey,
+			BaseURL:  cfg.Embeddings.BaseURL,
+		},
 	}, store, log)
 
 	// Start orchestrator
@@ -80,11 +113,48 @@ type AppConfig struct {
 		Password string `mapstructure:"password"`
 	} `mapstructure:"database"`
 
+	Security struct {
+		EncryptionKey string `mapstructure:"encryption_key"`
+	} `mapstructure:"security"`
+
 	Orchestrator struct {
-		Workers      int    `mapstructure:"workers"`
-		QueueSize    int    `mapstructure:"queue_size"`
-		WorkspaceDir string `mapstructure:"workspace_dir"`
+		Workers           int    `mapstructure:"workers"`
+		QueueSize         int    `mapstructure:"queue_size"`
+		WorkspaceDir      string `mapstructure:"workspace_dir"`
+		QueueBackend      string `mapstructure:"queue_backend"`
+		RecoveryPolicy    string `mapstructure:"recovery_policy"`
+		VisibilityTimeout int    `mapstructure:"visibility_timeout"` // seconds
 	} `mapstructure:"orchestrator"`
+
+	Redis struct {
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Password string `mapstructure:"password"`
+		DB       int    `mapstructure:"db"`
+	} `mapstructure:"redis"`
+
+	Agno struct {
+		Proxy string `mapstructure:"proxy"`
+	} `mapstructure:"agno"`
+
+	Attachment struct {
+		Backend   string `mapstructure:"backend"`
+		UploadDir string `mapstructure:"upload_dir"`
+		S3        struct {
+			Endpoint  string `mapstructure:"endpoint"`
+			Region    string `mapstructure:"region"`
+			Bucket    string `mapstructure:"bucket"`
+			AccessKey string `mapstructure:"access_key"`
+			SecretKey string `mapstructure:"secret_key"`
+		} `mapstructure:"s3"`
+	} `mapstructure:"attachment"`
+
+	Embeddings struct {
+		Provider string `mapstructure:"provider"`
+		Model    string `mapstructure:"model"`
+		APIKey   string `mapstructure:"api_key"`
+		BaseURL  string `mapstructure:"base_url"`
+	} `mapstructure:"embeddings"`
 }
 
 func loadConfig() (*AppConfig, error) {
@@ -100,9 +170,18 @@ func loadConfig() (*AppConfig, error) {
 	viper.SetDefault("orchestrator.workers", 10)
 	viper.SetDefault("orchestrator.queue_size", 100)
 	viper.SetDefault("orchestrator.workspace_dir", "/tmp/xgent-workspaces")
+	viper.SetDefault("orchestrator.queue_backend", "memory")
+	viper.SetDefault("orchestrator.recovery_policy", "requeue")
+	viper.SetDefault("orchestrator.visibility_timeout", 300)
+	viper.SetDefault("redis.host", "localhost")
+	viper.SetDefault("attachment.backend", "local")
+	viper.SetDefault("attachment.upload_dir", "/tmp/xgent-uploads")
+	viper.SetDefault("redis.port", 6379)
 
 	// Read environment variables
 	viper.AutomaticEnv()
+	viper.BindEnv("security.encryption_key", "XGENT_ENCRYPTION_KEY")
+	viper.BindEnv("agno.proxy", "XGENT_PROXY")
 
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
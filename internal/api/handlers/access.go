@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// workspaceRole returns the caller's role on a workspace: owner when they
+// created it, otherwise their membership role. ok is false for non-members.
+func workspaceRole(store *storage.Storage, workspaceID, userID uint) (models.WorkspaceRole, bool) {
+	workspace, err := store.Workspaces().GetByID(workspaceID)
+	if err != nil {
+		return "", false
+	}
+
+	member, err := store.WorkspaceMembers().Get(workspaceID, userID)
+	if err != nil {
+		member = nil
+	}
+	return resolveWorkspaceRole(workspace, member, userID)
+}
+
+// resolveWorkspaceRole decides the caller's role from already-loaded records;
+// member may be nil for non-members
+func resolveWorkspaceRole(workspace *models.Workspace, member *models.WorkspaceMember, userID uint) (models.WorkspaceRole, bool) {
+	if workspace.UserID == userID {
+		return models.WorkspaceRoleOwner, true
+	}
+	if member == nil {
+		return "", false
+	}
+	return member.Role, true
+}
+
+// hasWorkspaceRole reports whether the caller holds at least the required
+// role on a workspace
+func hasWorkspaceRole(store *storage.Storage, workspaceID, userID uint, required models.WorkspaceRole) bool {
+	role, ok := workspaceRole(store, workspaceID, userID)
+	return ok && role.AtLeast(required)
+}
+
+// canViewTask reports whether the caller may read a task: its creator or any
+// workspace member
+func canViewTask(store *storage.Storage, task *models.Task, userID uint) bool {
+	if task.UserID == userID {
+		return true
+	}
+	return hasWorkspaceRole(store, task.WorkspaceID, userID, models.WorkspaceRoleViewer)
+}
+
+// canEditTask reports whether the caller may modify a task: its creator or a
+// workspace editor
+func canEditTask(store *storage.Storage, task *models.Task, userID uint) bool {
+	if task.UserID == userID {
+		return true
+	}
+	return hasWorkspaceRole(store, task.WorkspaceID, userID, models.WorkspaceRoleEditor)
+}
+
+// canViewAttachment reports whether the caller may read an attachment: its
+// uploader, or any member of the workspace whose task it is linked to.
+// Unlinked attachments stay private to the uploader.
+func canViewAttachment(store *storage.Storage, attachment *models.Attachment, userID uint) bool {
+	if attachment.UserID == userID {
+		return true
+	}
+	if attachment.TaskID == nil {
+		return false
+	}
+	task, err := store.Tasks().GetByID(*attachment.TaskID)
+	if err != nil {
+		return false
+	}
+	return canViewTask(store, task, userID)
+}
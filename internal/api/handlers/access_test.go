@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+func TestResolveWorkspaceRole(t *testing.T) {
+	workspace := &models.Workspace{UserID: 1}
+
+	tests := []struct {
+		name     string
+		member   *models.WorkspaceMember
+		userID   uint
+		wantRole models.WorkspaceRole
+		wantOK   bool
+	}{
+		{
+			name:     "workspace creator is owner",
+			userID:   1,
+			wantRole: models.WorkspaceRoleOwner,
+			wantOK:   true,
+		},
+		{
+			name:   "non-member has no role",
+			userID: 2,
+			wantOK: false,
+		},
+		{
+			name:     "member keeps their membership role",
+			member:   &models.WorkspaceMember{WorkspaceID: 10, UserID: 2, Role: models.WorkspaceRoleEditor},
+			userID:   2,
+			wantRole: models.WorkspaceRoleEditor,
+			wantOK:   true,
+		},
+		{
+			name:     "creator outranks a viewer membership",
+			member:   &models.WorkspaceMember{WorkspaceID: 10, UserID: 1, Role: models.WorkspaceRoleViewer},
+			userID:   1,
+			wantRole: models.WorkspaceRoleOwner,
+			wantOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, ok := resolveWorkspaceRole(workspace, tt.member, tt.userID)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && role != tt.wantRole {
+				t.Errorf("role = %q, want %q", role, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestWorkspaceRoleAtLeast(t *testing.T) {
+	tests := []struct {
+		role     models.WorkspaceRole
+		required models.WorkspaceRole
+		want     bool
+	}{
+		{models.WorkspaceRoleOwner, models.WorkspaceRoleEditor, true},
+		{models.WorkspaceRoleEditor, models.WorkspaceRoleEditor, true},
+		{models.WorkspaceRoleEditor, models.WorkspaceRoleViewer, true},
+		{models.WorkspaceRoleViewer, models.WorkspaceRoleEditor, false},
+		{models.WorkspaceRoleViewer, models.WorkspaceRoleOwner, false},
+		{"", models.WorkspaceRoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.AtLeast(tt.required); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/orchestrator"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
+	"go.uber.org/zap"
+)
+
+// AdminHandler handles administrator-only requests with cross-user
+// visibility
+type AdminHandler struct {
+	storage      *storage.Storage
+	orchestrator *orchestrator.Orchestrator
+	logger       *zap.Logger
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(storage *storage.Storage, orch *orchestrator.Orchestrator, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		storage:      storage,
+		orchestrator: orch,
+		logger:       logger,
+	}
+}
+
+// ListUsers retrieves all registered users
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	users, err := h.storage.Users().List(limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to list users", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// ListTasks retrieves tasks across all users and workspaces
+func (h *AdminHandler) ListTasks(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter := repositories.TaskFilter{
+		Status: models.TaskStatus(c.Query("status")),
+	}
+
+	tasks, err := h.storage.Tasks().Search(filter, limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to list tasks", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tasks": tasks})
+}
+
+// CancelTask force-cancels any user's task
+func (h *AdminHandler) CancelTask(c *gin.Context) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	if err := h.orchestrator.CancelTask(uint(taskID)); err != nil {
+		h.logger.Error("Failed to cancel task", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel task"})
+		return
+	}
+
+	task.Status = models.TaskStatusCancelled
+	h.storage.Tasks().Update(task)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task cancelled"})
+}
+
+// RequeueTask puts a dead-lettered or failed task back on the queue with a
+// fresh attempt budget
+func (h *AdminHandler) RequeueTask(c *gin.Context) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	if task.Status != models.TaskStatusDeadLetter && task.Status != models.TaskStatusFailed {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only dead-lettered or failed tasks can be requeued"})
+		return
+	}
+
+	task.Status = models.TaskStatusPending
+	task.Progress = 0
+	task.Attempts = 0
+	task.Error = ""
+	task.Result = ""
+	if err := h.storage.Tasks().Update(task); err != nil {
+		h.logger.Error("Failed to reset task", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to requeue task"})
+		return
+	}
+
+	if err := h.orchestrator.SubmitTask(task, nil); err != nil {
+		h.logger.Error("Failed to requeue task", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to requeue task"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task requeued", "task": task})
+}
+
+// workerStatus is a registry row plus liveness derived from its heartbeat
+type workerStatus struct {
+	*models.Worker
+	Alive bool `json:"alive"`
+}
+
+// ListWorkers reports registered worker processes and whether each one's
+// heartbeat is current
+func (h *AdminHandler) ListWorkers(c *gin.Context) {
+	workers, err := h.storage.Workers().List()
+	if err != nil {
+		h.logger.Error("Failed to list workers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list workers"})
+		return
+	}
+
+	cutoff := time.Now().Add(-orchestrator.WorkerStaleAfter)
+	statuses := make([]workerStatus, 0, len(workers))
+	for _, worker := range workers {
+		statuses = append(statuses, workerStatus{
+			Worker: worker,
+			Alive:  worker.LastHeartbeat.After(cutoff),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workers": statuses,
+		"total":   len(statuses),
+	})
+}
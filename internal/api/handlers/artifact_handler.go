@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"go.uber.org/zap"
+)
+
+// ArtifactHandler serves task output files
+type ArtifactHandler struct {
+	storage *storage.Storage
+	blobs   attachment.BlobStore
+	logger  *zap.Logger
+}
+
+// NewArtifactHandler creates a new artifact handler
+func NewArtifactHandler(storage *storage.Storage, blobs attachment.BlobStore, logger *zap.Logger) *ArtifactHandler {
+	return &ArtifactHandler{
+		storage: storage,
+		blobs:   blobs,
+		logger:  logger,
+	}
+}
+
+// List returns the artifacts produced by a task
+func (h *ArtifactHandler) List(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	if !canViewTask(h.storage, task, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	artifacts, err := h.storage.Artifacts().ListByTask(uint(taskID))
+	if err != nil {
+		h.logger.Error("Failed to list artifacts", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list artifacts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"artifacts": artifacts})
+}
+
+// Download streams one artifact file. S3-backed storage redirects to a
+// presigned URL instead of proxying the bytes.
+func (h *ArtifactHandler) Download(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+	artifactID, err := strconv.ParseUint(c.Param("artifact_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid artifact ID"})
+		return
+	}
+
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	if !canViewTask(h.storage, task, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	artifact, err := h.storage.Artifacts().GetByID(uint(artifactID))
+	if err != nil || artifact.TaskID != uint(taskID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Artifact not found"})
+		return
+	}
+
+	if url, ok := h.blobs.PresignURL(artifact.StoragePath, artifact.Name, 15*time.Minute); ok {
+		c.Redirect(http.StatusTemporaryRedirect, url)
+		return
+	}
+
+	reader, err := h.blobs.Open(artifact.StoragePath)
+	if err != nil {
+		h.logger.Error("Failed to open artifact blob",
+			zap.Uint("artifact_id", artifact.ID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read artifact"})
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", artifact.Name))
+	c.Header("Content-Length", strconv.FormatInt(artifact.FileSize, 10))
+	c.Header("Content-Type", artifact.MimeType)
+	io.Copy(c.Writer, reader)
+}
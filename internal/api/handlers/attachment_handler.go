@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
 	"github.com/xcode-ai/xgent-go/internal/services/attachment"
 	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"go.uber.org/zap"
 )
 
@@ -27,26 +29,124 @@ func NewAttachmentHandler(storage *storage.Storage, attachmentService *attachmen
 	}
 }
 
-// Upload handles file upload
+// Upload handles file upload. Multiple files go in the "files" field; the
+// single "file" field is kept for older clients and answers with the bare
+// attachment object. An optional task_id field links every upload to a task.
 func (h *AttachmentHandler) Upload(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 
-	// Get uploaded file
-	file, err := c.FormFile("file")
+	var taskID *uint
+	if value := c.PostForm("task_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+			return
+		}
+		linked := uint(id)
+		taskID = &linked
+	}
+
+	form, err := c.MultipartForm()
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
 		return
 	}
+	files := form.File["files"]
+	single := len(files) == 0
+	if single {
+		files = form.File["file"]
+	}
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+
+	attachments := make([]*models.Attachment, 0, len(files))
+	for _, file := range files {
+		attachment, err := h.attachmentService.Upload(file, userID, taskID)
+		if err != nil {
+			h.logger.Error("Failed to upload file",
+				zap.String("filename", file.Filename), zap.Error(err))
+			// Files uploaded before the failure stay; report them so the
+			// client does not re-send the whole batch
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":    fmt.Sprintf("%s: %s", file.Filename, err.Error()),
+				"uploaded": attachments,
+			})
+			return
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if single {
+		c.JSON(http.StatusCreated, attachments[0])
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"attachments": attachments})
+}
 
-	// Upload file
-	attachment, err := h.attachmentService.Upload(file, userID)
+// ListByTask retrieves the attachments linked to a task the user owns
+func (h *AttachmentHandler) ListByTask(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		h.logger.Error("Failed to upload file", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, attachment)
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	if !canViewTask(h.storage, task, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	attachments, err := h.storage.Attachments().ListByTask(uint(taskID))
+	if err != nil {
+		h.logger.Error("Failed to list task attachments", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list attachments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+// ListBySubtask retrieves the attachments linked to a subtask of a task the
+// user owns
+func (h *AttachmentHandler) ListBySubtask(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	subtaskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subtask ID"})
+		return
+	}
+
+	subtask, err := h.storage.Tasks().GetSubTask(uint(subtaskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Subtask not found"})
+		return
+	}
+	task, err := h.storage.Tasks().GetByID(subtask.TaskID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	if !canViewTask(h.storage, task, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	attachments, err := h.storage.Attachments().ListBySubtask(uint(subtaskID))
+	if err != nil {
+		h.logger.Error("Failed to list subtask attachments", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list attachments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
 }
 
 // Get retrieves an attachment by ID
@@ -64,8 +164,8 @@ func (h *AttachmentHandler) Get(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if attachment.UserID != userID {
+	// Check access: uploader or a member of the linked task's workspace
+	if !canViewAttachment(h.storage, attachment, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -82,6 +182,12 @@ func (h *AttachmentHandler) Download(c *gin.Context) {
 		return
 	}
 
+	// Redirect to a presigned URL when the storage backend supports it
+	if url, ok, err := h.attachmentService.DownloadURL(uint(attachmentID), userID); err == nil && ok {
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
 	data, filename, err := h.attachmentService.GetFile(uint(attachmentID), userID)
 	if err != nil {
 		h.logger.Error("Failed to get file", zap.Error(err))
@@ -108,8 +214,8 @@ func (h *AttachmentHandler) GetContent(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if attachment.UserID != userID {
+	// Check access: uploader or a member of the linked task's workspace
+	if !canViewAttachment(h.storage, attachment, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -140,7 +246,7 @@ func (h *AttachmentHandler) List(c *gin.Context) {
 		}
 	}
 
-	attachments, err := h.storage.Attachments().ListByUser(userID, limit, offset)
+	attachments, err := h.storage.Scoped(c.Request.Context()).Attachments().ListByUser(userID, limit, offset)
 	if err != nil {
 		h.logger.Error("Failed to list attachments", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list attachments"})
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
+	"go.uber.org/zap"
+)
+
+// recordAudit appends one audit log entry for the current request, filling
+// in the actor and request ID from context. Audit failures are logged but
+// never surfaced to the client: the action itself already succeeded.
+func recordAudit(s *storage.Storage, logger *zap.Logger, c *gin.Context, workspaceID uint, action, objectType string, objectID uint, objectName, summary string) {
+	userID, _ := middleware.GetUserID(c)
+
+	entry := &models.AuditLog{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Action:      action,
+		ObjectType:  objectType,
+		ObjectID:    objectID,
+		ObjectName:  objectName,
+		Summary:     summary,
+		RequestID:   middleware.GetRequestID(c),
+	}
+	if err := s.Audits().Create(entry); err != nil {
+		logger.Error("Failed to write audit log entry",
+			zap.String("action", action), zap.Error(err))
+	}
+}
+
+// AuditHandler serves the audit trail to administrators
+type AuditHandler struct {
+	storage *storage.Storage
+	logger  *zap.Logger
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(storage *storage.Storage, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		storage: storage,
+		logger:  logger,
+	}
+}
+
+// List retrieves audit entries, newest first, filtered by the query
+// parameters user_id, workspace_id, action, object_type, since and until
+func (h *AuditHandler) List(c *gin.Context) {
+	var filter repositories.AuditLogFilter
+
+	if idStr := c.Query("user_id"); idStr != "" {
+		if id, err := strconv.ParseUint(idStr, 10, 32); err == nil {
+			filter.UserID = uint(id)
+		}
+	}
+	if idStr := c.Query("workspace_id"); idStr != "" {
+		if id, err := strconv.ParseUint(idStr, 10, 32); err == nil {
+			filter.WorkspaceID = uint(id)
+		}
+	}
+	filter.Action = c.Query("action")
+	filter.ObjectType = c.Query("object_type")
+
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be RFC3339"})
+			return
+		}
+		filter.CreatedAfter = parsed
+	}
+	if until := c.Query("until"); until != "" {
+		parsed, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be RFC3339"})
+			return
+		}
+		filter.CreatedBefore = parsed
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	entries, err := h.storage.Audits().List(filter, limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to list audit entries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit entries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
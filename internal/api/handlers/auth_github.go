@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	githubAuthorizeURL = "https://github.com/login/oauth/authorize"
+	githubTokenURL     = "https://github.com/login/oauth/access_token"
+	githubUserURL      = "https://api.github.com/user"
+
+	oauthStateTTL = 10 * time.Minute
+)
+
+// GitHubOAuthConfig contains GitHub OAuth application credentials
+type GitHubOAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// ConfigureGitHub enables the GitHub OAuth login flow
+func (h *AuthHandler) ConfigureGitHub(cfg GitHubOAuthConfig) {
+	h.github = &cfg
+}
+
+// oauthStates tracks pending OAuth state tokens to prevent CSRF on the
+// callback. States expire after a few minutes.
+var oauthStates = struct {
+	sync.Mutex
+	pending map[string]time.Time
+}{pending: map[string]time.Time{}}
+
+// githubUser is the subset of the GitHub user API response we consume
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// GitHubLogin redirects to GitHub's OAuth authorization page
+func (h *AuthHandler) GitHubLogin(c *gin.Context) {
+	if h.github == nil || h.github.ClientID == "" {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "GitHub OAuth is not configured"})
+		return
+	}
+
+	state := newOAuthState()
+
+	params := url.Values{}
+	params.Set("client_id", h.github.ClientID)
+	params.Set("redirect_uri", h.github.RedirectURL)
+	params.Set("scope", "read:user user:email")
+	params.Set("state", state)
+
+	c.Redirect(http.StatusFound, githubAuthorizeURL+"?"+params.Encode())
+}
+
+// GitHubCallback completes the OAuth dance: it exchanges the code for an
+// access token, fetches the GitHub profile, creates or links the local user
+// (storing the token encrypted) and returns a JWT
+func (h *AuthHandler) GitHubCallback(c *gin.Context) {
+	if h.github == nil || h.github.ClientID == "" {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "GitHub OAuth is not configured"})
+		return
+	}
+
+	if !consumeOAuthState(c.Query("state")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired OAuth state"})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing authorization code"})
+		return
+	}
+
+	accessToken, err := h.exchangeGitHubCode(code)
+	if err != nil {
+		h.logger.Error("GitHub code exchange failed", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to exchange authorization code"})
+		return
+	}
+
+	profile, err := fetchGitHubUser(accessToken)
+	if err != nil {
+		h.logger.Error("Failed to fetch GitHub profile", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch GitHub profile"})
+		return
+	}
+
+	user, err := h.findOrCreateGitHubUser(profile, accessToken)
+	if err != nil {
+		h.logger.Error("Failed to create GitHub user", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		return
+	}
+
+	token, refreshToken, expiresAt, err := h.generateToken(c, user)
+	if err != nil {
+		h.logger.Error("Failed to generate token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		User: &UserInfo{
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+		},
+	})
+}
+
+// exchangeGitHubCode trades an authorization code for an access token
+func (h *AuthHandler) exchangeGitHubCode(code string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", h.github.ClientID)
+	form.Set("client_secret", h.github.ClientSecret)
+	form.Set("code", code)
+	form.Set("redirect_uri", h.github.RedirectURL)
+
+	req, err := http.NewRequest(http.MethodPost, githubTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.AccessToken == "" {
+		return "", fmt.Errorf("github rejected code exchange: %s", body.Error)
+	}
+	return body.AccessToken, nil
+}
+
+// fetchGitHubUser retrieves the authenticated user's GitHub profile
+func fetchGitHubUser(accessToken string) (*githubUser, error) {
+	req, err := http.NewRequest(http.MethodGet, githubUserURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github user API returned status %d", resp.StatusCode)
+	}
+
+	var profile githubUser
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// findOrCreateGitHubUser resolves a GitHub profile to a local user: by GitID
+// first, then by email (linking an existing password account), creating a
+// fresh account otherwise. The access token is stored on the user and
+// encrypted at rest by the model layer.
+func (h *AuthHandler) findOrCreateGitHubUser(profile *githubUser, accessToken string) (*models.User, error) {
+	gitID := fmt.Sprintf("%d", profile.ID)
+
+	user, err := h.storage.Users().GetByGitID(gitID)
+	if err != nil && profile.Email != "" {
+		if existing, emailErr := h.storage.Users().GetByEmail(profile.Email); emailErr == nil {
+			user = existing
+		}
+	}
+
+	if user == nil {
+		username := profile.Login
+		if _, err := h.storage.Users().GetByUsername(username); err == nil {
+			username = fmt.Sprintf("%s-gh%s", profile.Login, gitID)
+		}
+		email := profile.Email
+		if email == "" {
+			email = fmt.Sprintf("%s@users.noreply.github.com", profile.Login)
+		}
+
+		user = &models.User{
+			Username: username,
+			Email:    email,
+		}
+	}
+
+	user.GitID = gitID
+	user.GitLogin = profile.Login
+	user.GitEmail = profile.Email
+	user.GitAvatar = profile.AvatarURL
+	user.GitToken = accessToken
+
+	if user.ID == 0 {
+		return user, h.storage.Users().Create(user)
+	}
+	return user, h.storage.Users().Update(user)
+}
+
+// newOAuthState generates and registers a random state token
+func newOAuthState() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	state := hex.EncodeToString(buf)
+
+	oauthStates.Lock()
+	defer oauthStates.Unlock()
+	for s, created := range oauthStates.pending {
+		if time.Since(created) > oauthStateTTL {
+			delete(oauthStates.pending, s)
+		}
+	}
+	oauthStates.pending[state] = time.Now()
+	return state
+}
+
+// consumeOAuthState validates and invalidates a state token
+func consumeOAuthState(state string) bool {
+	if state == "" {
+		return false
+	}
+	oauthStates.Lock()
+	defer oauthStates.Unlock()
+	created, ok := oauthStates.pending[state]
+	delete(oauthStates.pending, state)
+	return ok && time.Since(created) <= oauthStateTTL
+}
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
@@ -13,10 +18,16 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// refreshTokenTTL is how long a refresh token stays usable; it slides
+// forward on every rotation
+const refreshTokenTTL = 30 * 24 * time.Hour
+
 // AuthHandler handles authentication requests
 type AuthHandler struct {
 	storage   *storage.Storage
 	jwtSecret string
+	accessTTL time.Duration
+	github    *GitHubOAuthConfig
 	logger    *zap.Logger
 }
 
@@ -25,10 +36,18 @@ func NewAuthHandler(storage *storage.Storage, jwtSecret string, logger *zap.Logg
 	return &AuthHandler{
 		storage:   storage,
 		jwtSecret: jwtSecret,
+		accessTTL: 24 * time.Hour,
 		logger:    logger,
 	}
 }
 
+// SetAccessTokenTTL overrides the default 24h access token lifetime
+func (h *AuthHandler) SetAccessTokenTTL(ttl time.Duration) {
+	if ttl > 0 {
+		h.accessTTL = ttl
+	}
+}
+
 // RegisterRequest represents registration request
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=50"`
@@ -44,9 +63,10 @@ type LoginRequest struct {
 
 // AuthResponse represents authentication response
 type AuthResponse struct {
-	Token     string      `json:"token"`
-	ExpiresAt time.Time   `json:"expires_at"`
-	User      *UserInfo   `json:"user"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	User         *UserInfo `json:"user"`
 }
 
 // UserInfo represents user information
@@ -98,7 +118,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Generate token
-	token, expiresAt, err := h.generateToken(user)
+	token, refreshToken, expiresAt, err := h.generateToken(c, user)
 	if err != nil {
 		h.logger.Error("Failed to generate token", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
@@ -106,8 +126,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
 		User: &UserInfo{
 			ID:       user.ID,
 			Username: user.Username,
@@ -138,7 +159,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Generate token
-	token, expiresAt, err := h.generateToken(user)
+	token, refreshToken, expiresAt, err := h.generateToken(c, user)
 	if err != nil {
 		h.logger.Error("Failed to generate token", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
@@ -146,8 +167,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
 		User: &UserInfo{
 			ID:       user.ID,
 			Username: user.Username,
@@ -156,25 +178,292 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
-// generateToken generates a JWT token
-func (h *AuthHandler) generateToken(user *models.User) (string, time.Time, error) {
-	expiresAt := time.Now().Add(24 * time.Hour)
+// generateToken generates a full-access JWT plus a rotating refresh token
+// and records their session. The refresh token is best-effort: when its
+// session row cannot be written the access token alone is returned.
+func (h *AuthHandler) generateToken(c *gin.Context, user *models.User) (token, refreshToken string, expiresAt time.Time, err error) {
+	token, expiresAt, session, err := h.generateScopedToken(c, user, nil, h.accessTTL)
+	if err != nil || session == nil {
+		return token, "", expiresAt, err
+	}
+
+	refreshToken, refreshErr := h.issueRefreshToken(session)
+	if refreshErr != nil {
+		h.logger.Warn("Failed to issue refresh token", zap.Error(refreshErr))
+		return token, "", expiresAt, nil
+	}
+	return token, refreshToken, expiresAt, nil
+}
+
+// generateScopedToken generates a JWT token limited to the given scopes
+// (empty means full access) and records a login session for it so the token
+// can be listed and revoked later. The session is nil when recording it
+// failed.
+func (h *AuthHandler) generateScopedToken(c *gin.Context, user *models.User, scopes []string, ttl time.Duration) (string, time.Time, *models.LoginSession, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	tokenID := uuid.New().String()
 
 	claims := &middleware.Claims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Email:    user.Email,
+		Scopes:   scopes,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(h.jwtSecret))
 	if err != nil {
-		return "", time.Time{}, err
+		return "", time.Time{}, nil, err
+	}
+
+	session := &models.LoginSession{
+		UserID:     user.ID,
+		TokenID:    tokenID,
+		UserAgent:  c.Request.UserAgent(),
+		IP:         c.ClientIP(),
+		LastSeenAt: now,
+		ExpiresAt:  expiresAt,
+	}
+	if err := h.storage.LoginSessions().Create(session); err != nil {
+		// Tokens without a session row stay valid; tracking is best effort
+		h.logger.Warn("Failed to record login session", zap.Error(err))
+		session = nil
+	}
+
+	return tokenString, expiresAt, session, nil
+}
+
+// issueRefreshToken rotates the session's refresh token: a fresh random
+// token is returned to the client and only its hash is stored
+func (h *AuthHandler) issueRefreshToken(session *models.LoginSession) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	refreshToken := hex.EncodeToString(buf)
+
+	refreshExpiry := time.Now().Add(refreshTokenTTL)
+	session.RefreshHash = hashRefreshToken(refreshToken)
+	session.RefreshExpiresAt = &refreshExpiry
+	if err := h.storage.LoginSessions().Update(session); err != nil {
+		return "", err
+	}
+	return refreshToken, nil
+}
+
+// hashRefreshToken hashes a refresh token for at-rest storage and lookup
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshRequest is the payload for exchanging a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Refresh exchanges a valid refresh token for a fresh access/refresh token
+// pair. Tokens rotate: the presented refresh token is invalidated and the
+// session row carries the new pair, so session listing and revocation keep
+// working across refreshes.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.storage.LoginSessions().GetByRefreshHash(hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+	if session.RevokedAt != nil ||
+		session.RefreshExpiresAt == nil || time.Now().After(*session.RefreshExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token expired or revoked"})
+		return
+	}
+
+	user, err := h.storage.Users().GetByID(session.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+
+	// Mint the new access token on the same session, replacing its jti
+	now := time.Now()
+	expiresAt := now.Add(h.accessTTL)
+	tokenID := uuid.New().String()
+
+	claims := &middleware.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(h.jwtSecret))
+	if err != nil {
+		h.logger.Error("Failed to generate token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	session.TokenID = tokenID
+	session.LastSeenAt = now
+	session.ExpiresAt = expiresAt
+	session.IP = c.ClientIP()
+
+	refreshToken, err := h.issueRefreshToken(session)
+	if err != nil {
+		h.logger.Error("Failed to rotate refresh token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate refresh token"})
+		return
 	}
 
-	return tokenString, expiresAt, nil
+	c.JSON(http.StatusOK, AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		User: &UserInfo{
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+		},
+	})
+}
+
+// Logout revokes the caller's current session, killing both the access
+// token and its refresh token
+func (h *AuthHandler) Logout(c *gin.Context) {
+	tokenID := middleware.GetTokenID(c)
+	if tokenID == "" {
+		// Tokens issued before session tracking have nothing to revoke
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+		return
+	}
+
+	session, err := h.storage.LoginSessions().GetByTokenID(tokenID)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+		return
+	}
+
+	if err := h.storage.LoginSessions().Revoke(session.ID); err != nil {
+		h.logger.Error("Failed to revoke session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// ListSessions lists the caller's active login sessions
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	sessions, err := h.storage.LoginSessions().ListByUser(userID)
+	if err != nil {
+		h.logger.Error("Failed to list sessions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// RevokeSession revokes one of the caller's login sessions, killing its token
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	session, err := h.storage.LoginSessions().GetByID(uint(sessionID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if session.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if err := h.storage.LoginSessions().Revoke(session.ID); err != nil {
+		h.logger.Error("Failed to revoke session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// MintTokenRequest is the payload for minting a scoped integration token
+type MintTokenRequest struct {
+	Scopes    []string `json:"scopes" binding:"required,min=1"`
+	ExpiresIn int      `json:"expires_in,omitempty"` // seconds, default 30 days
+}
+
+// MintToken issues a least-privilege token carrying only the requested
+// scopes, for handing to integrations
+func (h *AuthHandler) MintToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	// Only full-access tokens may mint new tokens
+	if len(middleware.GetScopes(c)) > 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Scoped tokens cannot mint tokens"})
+		return
+	}
+
+	var req MintTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !middleware.IsKnownScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown scope: " + scope})
+			return
+		}
+	}
+
+	ttl := 30 * 24 * time.Hour
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+		if ttl > 90*24*time.Hour {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in may not exceed 90 days"})
+			return
+		}
+	}
+
+	user, err := h.storage.Users().GetByID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	token, expiresAt, _, err := h.generateScopedToken(c, user, req.Scopes, ttl)
+	if err != nil {
+		h.logger.Error("Failed to generate token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":      token,
+		"scopes":     req.Scopes,
+		"expires_at": expiresAt,
+	})
 }
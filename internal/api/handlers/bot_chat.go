@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// chatHistoryLimit caps how many persisted messages are replayed into the
+// model on each turn
+const chatHistoryLimit = 50
+
+// ChatRequest is one conversation turn against a robot
+type ChatRequest struct {
+	Message     string `json:"message" binding:"required"`
+	SessionID   string `json:"session_id,omitempty"` // empty starts a new conversation
+	WorkspaceID uint   `json:"workspace_id,omitempty"`
+}
+
+// ChatResponse carries the assistant's reply for a turn
+type ChatResponse struct {
+	SessionID string `json:"session_id"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+}
+
+// chatTurn resolves the robot, session and replayed history for a chat
+// request, persisting the user's message. It returns the LLM client and the
+// message list ready for the model.
+func (h *BotHandler) chatTurn(c *gin.Context) (llm.Client, *models.Session, []llm.Message, bool) {
+	userID, _ := middleware.GetUserID(c)
+	botName := c.Param("name")
+
+	var req ChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil, nil, false
+	}
+
+	// Use the active workspace if not specified
+	workspaceID := req.WorkspaceID
+	if workspaceID == 0 {
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, nil, nil, false
+		}
+		workspaceID = id
+	}
+	if !hasWorkspaceRole(h.storage, workspaceID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return nil, nil, nil, false
+	}
+
+	// Load the robot and its soul and mind
+	robotResource, err := h.storage.Resources().GetByName(workspaceID, botName, models.ResourceTypeRobot)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bot not found"})
+		return nil, nil, nil, false
+	}
+
+	parser := crd.NewParser()
+	resource, err := parser.Parse([]byte(robotResource.Spec))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid robot spec"})
+		return nil, nil, nil, false
+	}
+	robot, ok := resource.(*crd.Robot)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid robot resource"})
+		return nil, nil, nil, false
+	}
+
+	soulResource, err := h.storage.Resources().GetByName(workspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load soul"})
+		return nil, nil, nil, false
+	}
+	soulDef, err := parser.Parse([]byte(soulResource.Spec))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid soul spec"})
+		return nil, nil, nil, false
+	}
+	soul := soulDef.(*crd.Soul)
+
+	mindResource, err := h.storage.Resources().GetByName(workspaceID, robot.Spec.Mind, models.ResourceTypeMind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load mind"})
+		return nil, nil, nil, false
+	}
+	mindDef, err := parser.Parse([]byte(mindResource.Spec))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid mind spec"})
+		return nil, nil, nil, false
+	}
+	mind := mindDef.(*crd.Mind)
+
+	client, err := h.executor.LLMClient(workspaceID, mind)
+	if err != nil {
+		h.logger.Error("Failed to create LLM client", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, nil, nil, false
+	}
+
+	// Resolve or create the conversation session
+	var session *models.Session
+	if req.SessionID != "" {
+		session, err = h.storage.Sessions().GetBySessionID(req.SessionID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+			return nil, nil, nil, false
+		}
+		if session.UserID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return nil, nil, nil, false
+		}
+	} else {
+		session = &models.Session{
+			SessionID: fmt.Sprintf("chat-%s-%s", botName, uuid.New().String()),
+			UserID:    userID,
+			AgentID:   botName,
+		}
+		if err := h.storage.Sessions().Create(session); err != nil {
+			h.logger.Error("Failed to create session", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
+			return nil, nil, nil, false
+		}
+	}
+
+	// Persist the user's turn, then replay history into the model
+	if err := h.storage.Sessions().AddMessage(&models.Message{
+		SessionID: session.SessionID,
+		Role:      "user",
+		Content:   req.Message,
+	}); err != nil {
+		h.logger.Error("Failed to persist message", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to persist message"})
+		return nil, nil, nil, false
+	}
+
+	// Replay the running summary plus the turns it does not cover yet, so
+	// long conversations stay inside the model's context window
+	history, err := h.storage.Sessions().GetMessagesAfter(session.SessionID, session.SummarizedUpTo, chatHistoryLimit)
+	if err != nil {
+		h.logger.Error("Failed to load history", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load history"})
+		return nil, nil, nil, false
+	}
+
+	messages := make([]llm.Message, 0, len(history)+2)
+	messages = append(messages, llm.Message{Role: "system", Content: soul.Spec.Personality})
+	if session.Summary != "" {
+		messages = append(messages, llm.Message{Role: "system", Content: "Summary of the earlier conversation:\n" + session.Summary})
+	}
+	for _, msg := range history {
+		messages = append(messages, llm.Message{Role: msg.Role, Content: msg.Content})
+	}
+
+	return client, session, messages, true
+}
+
+// Chat handles one conversation turn and returns the full assistant reply
+func (h *BotHandler) Chat(c *gin.Context) {
+	client, session, messages, ok := h.chatTurn(c)
+	if !ok {
+		return
+	}
+
+	response, err := client.Chat(c.Request.Context(), messages)
+	if err != nil {
+		h.logger.Error("LLM call failed", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.Sessions().AddMessage(&models.Message{
+		SessionID: session.SessionID,
+		Role:      "assistant",
+		Content:   response.Content,
+	}); err != nil {
+		h.logger.Warn("Failed to persist assistant message", zap.Error(err))
+	}
+	h.executor.CompactSessionAsync(client, session.SessionID)
+
+	c.JSON(http.StatusOK, ChatResponse{
+		SessionID: session.SessionID,
+		Role:      "assistant",
+		Content:   response.Content,
+	})
+}
+
+// ChatStream handles one conversation turn, streaming the assistant reply as
+// server-sent events and persisting the assembled message at the end
+func (h *BotHandler) ChatStream(c *gin.Context) {
+	client, session, messages, ok := h.chatTurn(c)
+	if !ok {
+		return
+	}
+
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	flusher, _ := c.Writer.(http.Flusher)
+
+	writeEvent := func(payload interface{}) {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	writeEvent(gin.H{"session_id": session.SessionID})
+
+	var content string
+	err := client.Stream(c.Request.Context(), messages, func(chunk string) error {
+		content += chunk
+		writeEvent(gin.H{"delta": chunk})
+		return nil
+	})
+	if err != nil {
+		writeEvent(gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storage.Sessions().AddMessage(&models.Message{
+		SessionID: session.SessionID,
+		Role:      "assistant",
+		Content:   content,
+	}); err != nil {
+		h.logger.Warn("Failed to persist assistant message", zap.Error(err))
+	}
+	h.executor.CompactSessionAsync(client, session.SessionID)
+
+	fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
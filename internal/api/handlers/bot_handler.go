@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/executor"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"go.uber.org/zap"
@@ -12,15 +13,17 @@ import (
 
 // BotHandler handles bot-related requests
 type BotHandler struct {
-	storage *storage.Storage
-	logger  *zap.Logger
+	storage  *storage.Storage
+	executor *executor.Executor
+	logger   *zap.Logger
 }
 
 // NewBotHandler creates a new bot handler
 func NewBotHandler(storage *storage.Storage, logger *zap.Logger) *BotHandler {
 	return &BotHandler{
-		storage: storage,
-		logger:  logger,
+		storage:  storage,
+		executor: executor.New(storage, logger),
+		logger:   logger,
 	}
 }
 
@@ -28,15 +31,15 @@ func NewBotHandler(storage *storage.Storage, logger *zap.Logger) *BotHandler {
 func (h *BotHandler) List(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 
-	// Get default workspace
-	workspaces, err := h.storage.Workspaces().ListByUser(userID)
-	if err != nil || len(workspaces) == 0 {
+	// Resolve the active workspace
+	workspaceID, err := activeWorkspace(h.storage, c, userID)
+	if err != nil {
 		c.JSON(http.StatusOK, gin.H{"bots": []models.Resource{}})
 		return
 	}
 
 	// Get all bot resources
-	bots, err := h.storage.Resources().List(workspaces[0].ID, models.ResourceTypeRobot, 100, 0)
+	bots, err := h.storage.Resources().List(workspaceID, models.ResourceTypeRobot, 100, 0)
 	if err != nil {
 		h.logger.Error("Failed to list bots", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list bots"})
@@ -51,15 +54,15 @@ func (h *BotHandler) Get(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 	botName := c.Param("name")
 
-	// Get default workspace
-	workspaces, err := h.storage.Workspaces().ListByUser(userID)
-	if err != nil || len(workspaces) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No workspace found"})
+	// Resolve the active workspace
+	workspaceID, err := activeWorkspace(h.storage, c, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Get bot resource
-	bot, err := h.storage.Resources().GetByName(workspaces[0].ID, botName, models.ResourceTypeRobot)
+	bot, err := h.storage.Resources().GetByName(workspaceID, botName, models.ResourceTypeRobot)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Bot not found"})
 		return
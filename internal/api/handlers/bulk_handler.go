@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/executor"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
+	"go.uber.org/zap"
+)
+
+// bulkFilterLimit bounds how many items one filter-based bulk request may
+// touch, so a broad filter cannot wipe a workspace in one call
+const bulkFilterLimit = 500
+
+var (
+	errAlreadyFinished = errors.New("task already finished")
+	errNotRetryable    = errors.New("only failed, cancelled or dead-lettered tasks can be retried")
+)
+
+// BulkTaskRequest applies one action to many tasks, selected either by
+// explicit IDs or by a filter
+type BulkTaskRequest struct {
+	Action string          `json:"action" binding:"required,oneof=delete cancel retry"`
+	IDs    []uint          `json:"ids"`
+	Filter *BulkTaskFilter `json:"filter"`
+}
+
+// BulkTaskFilter selects tasks for a bulk action
+type BulkTaskFilter struct {
+	WorkspaceID  uint   `json:"workspace_id"`
+	Status       string `json:"status"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+}
+
+// BulkResult reports the outcome for one item of a bulk request
+type BulkResult struct {
+	ID    uint   `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// Bulk applies delete, cancel or retry to many tasks at once, returning
+// per-item results so partial failures are visible
+func (h *TaskHandler) Bulk(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req BulkTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.IDs) == 0 && req.Filter == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids or filter required"})
+		return
+	}
+
+	tasks, results := h.resolveBulkTasks(&req, userID)
+
+	succeeded := 0
+	for _, task := range tasks {
+		result := BulkResult{ID: task.ID}
+
+		if !canEditTask(h.storage, task, userID) {
+			result.Error = "access denied"
+			results = append(results, result)
+			continue
+		}
+
+		if err := h.applyBulkTaskAction(c, req.Action, task); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.OK = true
+			succeeded++
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
+
+// resolveBulkTasks loads the tasks a bulk request targets. Unknown IDs
+// become failed results; filters are capped at bulkFilterLimit items.
+func (h *TaskHandler) resolveBulkTasks(req *BulkTaskRequest, userID uint) ([]*models.Task, []BulkResult) {
+	var results []BulkResult
+
+	if len(req.IDs) > 0 {
+		tasks := make([]*models.Task, 0, len(req.IDs))
+		for _, id := range req.IDs {
+			task, err := h.storage.Tasks().GetByID(id)
+			if err != nil {
+				results = append(results, BulkResult{ID: id, Error: "task not found"})
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+		return tasks, results
+	}
+
+	filter := repositories.TaskFilter{
+		WorkspaceID:  req.Filter.WorkspaceID,
+		Status:       models.TaskStatus(req.Filter.Status),
+		ResourceType: req.Filter.ResourceType,
+		ResourceName: req.Filter.ResourceName,
+	}
+	// Without a workspace the filter is scoped to the caller's own tasks
+	if filter.WorkspaceID == 0 {
+		filter.UserID = userID
+	}
+
+	tasks, err := h.storage.Tasks().Search(filter, bulkFilterLimit, 0)
+	if err != nil {
+		h.logger.Error("Failed to search tasks for bulk action", zap.Error(err))
+	}
+	return tasks, results
+}
+
+// applyBulkTaskAction performs one action on one task
+func (h *TaskHandler) applyBulkTaskAction(c *gin.Context, action string, task *models.Task) error {
+	switch action {
+	case "delete":
+		return h.storage.Tasks().Delete(task.ID)
+
+	case "cancel":
+		if isTerminalStatus(task.Status) {
+			return errAlreadyFinished
+		}
+		if err := h.orchestrator.CancelTask(task.ID); err != nil {
+			return err
+		}
+		task.Status = models.TaskStatusCancelled
+		h.storage.Tasks().Update(task)
+		recordAudit(h.storage, h.logger, c, task.WorkspaceID,
+			models.AuditActionTaskCancel, "task", task.ID, task.Title, "")
+		return nil
+
+	case "retry":
+		if task.Status != models.TaskStatusFailed && task.Status != models.TaskStatusCancelled &&
+			task.Status != models.TaskStatusDeadLetter {
+			return errNotRetryable
+		}
+		task.Status = models.TaskStatusPending
+		task.Progress = 0
+		task.Attempts = 0
+		task.Error = ""
+		task.Result = ""
+		if err := h.storage.Tasks().Update(task); err != nil {
+			return err
+		}
+		return h.orchestrator.SubmitTask(task, nil)
+	}
+	return nil
+}
+
+// BulkResourceRequest applies one action to many resources; delete is the
+// only action that makes sense for declarative resources
+type BulkResourceRequest struct {
+	Action string              `json:"action" binding:"required,oneof=delete"`
+	IDs    []uint              `json:"ids"`
+	Filter *BulkResourceFilter `json:"filter"`
+}
+
+// BulkResourceFilter selects resources for a bulk action
+type BulkResourceFilter struct {
+	WorkspaceID uint   `json:"workspace_id" binding:"required"`
+	Type        string `json:"type"`
+}
+
+// Bulk deletes many resources at once, returning per-item results
+func (h *ResourceHandler) Bulk(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req BulkResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.IDs) == 0 && req.Filter == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids or filter required"})
+		return
+	}
+
+	var resources []*models.Resource
+	var results []BulkResult
+
+	if len(req.IDs) > 0 {
+		for _, id := range req.IDs {
+			resource, err := h.storage.Resources().GetByID(id)
+			if err != nil {
+				results = append(results, BulkResult{ID: id, Error: "resource not found"})
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	} else {
+		var err error
+		resources, err = h.storage.Resources().List(
+			req.Filter.WorkspaceID, models.ResourceType(req.Filter.Type), bulkFilterLimit, 0)
+		if err != nil {
+			h.logger.Error("Failed to list resources for bulk action", zap.Error(err))
+		}
+	}
+
+	succeeded := 0
+	for _, resource := range resources {
+		result := BulkResult{ID: resource.ID}
+
+		if !hasWorkspaceRole(h.storage, resource.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+			result.Error = "access denied"
+			results = append(results, result)
+			continue
+		}
+
+		if err := h.storage.Resources().Delete(resource.ID); err != nil {
+			result.Error = err.Error()
+		} else {
+			executor.InvalidateResource(resource.WorkspaceID, resource.Name, resource.Type)
+			recordAudit(h.storage, h.logger, c, resource.WorkspaceID,
+				models.AuditActionResourceDelete, string(resource.Type), resource.ID, resource.Name, "")
+			result.OK = true
+			succeeded++
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":   results,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	// embedTokenDefaultTTL and embedTokenMaxTTL bound embed token lifetimes
+	embedTokenDefaultTTL = time.Hour
+	embedTokenMaxTTL     = 24 * time.Hour
+
+	// embedTokenSubject marks embed tokens so they cannot be replayed
+	// against the normal authenticated API
+	embedTokenSubject = "xgent-embed"
+)
+
+// EmbedClaims are the JWT claims of a public embed token: read-only
+// streaming access to one task, restricted to the listed origins
+type EmbedClaims struct {
+	UserID  uint     `json:"user_id"`
+	TaskID  uint     `json:"task_id"`
+	Origins []string `json:"origins"`
+	jwt.RegisteredClaims
+}
+
+// EmbedTokenRequest mints an origin-restricted embed token for a task
+type EmbedTokenRequest struct {
+	AllowedOrigins []string `json:"allowed_origins" binding:"required,min=1"`
+	ExpiresIn      int      `json:"expires_in,omitempty"` // seconds, defaults to one hour
+}
+
+// EmbedHandler mints and validates public embed tokens, which let a portal
+// embed a live task console without exposing a user JWT
+type EmbedHandler struct {
+	storage   *storage.Storage
+	jwtSecret string
+	logger    *zap.Logger
+}
+
+// NewEmbedHandler creates a new embed token handler
+func NewEmbedHandler(storage *storage.Storage, jwtSecret string, logger *zap.Logger) *EmbedHandler {
+	return &EmbedHandler{
+		storage:   storage,
+		jwtSecret: jwtSecret,
+		logger:    logger,
+	}
+}
+
+// MintToken issues an embed token for a task the caller can view
+func (h *EmbedHandler) MintToken(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	if !canViewTask(h.storage, task, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var req EmbedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ttl := embedTokenDefaultTTL
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+		if ttl > embedTokenMaxTTL {
+			ttl = embedTokenMaxTTL
+		}
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	claims := EmbedClaims{
+		UserID:  userID,
+		TaskID:  task.ID,
+		Origins: req.AllowedOrigins,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   embedTokenSubject,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(h.jwtSecret))
+	if err != nil {
+		h.logger.Error("Failed to sign embed token", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":      signed,
+		"expires_at": expiresAt,
+	})
+}
+
+// Auth validates the embed token on the public streaming route. The token
+// arrives as a query parameter (WebSocket clients cannot set headers), must
+// match the task in the path and the request's Origin must be on the
+// token's allow list. On success the minting user's identity is set so the
+// normal view checks apply downstream.
+func (h *EmbedHandler) Auth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.Query("token")
+		if tokenString == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token required"})
+			c.Abort()
+			return
+		}
+
+		token, err := jwt.ParseWithClaims(tokenString, &EmbedClaims{}, func(token *jwt.Token) (interface{}, error) {
+			return []byte(h.jwtSecret), nil
+		})
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
+		}
+
+		claims, ok := token.Claims.(*EmbedClaims)
+		if !ok || !token.Valid || claims.Subject != embedTokenSubject {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Abort()
+			return
+		}
+
+		taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil || uint(taskID) != claims.TaskID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Token not valid for this task"})
+			c.Abort()
+			return
+		}
+
+		// Unlike OriginAllowed, a missing Origin header is rejected:
+		// the restriction is the whole point of the token
+		origin := c.GetHeader("Origin")
+		allowed := false
+		for _, o := range claims.Origins {
+			if strings.EqualFold(o, origin) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Origin not allowed"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", claims.UserID)
+		c.Next()
+	}
+}
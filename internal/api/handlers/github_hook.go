@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/orchestrator"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// commandPrefix is the issue comment prefix that fires issue_comment
+// triggers; the remainder of the comment is passed to the prompt template
+const commandPrefix = "/xgent"
+
+// GitHubHookHandler turns GitHub webhook deliveries into tasks via Trigger
+// resources
+type GitHubHookHandler struct {
+	storage      *storage.Storage
+	orchestrator *orchestrator.Orchestrator
+	logger       *zap.Logger
+}
+
+// NewGitHubHookHandler creates a new GitHub webhook handler
+func NewGitHubHookHandler(storage *storage.Storage, orch *orchestrator.Orchestrator, logger *zap.Logger) *GitHubHookHandler {
+	return &GitHubHookHandler{
+		storage:      storage,
+		orchestrator: orch,
+		logger:       logger,
+	}
+}
+
+// githubPayload is the subset of the webhook payload the triggers consume
+type githubPayload struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		CloneURL string `json:"clone_url"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		HTMLURL string `json:"html_url"`
+		Head    struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Issue struct {
+		Number int `json:"number"`
+	} `json:"issue"`
+	Comment struct {
+		Body string `json:"body"`
+	} `json:"comment"`
+}
+
+// Handle processes one webhook delivery. The request authenticates itself
+// via the HMAC signature against each matching trigger's secret, so the
+// endpoint is public.
+func (h *GitHubHookHandler) Handle(c *gin.Context) {
+	event := c.GetHeader("X-GitHub-Event")
+	if event == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing X-GitHub-Event header"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read body"})
+		return
+	}
+
+	var payload githubPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+	if payload.Repository.FullName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payload has no repository"})
+		return
+	}
+
+	triggers, err := h.matchTriggers(event, &payload, body, c.GetHeader("X-Hub-Signature-256"))
+	if err != nil {
+		h.logger.Error("Failed to load triggers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load triggers"})
+		return
+	}
+
+	created := make([]uint, 0, len(triggers))
+	for _, match := range triggers {
+		task, err := h.createTask(match.resource, match.trigger, event, &payload)
+		if err != nil {
+			h.logger.Error("Failed to create task from trigger",
+				zap.String("trigger", match.resource.Name),
+				zap.Error(err))
+			continue
+		}
+		created = append(created, task.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"event":    event,
+		"task_ids": created,
+	})
+}
+
+// triggerMatch pairs a trigger resource row with its parsed spec
+type triggerMatch struct {
+	resource *models.Resource
+	trigger  *crd.Trigger
+}
+
+// matchTriggers returns the triggers firing for this delivery: repository
+// and event must match, the signature must verify against the trigger's
+// secret, and issue comments must carry the /xgent command prefix
+func (h *GitHubHookHandler) matchTriggers(event string, payload *githubPayload, body []byte, signature string) ([]triggerMatch, error) {
+	resources, err := h.storage.Resources().ListAllByType(models.ResourceTypeTrigger)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := crd.NewParser()
+	var matches []triggerMatch
+
+	for _, resource := range resources {
+		parsed, err := parser.Parse([]byte(resource.Spec))
+		if err != nil {
+			h.logger.Warn("Skipping unparsable trigger",
+				zap.String("name", resource.Name), zap.Error(err))
+			continue
+		}
+		trigger, ok := parsed.(*crd.Trigger)
+		if !ok {
+			continue
+		}
+
+		if trigger.Spec.Repository != payload.Repository.FullName {
+			continue
+		}
+		if !containsString(trigger.Spec.Events, event) {
+			continue
+		}
+		if !verifySignature(body, signature, trigger.Spec.Secret) {
+			h.logger.Warn("Webhook signature mismatch for trigger",
+				zap.String("trigger", resource.Name),
+				zap.String("repository", payload.Repository.FullName))
+			continue
+		}
+		if event == "issue_comment" && !strings.HasPrefix(strings.TrimSpace(payload.Comment.Body), commandPrefix) {
+			continue
+		}
+		// Only act on newly opened PRs, not every synchronize/label event
+		if event == "pull_request" && payload.Action != "opened" && payload.Action != "reopened" {
+			continue
+		}
+
+		matches = append(matches, triggerMatch{resource: resource, trigger: trigger})
+	}
+
+	return matches, nil
+}
+
+// createTask builds and submits a task for one fired trigger. The prompt
+// keeps its {{placeholders}}; the payload fields travel as task parameters
+// and are resolved by the executor's template pass.
+func (h *GitHubHookHandler) createTask(resource *models.Resource, trigger *crd.Trigger, event string, payload *githubPayload) (*models.Task, error) {
+	workspace, err := h.storage.Workspaces().GetByID(resource.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace: %w", err)
+	}
+
+	command := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(payload.Comment.Body), commandPrefix))
+	parameters, _ := json.Marshal(map[string]string{
+		"repo":      payload.Repository.FullName,
+		"event":     event,
+		"ref":       payload.Ref,
+		"sender":    payload.Sender.Login,
+		"pr_number": fmt.Sprint(payload.PullRequest.Number),
+		"pr_title":  payload.PullRequest.Title,
+		"pr_url":    payload.PullRequest.HTMLURL,
+		"comment":   command,
+	})
+
+	// Pull request events run against the PR branch
+	branch := ""
+	if event == "pull_request" {
+		branch = payload.PullRequest.Head.Ref
+	}
+
+	task := &models.Task{
+		WorkspaceID:  resource.WorkspaceID,
+		UserID:       workspace.UserID,
+		Status:       models.TaskStatusPending,
+		Title:        fmt.Sprintf("Trigger %s: %s %s", resource.Name, payload.Repository.FullName, event),
+		Prompt:       trigger.Spec.Prompt,
+		Parameters:   string(parameters),
+		ResourceType: trigger.Spec.ResourceType,
+		ResourceName: trigger.Spec.ResourceName,
+		GitURL:       payload.Repository.CloneURL,
+		BranchName:   branch,
+		Priority:     models.TaskPriorityNormal,
+	}
+
+	if err := h.storage.Tasks().Create(task); err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+	if err := h.orchestrator.SubmitTask(task, nil); err != nil {
+		return nil, fmt.Errorf("failed to submit task: %w", err)
+	}
+
+	h.logger.Info("Trigger fired",
+		zap.String("trigger", resource.Name),
+		zap.String("repository", payload.Repository.FullName),
+		zap.String("event", event),
+		zap.Uint("task_id", task.ID))
+
+	return task, nil
+}
+
+// verifySignature checks the X-Hub-Signature-256 HMAC against a trigger's
+// secret. Triggers without a secret only accept unsigned deliveries.
+func verifySignature(body []byte, signature, secret string) bool {
+	if secret == "" {
+		return signature == ""
+	}
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
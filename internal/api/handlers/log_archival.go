@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// retentionRequest sets a workspace's log retention window
+type retentionRequest struct {
+	Days int `json:"days" binding:"min=0"`
+}
+
+// SetWorkspaceRetention sets how many days a workspace keeps task logs and
+// events before the janitor archives them; 0 keeps them forever (admin
+// only)
+func (h *AdminHandler) SetWorkspaceRetention(c *gin.Context) {
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	var req retentionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace.LogRetentionDays = req.Days
+	if err := h.storage.Workspaces().Update(workspace); err != nil {
+		h.logger.Error("Failed to update workspace retention", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+// GetLogArchival reports the janitor's last run and recent archives (admin
+// only)
+func (h *AdminHandler) GetLogArchival(c *gin.Context) {
+	workspaceID, _ := strconv.ParseUint(c.Query("workspace_id"), 10, 32)
+
+	archives, err := h.storage.Tasks().ListLogArchives(uint(workspaceID), 50)
+	if err != nil {
+		h.logger.Error("Failed to list log archives", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list archives"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   h.orchestrator.LogJanitor().Status(),
+		"archives": archives,
+	})
+}
+
+// RunLogArchival triggers an archival pass immediately and returns its
+// outcome (admin only)
+func (h *AdminHandler) RunLogArchival(c *gin.Context) {
+	status := h.orchestrator.LogJanitor().RunNow()
+	c.JSON(http.StatusOK, status)
+}
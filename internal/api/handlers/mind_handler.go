@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/executor"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// MindHandler runs prompts directly against a Mind, without a robot in
+// between. The CLI benchmark command uses it to compare providers.
+type MindHandler struct {
+	storage  *storage.Storage
+	executor *executor.Executor
+	logger   *zap.Logger
+}
+
+// NewMindHandler creates a new mind handler
+func NewMindHandler(storage *storage.Storage, logger *zap.Logger) *MindHandler {
+	return &MindHandler{
+		storage:  storage,
+		executor: executor.New(storage, logger),
+		logger:   logger,
+	}
+}
+
+// CompleteRequest is a single prompt run against a Mind
+type CompleteRequest struct {
+	Prompt      string `json:"prompt" binding:"required"`
+	WorkspaceID uint   `json:"workspace_id,omitempty"`
+}
+
+// Complete sends one prompt through the named Mind and reports the reply
+// together with timing and token usage
+func (h *MindHandler) Complete(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	mindName := c.Param("name")
+
+	var req CompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Use default workspace if not specified
+	workspaceID := req.WorkspaceID
+	if workspaceID == 0 {
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		workspaceID = id
+	}
+	if !hasWorkspaceRole(h.storage, workspaceID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	mindResource, err := h.storage.Resources().GetByName(workspaceID, mindName, models.ResourceTypeMind)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Mind not found"})
+		return
+	}
+	mindDef, err := crd.NewParser().Parse([]byte(mindResource.Spec))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid mind spec"})
+		return
+	}
+	mind, ok := mindDef.(*crd.Mind)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid mind resource"})
+		return
+	}
+
+	client, err := h.executor.LLMClient(workspaceID, mind)
+	if err != nil {
+		h.logger.Error("Failed to create LLM client", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	started := time.Now()
+	response, err := client.Chat(c.Request.Context(), []llm.Message{
+		{Role: "user", Content: req.Prompt},
+	})
+	if err != nil {
+		h.logger.Error("Mind completion failed",
+			zap.String("mind", mindName), zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"content":     response.Content,
+		"provider":    mind.Spec.Provider,
+		"model":       mind.Spec.ModelID,
+		"duration_ms": time.Since(started).Milliseconds(),
+		"usage": gin.H{
+			"prompt_tokens":     response.Usage.PromptTokens,
+			"completion_tokens": response.Usage.CompletionTokens,
+			"total_tokens":      response.Usage.TotalTokens,
+		},
+	})
+}
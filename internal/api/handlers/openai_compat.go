@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/executor"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// OpenAICompatHandler serves an OpenAI-compatible chat completions endpoint
+// backed by robots: the model name selects a Robot, its soul becomes the
+// system prompt and its mind picks the actual model. Existing OpenAI client
+// libraries work unchanged with the server URL and an Xgent token.
+type OpenAICompatHandler struct {
+	storage  *storage.Storage
+	executor *executor.Executor
+	logger   *zap.Logger
+}
+
+// NewOpenAICompatHandler creates a new OpenAI-compatible handler
+func NewOpenAICompatHandler(storage *storage.Storage, logger *zap.Logger) *OpenAICompatHandler {
+	return &OpenAICompatHandler{
+		storage:  storage,
+		executor: executor.New(storage, logger),
+		logger:   logger,
+	}
+}
+
+// chatCompletionRequest is the subset of the OpenAI request schema we honor;
+// unknown fields are ignored
+type chatCompletionRequest struct {
+	Model    string `json:"model" binding:"required"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages" binding:"required"`
+	Stream bool `json:"stream"`
+}
+
+// openAIError mirrors the OpenAI error envelope so client libraries surface
+// failures the way their users expect
+func openAIError(c *gin.Context, status int, message string) {
+	c.JSON(status, gin.H{"error": gin.H{"message": message, "type": "invalid_request_error"}})
+}
+
+// ChatCompletions handles POST /v1/chat/completions
+func (h *OpenAICompatHandler) ChatCompletions(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req chatCompletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		openAIError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The "model" names a Robot in the caller's active workspace
+	workspaceID, err := activeWorkspace(h.storage, c, userID)
+	if err != nil {
+		openAIError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	robotResource, err := h.storage.Resources().GetByName(workspaceID, req.Model, models.ResourceTypeRobot)
+	if err != nil {
+		openAIError(c, http.StatusNotFound, fmt.Sprintf("model %q does not exist", req.Model))
+		return
+	}
+
+	parser := crd.NewParser()
+	resource, err := parser.Parse([]byte(robotResource.Spec))
+	if err != nil {
+		openAIError(c, http.StatusInternalServerError, "invalid robot spec")
+		return
+	}
+	robot, ok := resource.(*crd.Robot)
+	if !ok {
+		openAIError(c, http.StatusInternalServerError, "invalid robot resource")
+		return
+	}
+
+	soulResource, err := h.storage.Resources().GetByName(workspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
+	if err != nil {
+		openAIError(c, http.StatusInternalServerError, "failed to load soul")
+		return
+	}
+	soulDef, err := parser.Parse([]byte(soulResource.Spec))
+	if err != nil {
+		openAIError(c, http.StatusInternalServerError, "invalid soul spec")
+		return
+	}
+	soul := soulDef.(*crd.Soul)
+
+	mindResource, err := h.storage.Resources().GetByName(workspaceID, robot.Spec.Mind, models.ResourceTypeMind)
+	if err != nil {
+		openAIError(c, http.StatusInternalServerError, "failed to load mind")
+		return
+	}
+	mindDef, err := parser.Parse([]byte(mindResource.Spec))
+	if err != nil {
+		openAIError(c, http.StatusInternalServerError, "invalid mind spec")
+		return
+	}
+	mind := mindDef.(*crd.Mind)
+
+	client, err := h.executor.LLMClient(workspaceID, mind)
+	if err != nil {
+		h.logger.Error("Failed to create LLM client", zap.Error(err))
+		openAIError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// The soul's personality leads; any system messages the client sent
+	// are kept after it
+	messages := make([]llm.Message, 0, len(req.Messages)+1)
+	messages = append(messages, llm.Message{Role: "system", Content: soul.Spec.Personality})
+	for _, msg := range req.Messages {
+		messages = append(messages, llm.Message{Role: msg.Role, Content: msg.Content})
+	}
+
+	completionID := "chatcmpl-" + uuid.New().String()
+	created := time.Now().Unix()
+
+	if req.Stream {
+		h.streamCompletion(c, client, messages, completionID, req.Model, created)
+		return
+	}
+
+	response, err := client.Chat(c.Request.Context(), messages)
+	if err != nil {
+		h.logger.Error("LLM call failed", zap.Error(err))
+		openAIError(c, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":      completionID,
+		"object":  "chat.completion",
+		"created": created,
+		"model":   req.Model,
+		"choices": []gin.H{{
+			"index":         0,
+			"message":       gin.H{"role": "assistant", "content": response.Content},
+			"finish_reason": "stop",
+		}},
+		"usage": gin.H{
+			"prompt_tokens":     response.Usage.PromptTokens,
+			"completion_tokens": response.Usage.CompletionTokens,
+			"total_tokens":      response.Usage.TotalTokens,
+		},
+	})
+}
+
+// streamCompletion writes the reply as OpenAI-style chat.completion.chunk
+// server-sent events terminated by [DONE]
+func (h *OpenAICompatHandler) streamCompletion(c *gin.Context, client llm.Client, messages []llm.Message, completionID, model string, created int64) {
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	flusher, _ := c.Writer.(http.Flusher)
+
+	writeChunk := func(delta gin.H, finishReason interface{}) {
+		data, err := json.Marshal(gin.H{
+			"id":      completionID,
+			"object":  "chat.completion.chunk",
+			"created": created,
+			"model":   model,
+			"choices": []gin.H{{
+				"index":         0,
+				"delta":         delta,
+				"finish_reason": finishReason,
+			}},
+		})
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(c.Writer, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	writeChunk(gin.H{"role": "assistant"}, nil)
+
+	err := client.Stream(c.Request.Context(), messages, func(chunk string) error {
+		writeChunk(gin.H{"content": chunk}, nil)
+		return nil
+	})
+	if err != nil {
+		h.logger.Error("LLM call failed", zap.Error(err))
+		writeChunk(gin.H{}, "error")
+		return
+	}
+
+	writeChunk(gin.H{}, "stop")
+	fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
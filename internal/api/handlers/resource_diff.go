@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// diffApply compares an incoming spec against the stored revision and
+// returns a unified diff without writing, so operators can review what an
+// apply would change
+func (h *ResourceHandler) diffApply(c *gin.Context, workspaceID uint, resource crd.Resource, resourceType models.ResourceType, body []byte) {
+	metadata := resource.GetMetadata()
+	label := fmt.Sprintf("%s/%s", resourceType, metadata.Name)
+
+	existing, err := h.storage.Resources().GetByName(workspaceID, metadata.Name, resourceType)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"kind":   resource.GetKind(),
+			"name":   metadata.Name,
+			"action": "created",
+			"diff":   unifiedDiff("", string(body), "/dev/null", "b/"+label),
+		})
+		return
+	}
+
+	if strings.TrimSpace(existing.Spec) == strings.TrimSpace(string(body)) {
+		c.JSON(http.StatusOK, gin.H{
+			"kind":   resource.GetKind(),
+			"name":   metadata.Name,
+			"action": "unchanged",
+			"diff":   "",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"kind":   resource.GetKind(),
+		"name":   metadata.Name,
+		"action": "updated",
+		"diff":   unifiedDiff(existing.Spec, string(body), "a/"+label, "b/"+label),
+	})
+}
+
+// unifiedDiff renders a unified diff between two texts, computed from a
+// longest-common-subsequence over lines. Specs are small, so the quadratic
+// table is fine and a single hunk keeps the output simple.
+func unifiedDiff(from, to, fromLabel, toLabel string) string {
+	fromLines := splitDiffLines(from)
+	toLines := splitDiffLines(to)
+
+	// LCS table: lcs[i][j] is the length of the longest common
+	// subsequence of fromLines[i:] and toLines[j:]
+	lcs := make([][]int, len(fromLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(toLines)+1)
+	}
+	for i := len(fromLines) - 1; i >= 0; i-- {
+		for j := len(toLines) - 1; j >= 0; j-- {
+			if fromLines[i] == toLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n", fromLabel)
+	fmt.Fprintf(&b, "+++ %s\n", toLabel)
+	fmt.Fprintf(&b, "@@ -1,%d +1,%d @@\n", len(fromLines), len(toLines))
+
+	i, j := 0, 0
+	for i < len(fromLines) && j < len(toLines) {
+		switch {
+		case fromLines[i] == toLines[j]:
+			b.WriteString(" " + fromLines[i] + "\n")
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			b.WriteString("-" + fromLines[i] + "\n")
+			i++
+		default:
+			b.WriteString("+" + toLines[j] + "\n")
+			j++
+		}
+	}
+	for ; i < len(fromLines); i++ {
+		b.WriteString("-" + fromLines[i] + "\n")
+	}
+	for ; j < len(toLines); j++ {
+		b.WriteString("+" + toLines[j] + "\n")
+	}
+	return b.String()
+}
+
+// splitDiffLines splits text into lines without a trailing empty entry
+func splitDiffLines(text string) []string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
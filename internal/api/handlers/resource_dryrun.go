@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// dryRunApply reports what applying a resource would do — created, updated
+// or unchanged — without writing, mirroring kubectl's --dry-run=server.
+// References to other resources are resolved the same way execution would
+// resolve them, so dangling references fail here instead of at run time.
+func (h *ResourceHandler) dryRunApply(c *gin.Context, workspaceID uint, resource crd.Resource, resourceType models.ResourceType, body []byte) {
+	metadata := resource.GetMetadata()
+	missing := h.missingReferences(workspaceID, resource)
+
+	action := "created"
+	if existing, err := h.storage.Resources().GetByName(workspaceID, metadata.Name, resourceType); err == nil {
+		if strings.TrimSpace(existing.Spec) == strings.TrimSpace(string(body)) {
+			action = "unchanged"
+		} else {
+			action = "updated"
+		}
+	}
+
+	response := gin.H{
+		"dry_run": true,
+		"kind":    resource.GetKind(),
+		"name":    metadata.Name,
+		"action":  action,
+		"valid":   len(missing) == 0,
+	}
+	if len(missing) > 0 {
+		response["missing_references"] = missing
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// missingReferences resolves every resource a spec refers to and returns
+// the ones that do not exist in the workspace
+func (h *ResourceHandler) missingReferences(workspaceID uint, resource crd.Resource) []string {
+	type reference struct {
+		resourceType models.ResourceType
+		name         string
+	}
+	var refs []reference
+	add := func(resourceType models.ResourceType, name string) {
+		if name != "" {
+			refs = append(refs, reference{resourceType, name})
+		}
+	}
+
+	switch r := resource.(type) {
+	case *crd.Robot:
+		add(models.ResourceTypeSoul, r.Spec.Soul)
+		add(models.ResourceTypeMind, r.Spec.Mind)
+		add(models.ResourceTypeCraft, r.Spec.Craft)
+		add(models.ResourceTypeEnvironment, r.Spec.Environment)
+		for _, knowledge := range r.Spec.Knowledge {
+			add(models.ResourceTypeKnowledge, knowledge)
+		}
+	case *crd.Team:
+		add(models.ResourceTypeRobot, r.Spec.Leader)
+		add(models.ResourceTypeCraft, r.Spec.Craft)
+		for _, member := range r.Spec.Members {
+			add(models.ResourceTypeRobot, member)
+		}
+	case *crd.Collaboration:
+		for _, step := range r.Spec.Steps {
+			add(models.ResourceTypeRobot, step.Agent)
+		}
+	case *crd.Workflow:
+		for _, step := range r.Spec.Steps {
+			if step.Type == crd.StepTypeRobot || step.Type == "" {
+				add(models.ResourceTypeRobot, step.Robot)
+			}
+		}
+	}
+
+	var missing []string
+	for _, ref := range refs {
+		exists, err := h.storage.Resources().Exists(workspaceID, ref.name, ref.resourceType)
+		if err == nil && !exists {
+			missing = append(missing, fmt.Sprintf("%s/%s", ref.resourceType, ref.name))
+		}
+	}
+	return missing
+}
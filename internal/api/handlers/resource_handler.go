@@ -5,10 +5,12 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
 	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/executor"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"go.uber.org/zap"
@@ -47,14 +49,20 @@ func (h *ResourceHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Use default workspace if not specified
+	// Use the active workspace if not specified
 	if req.WorkspaceID == 0 {
-		workspaces, err := h.storage.Workspaces().ListByUser(userID)
-		if err != nil || len(workspaces) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No workspace found"})
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		req.WorkspaceID = workspaces[0].ID
+		req.WorkspaceID = id
+	}
+
+	// Members need at least editor rights to create resources
+	if !hasWorkspaceRole(h.storage, req.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
 	}
 
 	// Validate YAML spec
@@ -71,6 +79,12 @@ func (h *ResourceHandler) Create(c *gin.Context) {
 		return
 	}
 
+	// Enforce the workspace's per-type quota
+	if err := checkResourceQuota(h.storage, req.WorkspaceID, req.Type); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Create resource
 	resource := &models.Resource{
 		WorkspaceID: req.WorkspaceID,
@@ -87,23 +101,34 @@ func (h *ResourceHandler) Create(c *gin.Context) {
 		return
 	}
 
+	recordAudit(h.storage, h.logger, c, resource.WorkspaceID,
+		models.AuditActionResourceCreate, string(resource.Type), resource.ID, resource.Name, "")
+
 	c.JSON(http.StatusCreated, resource)
 }
 
 // Get retrieves a resource by ID
 func (h *ResourceHandler) Get(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
 	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
 		return
 	}
 
-	resource, err := h.storage.Resources().GetByID(uint(resourceID))
+	// Tenant scoping hides other tenants' resources from the lookup itself
+	resource, err := h.storage.Scoped(c.Request.Context()).Resources().GetByID(uint(resourceID))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
 		return
 	}
 
+	// Check access
+	if !hasWorkspaceRole(h.storage, resource.WorkspaceID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
 	c.JSON(http.StatusOK, resource)
 }
 
@@ -119,14 +144,20 @@ func (h *ResourceHandler) List(c *gin.Context) {
 		}
 	}
 
-	// Use default workspace if not specified
+	// Use the active workspace if not specified
 	if workspaceID == 0 {
-		workspaces, err := h.storage.Workspaces().ListByUser(userID)
-		if err != nil || len(workspaces) == 0 {
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
 			c.JSON(http.StatusOK, gin.H{"resources": []models.Resource{}})
 			return
 		}
-		workspaceID = workspaces[0].ID
+		workspaceID = id
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, workspaceID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
 	}
 
 	// Get resource type filter
@@ -145,7 +176,7 @@ func (h *ResourceHandler) List(c *gin.Context) {
 		}
 	}
 
-	resources, err := h.storage.Resources().List(workspaceID, resourceType, limit, offset)
+	resources, err := h.storage.Scoped(c.Request.Context()).Resources().List(workspaceID, resourceType, limit, offset)
 	if err != nil {
 		h.logger.Error("Failed to list resources", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list resources"})
@@ -161,6 +192,7 @@ func (h *ResourceHandler) List(c *gin.Context) {
 
 // Update updates a resource
 func (h *ResourceHandler) Update(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
 	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
@@ -173,6 +205,12 @@ func (h *ResourceHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// Check access
+	if !hasWorkspaceRole(h.storage, resource.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
 	var req CreateResourceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -187,6 +225,7 @@ func (h *ResourceHandler) Update(c *gin.Context) {
 	}
 
 	// Update fields
+	previousName := resource.Name
 	if req.Name != "" {
 		resource.Name = req.Name
 	}
@@ -203,23 +242,44 @@ func (h *ResourceHandler) Update(c *gin.Context) {
 		return
 	}
 
+	executor.InvalidateResource(resource.WorkspaceID, previousName, resource.Type)
+	executor.InvalidateResource(resource.WorkspaceID, resource.Name, resource.Type)
+
 	c.JSON(http.StatusOK, resource)
 }
 
 // Delete deletes a resource
 func (h *ResourceHandler) Delete(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
 	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
 		return
 	}
 
+	resource, err := h.storage.Resources().GetByID(uint(resourceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, resource.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
 	if err := h.storage.Resources().Delete(uint(resourceID)); err != nil {
 		h.logger.Error("Failed to delete resource", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete resource"})
 		return
 	}
 
+	executor.InvalidateResource(resource.WorkspaceID, resource.Name, resource.Type)
+
+	recordAudit(h.storage, h.logger, c, resource.WorkspaceID,
+		models.AuditActionResourceDelete, string(resource.Type), resource.ID, resource.Name, "")
+
 	c.JSON(http.StatusOK, gin.H{"message": "Resource deleted"})
 }
 
@@ -235,14 +295,20 @@ func (h *ResourceHandler) Apply(c *gin.Context) {
 		}
 	}
 
-	// Use default workspace if not specified
+	// Use the active workspace if not specified
 	if workspaceID == 0 {
-		workspaces, err := h.storage.Workspaces().ListByUser(userID)
-		if err != nil || len(workspaces) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No workspace found"})
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		workspaceID = workspaces[0].ID
+		workspaceID = id
+	}
+
+	// Members need at least editor rights to apply resources
+	if !hasWorkspaceRole(h.storage, workspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
 	}
 
 	// Read YAML content
@@ -275,6 +341,12 @@ func (h *ResourceHandler) Apply(c *gin.Context) {
 		resourceType = models.ResourceTypeTeam
 	case crd.KindCollaboration:
 		resourceType = models.ResourceTypeCollaboration
+	case crd.KindKnowledge:
+		resourceType = models.ResourceTypeKnowledge
+	case crd.KindSecret:
+		resourceType = models.ResourceTypeSecret
+	case crd.KindTrigger:
+		resourceType = models.ResourceTypeTrigger
 	default:
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown resource kind"})
 		return
@@ -282,10 +354,58 @@ func (h *ResourceHandler) Apply(c *gin.Context) {
 
 	metadata := resource.GetMetadata()
 
+	// Dry run: validate, resolve references and report what apply would
+	// do without writing anything
+	if c.Query("dryRun") == "true" {
+		h.dryRunApply(c, workspaceID, resource, resourceType, body)
+		return
+	}
+
+	// Diff: show what applying this spec would change, without writing
+	if c.Query("diff") == "true" {
+		h.diffApply(c, workspaceID, resource, resourceType, body)
+		return
+	}
+
+	// Optional canary rollout: keep the previous revision serving a
+	// percentage of new tasks for a window before full cutover
+	canaryPercent := 0
+	canaryWindow := 60 * time.Minute
+	if canaryStr := c.Query("canary"); canaryStr != "" {
+		percent, err := strconv.Atoi(canaryStr)
+		if err != nil || percent < 0 || percent > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "canary must be a percentage between 0 and 100"})
+			return
+		}
+		canaryPercent = percent
+	}
+	if windowStr := c.Query("canary_window"); windowStr != "" {
+		minutes, err := strconv.Atoi(windowStr)
+		if err != nil || minutes <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "canary_window must be a positive number of minutes"})
+			return
+		}
+		canaryWindow = time.Duration(minutes) * time.Minute
+	}
+
 	// Check if resource exists
 	existingResource, err := h.storage.Resources().GetByName(workspaceID, metadata.Name, resourceType)
 	if err == nil {
 		// Update existing resource
+		if canaryPercent > 0 {
+			now := time.Now()
+			expires := now.Add(canaryWindow)
+			existingResource.PreviousSpec = existingResource.Spec
+			existingResource.CanaryPercent = canaryPercent
+			existingResource.CanaryStartedAt = &now
+			existingResource.CanaryExpiresAt = &expires
+		} else {
+			existingResource.PreviousSpec = ""
+			existingResource.CanaryPercent = 0
+			existingResource.CanaryStartedAt = nil
+			existingResource.CanaryExpiresAt = nil
+		}
+		previousLen := len(existingResource.Spec)
 		existingResource.Spec = string(body)
 		existingResource.Description = metadata.Description
 		if err := h.storage.Resources().Update(existingResource); err != nil {
@@ -293,6 +413,15 @@ func (h *ResourceHandler) Apply(c *gin.Context) {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update resource"})
 			return
 		}
+		executor.InvalidateResource(existingResource.WorkspaceID, existingResource.Name, existingResource.Type)
+
+		summary := fmt.Sprintf("spec changed (%d -> %d bytes)", previousLen, len(body))
+		if canaryPercent > 0 {
+			summary += fmt.Sprintf(", canary %d%%", canaryPercent)
+		}
+		recordAudit(h.storage, h.logger, c, workspaceID,
+			models.AuditActionResourceUpdate, string(resourceType), existingResource.ID, existingResource.Name, summary)
+
 		c.JSON(http.StatusOK, gin.H{
 			"action":   "updated",
 			"resource": existingResource,
@@ -300,6 +429,12 @@ func (h *ResourceHandler) Apply(c *gin.Context) {
 		return
 	}
 
+	// Enforce the workspace's per-type quota; updates above are unaffected
+	if err := checkResourceQuota(h.storage, workspaceID, resourceType); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Create new resource
 	newResource := &models.Resource{
 		WorkspaceID: workspaceID,
@@ -316,8 +451,126 @@ func (h *ResourceHandler) Apply(c *gin.Context) {
 		return
 	}
 
+	recordAudit(h.storage, h.logger, c, workspaceID,
+		models.AuditActionResourceCreate, string(resourceType), newResource.ID, newResource.Name, "")
+
 	c.JSON(http.StatusCreated, gin.H{
 		"action":   "created",
 		"resource": newResource,
 	})
 }
+
+// CanaryStatus reports failure rates during an active canary rollout,
+// comparing the canary window against an equal-length trailing window
+func (h *ResourceHandler) CanaryStatus(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+
+	resource, err := h.storage.Resources().GetByID(uint(resourceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, resource.WorkspaceID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if resource.CanaryPercent == 0 || resource.CanaryStartedAt == nil {
+		c.JSON(http.StatusOK, gin.H{"active": false})
+		return
+	}
+
+	started := *resource.CanaryStartedAt
+	window := time.Hour
+	if resource.CanaryExpiresAt != nil {
+		window = resource.CanaryExpiresAt.Sub(started)
+	}
+
+	duringTotal, duringFailed, _ := h.storage.Tasks().CountForResource(resource.WorkspaceID, resource.Name, started, time.Now())
+	beforeTotal, beforeFailed, _ := h.storage.Tasks().CountForResource(resource.WorkspaceID, resource.Name, started.Add(-window), started)
+
+	c.JSON(http.StatusOK, gin.H{
+		"active":         resource.CanaryExpiresAt != nil && time.Now().Before(*resource.CanaryExpiresAt),
+		"canary_percent": resource.CanaryPercent,
+		"started_at":     resource.CanaryStartedAt,
+		"expires_at":     resource.CanaryExpiresAt,
+		"during_canary":  gin.H{"total": duringTotal, "failed": duringFailed, "failure_rate": failureRate(duringTotal, duringFailed)},
+		"before_canary":  gin.H{"total": beforeTotal, "failed": beforeFailed, "failure_rate": failureRate(beforeTotal, beforeFailed)},
+	})
+}
+
+// PromoteCanary completes a canary rollout, cutting all traffic over to
+// the new revision
+func (h *ResourceHandler) PromoteCanary(c *gin.Context) {
+	h.finishCanary(c, false)
+}
+
+// RollbackCanary aborts a canary rollout, restoring the previous revision
+func (h *ResourceHandler) RollbackCanary(c *gin.Context) {
+	h.finishCanary(c, true)
+}
+
+// finishCanary ends a canary rollout, optionally restoring the previous spec
+func (h *ResourceHandler) finishCanary(c *gin.Context, rollback bool) {
+	userID, _ := middleware.GetUserID(c)
+	resourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+
+	resource, err := h.storage.Resources().GetByID(uint(resourceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, resource.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if resource.CanaryPercent == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No canary rollout in progress"})
+		return
+	}
+
+	action := "promoted"
+	if rollback {
+		resource.Spec = resource.PreviousSpec
+		action = "rolled_back"
+	}
+	resource.PreviousSpec = ""
+	resource.CanaryPercent = 0
+	resource.CanaryStartedAt = nil
+	resource.CanaryExpiresAt = nil
+
+	if err := h.storage.Resources().Update(resource); err != nil {
+		h.logger.Error("Failed to update resource", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update resource"})
+		return
+	}
+
+	executor.InvalidateResource(resource.WorkspaceID, resource.Name, resource.Type)
+
+	c.JSON(http.StatusOK, gin.H{
+		"action":   action,
+		"resource": resource,
+	})
+}
+
+// failureRate computes a failure percentage, guarding against division by zero
+func failureRate(total, failed int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(failed) / float64(total) * 100
+}
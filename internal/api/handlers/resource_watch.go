@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	watchPollInterval  = 2 * time.Second
+	watchDefaultWindow = 30 * time.Second
+	watchMaxWindow     = 5 * time.Minute
+)
+
+// watchEvent describes a single resource change, modelled after the
+// Kubernetes watch event types
+type watchEvent struct {
+	Type     string           `json:"type"` // ADDED, MODIFIED, DELETED
+	Resource *models.Resource `json:"resource"`
+}
+
+// watchResponse is the long-poll response. ResourceVersion is a bookmark the
+// client passes back on its next request to resume where it left off; a
+// response with no events is a pure bookmark after the poll window expired.
+type watchResponse struct {
+	Events          []watchEvent `json:"events"`
+	ResourceVersion int64        `json:"resource_version"`
+}
+
+// Watch long-polls for resource changes in a workspace, in the style of the
+// Kubernetes watch API. Clients pass the resource_version bookmark from the
+// previous response; a version of 0 starts watching from now.
+func (h *ResourceHandler) Watch(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	workspaceID := uint(0)
+	if wsIDStr := c.Query("workspace_id"); wsIDStr != "" {
+		if wsID, err := strconv.ParseUint(wsIDStr, 10, 32); err == nil {
+			workspaceID = uint(wsID)
+		}
+	}
+	if workspaceID == 0 {
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		workspaceID = id
+	}
+
+	// The bookmark is a unix-nanosecond timestamp derived from row
+	// update times, so it survives server restarts
+	since := time.Now()
+	if rvStr := c.Query("resource_version"); rvStr != "" {
+		if rv, err := strconv.ParseInt(rvStr, 10, 64); err == nil && rv > 0 {
+			since = time.Unix(0, rv)
+		}
+	}
+
+	window := watchDefaultWindow
+	if timeoutStr := c.Query("timeout"); timeoutStr != "" {
+		if seconds, err := strconv.Atoi(timeoutStr); err == nil && seconds > 0 {
+			window = time.Duration(seconds) * time.Second
+			if window > watchMaxWindow {
+				window = watchMaxWindow
+			}
+		}
+	}
+
+	deadline := time.NewTimer(window)
+	defer deadline.Stop()
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		changed, err := h.storage.Resources().ListChangedSince(workspaceID, since)
+		if err != nil {
+			h.logger.Error("Failed to poll resource changes", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to watch resources"})
+			return
+		}
+		if len(changed) > 0 {
+			c.JSON(http.StatusOK, buildWatchResponse(changed, since))
+			return
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-deadline.C:
+			// Poll window expired: return a bookmark so the client
+			// can reconnect without missing changes
+			c.JSON(http.StatusOK, watchResponse{
+				Events:          []watchEvent{},
+				ResourceVersion: time.Now().UnixNano(),
+			})
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// buildWatchResponse classifies changed resources into watch events and
+// computes the next bookmark
+func buildWatchResponse(changed []*models.Resource, since time.Time) watchResponse {
+	response := watchResponse{Events: make([]watchEvent, 0, len(changed))}
+
+	for _, resource := range changed {
+		eventType := "MODIFIED"
+		version := resource.UpdatedAt
+
+		switch {
+		case resource.DeletedAt.Valid:
+			eventType = "DELETED"
+			if resource.DeletedAt.Time.After(version) {
+				version = resource.DeletedAt.Time
+			}
+		case resource.CreatedAt.After(since):
+			eventType = "ADDED"
+		}
+
+		if nano := version.UnixNano(); nano > response.ResourceVersion {
+			response.ResourceVersion = nano
+		}
+		response.Events = append(response.Events, watchEvent{Type: eventType, Resource: resource})
+	}
+
+	return response
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/runner"
+	"go.uber.org/zap"
+)
+
+// RunnerHandler handles runner agent connections
+type RunnerHandler struct {
+	logger *zap.Logger
+}
+
+// NewRunnerHandler creates a new runner handler
+func NewRunnerHandler(logger *zap.Logger) *RunnerHandler {
+	return &RunnerHandler{logger: logger}
+}
+
+// Connect upgrades a runner agent connection and pumps its messages into the
+// registry until it disconnects
+func (h *RunnerHandler) Connect(c *gin.Context) {
+	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("Failed to upgrade runner connection", zap.Error(err))
+		return
+	}
+
+	// First message must identify the runner
+	var hello runner.Hello
+	if err := ws.ReadJSON(&hello); err != nil || hello.Name == "" {
+		h.logger.Warn("Runner connection without valid hello", zap.Error(err))
+		ws.Close()
+		return
+	}
+
+	registry := runner.GetRegistry()
+	conn := registry.Register(hello.Name, ws)
+	h.logger.Info("Runner connected",
+		zap.String("runner", hello.Name),
+		zap.String("version", hello.Version))
+
+	defer func() {
+		registry.Unregister(conn)
+		h.logger.Info("Runner disconnected", zap.String("runner", hello.Name))
+	}()
+
+	for {
+		var msg runner.Message
+		if err := ws.ReadJSON(&msg); err != nil {
+			return
+		}
+		conn.Dispatch(msg)
+	}
+}
+
+// List returns the names of connected runners
+func (h *RunnerHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"runners": runner.GetRegistry().List(),
+	})
+}
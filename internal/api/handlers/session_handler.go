@@ -101,6 +101,58 @@ func (h *SessionHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Session deleted"})
 }
 
+// GetSummary returns the session's running summary and how far into the
+// message history it reaches
+func (h *SessionHandler) GetSummary(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	sessionID := c.Param("id")
+
+	session, err := h.storage.Sessions().GetBySessionID(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
+	if session.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":       session.SessionID,
+		"summary":          session.Summary,
+		"summarized_up_to": session.SummarizedUpTo,
+	})
+}
+
+// ResetSummary clears the running summary; the next compaction pass starts
+// over from the full message history
+func (h *SessionHandler) ResetSummary(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	sessionID := c.Param("id")
+
+	session, err := h.storage.Sessions().GetBySessionID(sessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
+	if session.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	session.Summary = ""
+	session.SummarizedUpTo = 0
+	if err := h.storage.Sessions().Update(session); err != nil {
+		h.logger.Error("Failed to reset session summary", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Summary reset"})
+}
+
 // GetMessages retrieves messages for a session
 func (h *SessionHandler) GetMessages(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
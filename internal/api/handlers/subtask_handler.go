@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -41,7 +42,7 @@ func (h *SubtaskHandler) ListByTask(c *gin.Context) {
 		return
 	}
 
-	if task.UserID != userID {
+	if !canViewTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -53,6 +54,75 @@ func (h *SubtaskHandler) ListByTask(c *gin.Context) {
 	})
 }
 
+// Plan returns a task's subtasks as an ordered checklist with parsed
+// dependencies and aggregate counts
+func (h *SubtaskHandler) Plan(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	// Verify task ownership
+	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	if !canViewTask(h.storage, task, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	subtasks, err := h.storage.Tasks().ListSubTasks(uint(taskID))
+	if err != nil {
+		h.logger.Error("Failed to list subtasks", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load plan"})
+		return
+	}
+
+	type planStep struct {
+		ID        uint              `json:"id"`
+		Title     string            `json:"title"`
+		Status    models.TaskStatus `json:"status"`
+		StepOrder int               `json:"step_order"`
+		DependsOn []string          `json:"depends_on,omitempty"`
+		Agent     string            `json:"agent,omitempty"`
+		Progress  int               `json:"progress"`
+	}
+
+	steps := make([]planStep, 0, len(subtasks))
+	completed := 0
+	for _, subtask := range subtasks {
+		var dependsOn []string
+		if subtask.DependsOn != "" {
+			json.Unmarshal([]byte(subtask.DependsOn), &dependsOn)
+		}
+		if subtask.Status == models.TaskStatusCompleted {
+			completed++
+		}
+		steps = append(steps, planStep{
+			ID:        subtask.ID,
+			Title:     subtask.Title,
+			Status:    subtask.Status,
+			StepOrder: subtask.StepOrder,
+			DependsOn: dependsOn,
+			Agent:     subtask.AgentID,
+			Progress:  subtask.Progress,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task_id":   task.ID,
+		"status":    task.Status,
+		"total":     len(steps),
+		"completed": completed,
+		"steps":     steps,
+	})
+}
+
 // Get retrieves a subtask by ID
 func (h *SubtaskHandler) Get(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
@@ -76,7 +146,7 @@ func (h *SubtaskHandler) Get(c *gin.Context) {
 		return
 	}
 
-	if task.UserID != userID {
+	if !canViewTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -116,7 +186,7 @@ func (h *SubtaskHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	if task.UserID != userID {
+	if !canEditTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -159,7 +229,7 @@ func (h *SubtaskHandler) GetLogs(c *gin.Context) {
 		return
 	}
 
-	if task.UserID != userID {
+	if !canViewTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
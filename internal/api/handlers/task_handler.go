@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,16 +12,29 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/events"
+	"github.com/xcode-ai/xgent-go/internal/executor"
 	"github.com/xcode-ai/xgent-go/internal/orchestrator"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
 	"go.uber.org/zap"
 )
 
+// upgrader is shared by every WebSocket endpoint. CheckOrigin is left at
+// gorilla's same-origin default until ConfigureWebSocketOrigins installs
+// the server's allow list; the "bearer" subprotocol is echoed back to
+// browser clients that pass their token through Sec-WebSocket-Protocol.
 var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Allow all origins in development
-	},
+	Subprotocols: []string{"bearer"},
+}
+
+// ConfigureWebSocketOrigins restricts WebSocket upgrades to the configured
+// origins; a list containing "*" allows all
+func ConfigureWebSocketOrigins(allowOrigins []string) {
+	upgrader.CheckOrigin = func(r *http.Request) bool {
+		return middleware.OriginAllowed(allowOrigins, r.Header.Get("Origin"))
+	}
 }
 
 // TaskHandler handles task-related requests
@@ -42,12 +58,49 @@ type CreateTaskRequest struct {
 	Title        string `json:"title" binding:"required"`
 	Description  string `json:"description"`
 	Prompt       string `json:"prompt" binding:"required"`
-	ResourceType string `json:"resource_type" binding:"required,oneof=robot team"`
+	ResourceType string `json:"resource_type" binding:"required,oneof=robot team collaboration workflow"`
 	ResourceName string `json:"resource_name" binding:"required"`
 	Mode         string `json:"mode,omitempty"`
 	GitURL       string `json:"git_url,omitempty"`
 	BranchName   string `json:"branch_name,omitempty"`
+	AutoPR       bool   `json:"auto_pr,omitempty"` // open a PR with the agent's changes after completion
+	Environment  string `json:"environment,omitempty"`
 	WorkspaceID  uint   `json:"workspace_id"`
+
+	// Priority orders the task in the queue; defaults to normal
+	Priority models.TaskPriority `json:"priority,omitempty" binding:"omitempty,oneof=high normal low"`
+
+	// UseCache reuses the result of an earlier identical run (same prompt,
+	// resource revision and git inputs) instead of executing again
+	UseCache bool `json:"use_cache,omitempty"`
+
+	// Parameters are template variables resolved against {{placeholders}} in the prompt
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Variables are merged into Parameters; the alias matches Go-template
+	// prompts ({{.variable}}) and wins on key collisions
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// AttachmentIDs are previously uploaded attachments to use as task input,
+	// saving the create-then-attach round trip
+	AttachmentIDs []uint `json:"attachment_ids,omitempty"`
+
+	// RunAt schedules the task for a future time instead of executing
+	// immediately; a past or missing value runs right away
+	RunAt *time.Time `json:"run_at,omitempty"`
+
+	// RunAtLocal schedules by wall-clock time ("2006-01-02 15:04") in the
+	// workspace's configured timezone, for clients that do not want to
+	// compute UTC offsets themselves. Ignored when run_at is set.
+	RunAtLocal string `json:"run_at_local,omitempty"`
+
+	// DependsOn lists task IDs that must complete before this task runs;
+	// their results become {{task_<id>_result}} template variables
+	DependsOn []uint `json:"depends_on,omitempty"`
+
+	// MaxTokens caps the task's estimated LLM token usage; execution aborts
+	// with budget_exceeded once crossed. 0 means unlimited.
+	MaxTokens int `json:"max_tokens,omitempty" binding:"omitempty,min=0"`
 }
 
 // Create creates a new task
@@ -60,14 +113,106 @@ func (h *TaskHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Use default workspace if not specified
+	h.createTask(c, userID, req)
+}
+
+// createTask validates and submits a task from an already-bound request;
+// shared by Create and CreateFromTemplate
+func (h *TaskHandler) createTask(c *gin.Context, userID uint, req CreateTaskRequest) {
+	// Use the active workspace if not specified
 	if req.WorkspaceID == 0 {
-		workspaces, err := h.storage.Workspaces().ListByUser(userID)
-		if err != nil || len(workspaces) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No workspace found"})
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.WorkspaceID = id
+	}
+
+	// Members need at least editor rights to create tasks
+	if !hasWorkspaceRole(h.storage, req.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	// Reject malformed Go templates at submission rather than at execution
+	if err := executor.ValidatePromptTemplate(req.Prompt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Serialize template parameters
+	if len(req.Variables) > 0 {
+		if req.Parameters == nil {
+			req.Parameters = make(map[string]string, len(req.Variables))
+		}
+		for k, v := range req.Variables {
+			req.Parameters[k] = v
+		}
+	}
+	var parameters string
+	if len(req.Parameters) > 0 {
+		data, err := json.Marshal(req.Parameters)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters"})
+			return
+		}
+		parameters = string(data)
+	}
+
+	// Validate input attachments up front so a bad ID doesn't leave an
+	// orphaned task behind
+	attachments := make([]*models.Attachment, 0, len(req.AttachmentIDs))
+	for _, attachmentID := range req.AttachmentIDs {
+		attachment, err := h.storage.Attachments().GetByID(attachmentID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Attachment %d not found", attachmentID)})
+			return
+		}
+		if attachment.UserID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("Access denied to attachment %d", attachmentID)})
+			return
+		}
+		if attachment.TaskID != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Attachment %d is already linked to a task", attachmentID)})
+			return
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	// Validate dependencies up front. Parents that already completed have
+	// their results injected right away; unfinished parents block the task
+	// until the orchestrator's dependency watcher releases it.
+	var dependsOn string
+	hasUnfinishedParents := false
+	parentResults := make(map[uint]string, len(req.DependsOn))
+	for _, parentID := range req.DependsOn {
+		parent, err := h.storage.Tasks().GetByID(parentID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Dependency task %d not found", parentID)})
+			return
+		}
+		if parent.WorkspaceID != req.WorkspaceID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Dependency task %d is in another workspace", parentID)})
+			return
+		}
+		switch parent.Status {
+		case models.TaskStatusCompleted:
+			parentResults[parent.ID] = parent.Result
+		case models.TaskStatusFailed, models.TaskStatusCancelled, models.TaskStatusDeadLetter:
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Dependency task %d already ended %s", parentID, parent.Status)})
 			return
+		default:
+			hasUnfinishedParents = true
 		}
-		req.WorkspaceID = workspaces[0].ID
+	}
+	if len(req.DependsOn) > 0 {
+		data, err := json.Marshal(req.DependsOn)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid depends_on"})
+			return
+		}
+		dependsOn = string(data)
 	}
 
 	// Create task
@@ -78,12 +223,55 @@ func (h *TaskHandler) Create(c *gin.Context) {
 		Title:        req.Title,
 		Description:  req.Description,
 		Prompt:       req.Prompt,
+		Parameters:   parameters,
 		ResourceType: req.ResourceType,
 		ResourceName: req.ResourceName,
 		Mode:         req.Mode,
 		GitURL:       req.GitURL,
 		BranchName:   req.BranchName,
+		AutoPR:       req.AutoPR,
+		Environment:  req.Environment,
+		UseCache:     req.UseCache,
 		Progress:     0,
+		RequestID:    middleware.GetRequestID(c),
+		Priority:     req.Priority,
+		DependsOn:    dependsOn,
+		MaxTokens:    req.MaxTokens,
+	}
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityNormal
+	}
+
+	// A wall-clock schedule is interpreted in the workspace's configured
+	// timezone, or UTC when the settings name none
+	if req.RunAt == nil && req.RunAtLocal != "" {
+		loc := time.UTC
+		if workspace, err := h.storage.Workspaces().GetByID(req.WorkspaceID); err == nil {
+			if tz := workspace.SettingsValues().Timezone; tz != "" {
+				if parsed, err := time.LoadLocation(tz); err == nil {
+					loc = parsed
+				}
+			}
+		}
+		runAt, err := time.ParseInLocation("2006-01-02 15:04", req.RunAtLocal, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run_at_local, expected YYYY-MM-DD HH:MM"})
+			return
+		}
+		req.RunAt = &runAt
+	}
+
+	// A future run_at parks the task in scheduled state; the orchestrator's
+	// scheduler enqueues it when due. Unfinished dependencies take
+	// precedence and block the task instead.
+	if req.RunAt != nil && req.RunAt.After(time.Now()) {
+		task.Status = models.TaskStatusScheduled
+		task.RunAt = req.RunAt
+	}
+	if hasUnfinishedParents {
+		task.Status = models.TaskStatusBlocked
+	} else {
+		orchestrator.InjectParentResults(task, parentResults)
 	}
 
 	if err := h.storage.Tasks().Create(task); err != nil {
@@ -92,70 +280,133 @@ func (h *TaskHandler) Create(c *gin.Context) {
 		return
 	}
 
-	// Submit task to orchestrator
-	callback := func(taskID uint, progress int, status models.TaskStatus, message string, metadata map[string]interface{}) {
-		// Determine event type
-		eventType := "info"
-		if metadata != nil {
-			if t, ok := metadata["type"].(string); ok {
-				eventType = t
-			}
-		}
-
-		// Extract details from metadata
-		var details map[string]interface{}
-		if metadata != nil {
-			if d, ok := metadata["details"].(map[string]interface{}); ok {
-				details = d
-			}
+	// Link input attachments before submission so the executor sees them
+	for _, attachment := range attachments {
+		attachment.TaskID = &task.ID
+		if err := h.storage.Attachments().Update(attachment); err != nil {
+			h.logger.Error("Failed to link attachment to task",
+				zap.Uint("attachment_id", attachment.ID),
+				zap.Uint("task_id", task.ID),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link attachment"})
+			return
 		}
+	}
 
-		// Broadcast event in real-time to WebSocket subscribers
-		GetBroadcaster().Broadcast(TaskEvent{
-			TaskID:    taskID,
-			Type:      "log",
-			EventType: eventType,
-			Content:   message,
-			Details:   details,
-			Progress:  progress,
-			Status:    string(status),
-		})
-
-		// Update task in database
-		if t, err := h.storage.Tasks().GetByID(taskID); err == nil {
-			t.Progress = progress
-			t.Status = status
-			h.storage.Tasks().Update(t)
-
-			// Build JSON message with type, content, and details for frontend parsing
-			logMessage := map[string]interface{}{
-				"type":    eventType,
-				"content": message,
-			}
-			if details != nil {
-				logMessage["details"] = details
-			}
-			msgJSON, _ := json.Marshal(logMessage)
-
-			// Add log entry with full event data
-			h.storage.Tasks().AddLog(&models.TaskLog{
-				TaskID:    taskID,
-				Level:     "info",
-				Message:   string(msgJSON),
-				EventType: eventType,
-			})
-		}
+	// Scheduled and blocked tasks wait in the database; the orchestrator's
+	// scheduler and dependency watcher submit them when ready
+	if task.Status == models.TaskStatusScheduled || task.Status == models.TaskStatusBlocked {
+		recordAudit(h.storage, h.logger, c, task.WorkspaceID,
+			models.AuditActionTaskCreate, "task", task.ID, task.Title,
+			fmt.Sprintf("%s/%s", task.ResourceType, task.ResourceName))
+		c.JSON(http.StatusCreated, task)
+		return
 	}
 
-	if err := h.orchestrator.SubmitTask(task, callback); err != nil {
+	// Submit task to orchestrator; progress reporting (event broadcast,
+	// progress persistence, task logs) is handled by the orchestrator's
+	// built-in reporter
+	if err := h.orchestrator.SubmitTask(task, nil); err != nil {
+		if errors.Is(err, orchestrator.ErrLimitExceeded) {
+			task.Status = models.TaskStatusFailed
+			task.Error = err.Error()
+			h.storage.Tasks().Update(task)
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to submit task", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit task"})
 		return
 	}
 
+	recordAudit(h.storage, h.logger, c, task.WorkspaceID,
+		models.AuditActionTaskCreate, "task", task.ID, task.Title,
+		fmt.Sprintf("%s/%s", task.ResourceType, task.ResourceName))
+
 	c.JSON(http.StatusCreated, task)
 }
 
+// FromTemplateRequest carries per-run overrides when instantiating a task
+// template; anything left empty falls back to the template's defaults
+type FromTemplateRequest struct {
+	Title      string            `json:"title,omitempty"`
+	Prompt     string            `json:"prompt,omitempty"`
+	GitURL     string            `json:"git_url,omitempty"`
+	BranchName string            `json:"branch_name,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+
+	// Priority overrides the template's; defaults to the template value
+	Priority models.TaskPriority `json:"priority,omitempty" binding:"omitempty,oneof=high normal low"`
+}
+
+// CreateFromTemplate instantiates a task from a stored template, applying
+// the request's overrides on top of the template defaults
+func (h *TaskHandler) CreateFromTemplate(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.storage.TaskTemplates().GetByID(uint(templateID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+
+	var req FromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Template defaults first, request variables on top
+	parameters := make(map[string]string)
+	if template.Parameters != "" {
+		if err := json.Unmarshal([]byte(template.Parameters), &parameters); err != nil {
+			h.logger.Warn("Ignoring malformed template parameters",
+				zap.Uint("template_id", template.ID), zap.Error(err))
+		}
+	}
+	for k, v := range req.Variables {
+		parameters[k] = v
+	}
+
+	createReq := CreateTaskRequest{
+		Title:        template.Title,
+		Description:  template.Description,
+		Prompt:       template.Prompt,
+		ResourceType: template.ResourceType,
+		ResourceName: template.ResourceName,
+		Mode:         template.Mode,
+		GitURL:       template.GitURL,
+		BranchName:   template.BranchName,
+		AutoPR:       template.AutoPR,
+		Environment:  template.Environment,
+		WorkspaceID:  template.WorkspaceID,
+		Priority:     template.Priority,
+		Parameters:   parameters,
+	}
+	if req.Title != "" {
+		createReq.Title = req.Title
+	}
+	if req.Prompt != "" {
+		createReq.Prompt = req.Prompt
+	}
+	if req.GitURL != "" {
+		createReq.GitURL = req.GitURL
+	}
+	if req.BranchName != "" {
+		createReq.BranchName = req.BranchName
+	}
+	if req.Priority != "" {
+		createReq.Priority = req.Priority
+	}
+
+	h.createTask(c, userID, createReq)
+}
+
 // Get retrieves a task by ID
 func (h *TaskHandler) Get(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
@@ -165,14 +416,16 @@ func (h *TaskHandler) Get(c *gin.Context) {
 		return
 	}
 
-	task, err := h.storage.Tasks().GetByID(uint(taskID))
+	// The scoped lookup already excludes other tenants' rows, so a foreign
+	// task reads as not found rather than forbidden
+	task, err := h.storage.Scoped(c.Request.Context()).Tasks().GetByID(uint(taskID))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	}
 
-	// Check ownership
-	if task.UserID != userID {
+	// Check access
+	if !canViewTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -197,7 +450,48 @@ func (h *TaskHandler) List(c *gin.Context) {
 		}
 	}
 
-	tasks, err := h.storage.Tasks().ListByUser(userID, limit, offset)
+	filter := repositories.TaskFilter{
+		Status:       models.TaskStatus(c.Query("status")),
+		ResourceType: c.Query("resource_type"),
+		ResourceName: c.Query("resource_name"),
+		Search:       c.Query("q"),
+	}
+
+	// Workspace-scoped listings need at least viewer access; otherwise the
+	// caller sees their own tasks across workspaces
+	if workspaceStr := c.Query("workspace_id"); workspaceStr != "" {
+		workspaceID, err := strconv.ParseUint(workspaceStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+			return
+		}
+		if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleViewer) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return
+		}
+		filter.WorkspaceID = uint(workspaceID)
+	} else {
+		filter.UserID = userID
+	}
+
+	if afterStr := c.Query("created_after"); afterStr != "" {
+		after, err := time.Parse(time.RFC3339, afterStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid created_after, expected RFC3339"})
+			return
+		}
+		filter.CreatedAfter = after
+	}
+	if beforeStr := c.Query("created_before"); beforeStr != "" {
+		before, err := time.Parse(time.RFC3339, beforeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid created_before, expected RFC3339"})
+			return
+		}
+		filter.CreatedBefore = before
+	}
+
+	tasks, err := h.storage.Scoped(c.Request.Context()).Tasks().Search(filter, limit, offset)
 	if err != nil {
 		h.logger.Error("Failed to list tasks", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tasks"})
@@ -226,8 +520,8 @@ func (h *TaskHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if task.UserID != userID {
+	// Check access
+	if !canEditTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -256,12 +550,23 @@ func (h *TaskHandler) Cancel(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if task.UserID != userID {
+	// Check access
+	if !canEditTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
 
+	// Scheduled and blocked tasks are not in the queue yet; cancelling is
+	// just a status change
+	if task.Status == models.TaskStatusScheduled || task.Status == models.TaskStatusBlocked {
+		task.Status = models.TaskStatusCancelled
+		h.storage.Tasks().Update(task)
+		recordAudit(h.storage, h.logger, c, task.WorkspaceID,
+			models.AuditActionTaskCancel, "task", task.ID, task.Title, "")
+		c.JSON(http.StatusOK, gin.H{"message": "Task cancelled"})
+		return
+	}
+
 	// Cancel task in orchestrator
 	if err := h.orchestrator.CancelTask(uint(taskID)); err != nil {
 		h.logger.Error("Failed to cancel task", zap.Error(err))
@@ -273,9 +578,45 @@ func (h *TaskHandler) Cancel(c *gin.Context) {
 	task.Status = models.TaskStatusCancelled
 	h.storage.Tasks().Update(task)
 
+	recordAudit(h.storage, h.logger, c, task.WorkspaceID,
+		models.AuditActionTaskCancel, "task", task.ID, task.Title, "")
+
 	c.JSON(http.StatusOK, gin.H{"message": "Task cancelled"})
 }
 
+// replayPersistedEvents streams a task's stored event rows over a fresh
+// WebSocket connection, in the same shape as live events, so clients can
+// catch up on runs whose in-memory buffer has expired
+func (h *TaskHandler) replayPersistedEvents(conn *websocket.Conn, taskID uint) {
+	rows, err := h.storage.Tasks().GetEvents(taskID, 0, 0)
+	if err != nil {
+		h.logger.Error("Failed to load persisted events for replay",
+			zap.Uint("task_id", taskID), zap.Error(err))
+		return
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	h.logger.Info("Replaying persisted events",
+		zap.Uint("task_id", taskID), zap.Int("count", len(rows)))
+
+	for _, row := range rows {
+		msg := gin.H{
+			"type":       "log",
+			"task_id":    taskID,
+			"event_type": row.Type,
+			"message":    row.Payload,
+			"seq":        row.Seq,
+			"replay":     true,
+		}
+		if err := conn.WriteJSON(msg); err != nil {
+			h.logger.Error("Failed to write replayed event", zap.Error(err))
+			return
+		}
+	}
+}
+
 // GetLogs retrieves task logs
 func (h *TaskHandler) GetLogs(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
@@ -291,8 +632,8 @@ func (h *TaskHandler) GetLogs(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if task.UserID != userID {
+	// Check access
+	if !canViewTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -311,7 +652,17 @@ func (h *TaskHandler) GetLogs(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"logs": logs})
+	// Persisted event rows, paginated by sequence number: pass the seq of
+	// the last event received as after_seq to fetch the next page
+	afterSeq, _ := strconv.ParseUint(c.Query("after_seq"), 10, 64)
+	taskEvents, err := h.storage.Tasks().GetEvents(uint(taskID), afterSeq, limit)
+	if err != nil {
+		h.logger.Error("Failed to get events", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"logs": logs, "events": taskEvents})
 }
 
 // Stream handles WebSocket streaming for task execution with real-time events
@@ -329,8 +680,8 @@ func (h *TaskHandler) Stream(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if task.UserID != userID {
+	// Check access
+	if !canViewTask(h.storage, task, userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -343,6 +694,10 @@ func (h *TaskHandler) Stream(c *gin.Context) {
 	}
 	defer conn.Close()
 
+	// Track the connection so shutdown can drain it gracefully
+	drain := registerStreamClient(conn)
+	defer unregisterStreamClient(conn)
+
 	h.logger.Info("WebSocket connection established",
 		zap.Uint("task_id", uint(taskID)),
 		zap.Uint("user_id", userID),
@@ -367,12 +722,25 @@ func (h *TaskHandler) Stream(c *gin.Context) {
 	h.logger.Info("Sent connection confirmation to client", zap.Uint("task_id", task.ID))
 
 	// Subscribe to real-time events for this task
-	eventCh := GetBroadcaster().Subscribe(uint(taskID))
-	defer GetBroadcaster().Unsubscribe(uint(taskID), eventCh)
+	eventCh := events.GetBroadcaster().Subscribe(uint(taskID))
+	defer events.GetBroadcaster().Unsubscribe(uint(taskID), eventCh)
+
+	// When the in-memory buffer has expired (e.g. reconnecting to an old
+	// task), replay the persisted event rows instead
+	if events.GetBroadcaster().BufferedCount(uint(taskID)) == 0 {
+		h.replayPersistedEvents(conn, uint(taskID))
+	}
 
-	// Also check task status periodically for completion
-	statusTicker := time.NewTicker(2 * time.Second)
-	defer statusTicker.Stop()
+	// Terminal status arrives through the broadcaster; if the task already
+	// finished before we subscribed, close out immediately after replay
+	if isTerminalStatus(task.Status) {
+		conn.WriteJSON(completeMessage(task))
+		return
+	}
+
+	// Low-frequency keepalive so intermediaries don't drop idle connections
+	keepalive := time.NewTicker(30 * time.Second)
+	defer keepalive.Stop()
 
 	// Handle WebSocket close
 	done := make(chan struct{})
@@ -391,6 +759,15 @@ func (h *TaskHandler) Stream(c *gin.Context) {
 			h.logger.Info("WebSocket client disconnected", zap.Uint64("task_id", taskID))
 			return
 
+		case <-drain:
+			// Server is shutting down: tell the client to reconnect and
+			// close cleanly instead of dropping the connection
+			conn.WriteJSON(closingMessage(uint(taskID)))
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(time.Second))
+			return
+
 		case event := <-eventCh:
 			// Send real-time event immediately
 			h.logger.Info("Sending event via WebSocket",
@@ -423,31 +800,39 @@ func (h *TaskHandler) Stream(c *gin.Context) {
 				return
 			}
 
-		case <-statusTicker.C:
-			// Periodically check task completion status
-			updatedTask, _ := h.storage.Tasks().GetByID(uint(taskID))
-			if updatedTask != nil {
-				// Send status update
-				conn.WriteJSON(gin.H{
-					"type":     "status",
-					"task_id":  updatedTask.ID,
-					"status":   updatedTask.Status,
-					"progress": updatedTask.Progress,
-				})
-
-				// Close connection if task is completed
-				if updatedTask.Status == models.TaskStatusCompleted ||
-					updatedTask.Status == models.TaskStatusFailed ||
-					updatedTask.Status == models.TaskStatusCancelled {
-					conn.WriteJSON(gin.H{
-						"type":    "complete",
-						"task_id": taskID,
-						"status":  updatedTask.Status,
-						"result":  updatedTask.Result,
-					})
-					return
+			// Terminal status ends the stream: fetch the final task state
+			// once and tell the client it's done
+			if isTerminalStatus(models.TaskStatus(event.Status)) {
+				if updatedTask, err := h.storage.Tasks().GetByID(uint(taskID)); err == nil {
+					conn.WriteJSON(completeMessage(updatedTask))
 				}
+				return
+			}
+
+		case <-keepalive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil,
+				time.Now().Add(5*time.Second)); err != nil {
+				h.logger.Info("Keepalive failed, closing stream", zap.Uint64("task_id", taskID))
+				return
 			}
 		}
 	}
 }
+
+// isTerminalStatus reports whether a status ends a task's stream
+func isTerminalStatus(status models.TaskStatus) bool {
+	return status == models.TaskStatusCompleted ||
+		status == models.TaskStatusFailed ||
+		status == models.TaskStatusCancelled ||
+		status == models.TaskStatusDeadLetter
+}
+
+// completeMessage is the final WebSocket frame sent when a task finishes
+func completeMessage(task *models.Task) gin.H {
+	return gin.H{
+		"type":    "complete",
+		"task_id": task.ID,
+		"status":  task.Status,
+		"result":  task.Result,
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// Replay re-executes a finished task using the recorded prompt and the
+// resource spec revisions captured during the original run, so resource edits
+// made since then do not change the outcome. The replay is a new task linked
+// to the original; once it finishes, a "replay" log entry records whether the
+// result diverged.
+func (h *TaskHandler) Replay(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	original, err := h.storage.Tasks().GetByID(uint(taskID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	// Check ownership
+	if !canEditTask(h.storage, original, userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if original.Status != models.TaskStatusCompleted && original.Status != models.TaskStatusFailed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only finished tasks can be replayed"})
+		return
+	}
+
+	if original.SpecSnapshot == "" {
+		h.logger.Warn("Replaying task without spec snapshot, live resources will be used",
+			zap.Uint("task_id", original.ID))
+	}
+
+	originalID := original.ID
+	replay := &models.Task{
+		WorkspaceID:  original.WorkspaceID,
+		UserID:       original.UserID,
+		Status:       models.TaskStatusPending,
+		Title:        fmt.Sprintf("%s (replay of #%d)", original.Title, original.ID),
+		Description:  original.Description,
+		Prompt:       original.Prompt,
+		Parameters:   original.Parameters,
+		ResourceType: original.ResourceType,
+		ResourceName: original.ResourceName,
+		Mode:         original.Mode,
+		GitURL:       original.GitURL,
+		BranchName:   original.BranchName,
+		Environment:  original.Environment,
+		SpecSnapshot: original.SpecSnapshot,
+		ReplayOfID:   &originalID,
+	}
+
+	if err := h.storage.Tasks().Create(replay); err != nil {
+		h.logger.Error("Failed to create replay task", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create replay task"})
+		return
+	}
+
+	if err := h.orchestrator.SubmitTask(replay, nil); err != nil {
+		h.logger.Error("Failed to submit replay task", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit replay task"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, replay)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// junitTestSuite is the JUnit XML report root element
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// taskReport is the JSON report format
+type taskReport struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Total       int              `json:"total"`
+	Completed   int              `json:"completed"`
+	Failed      int              `json:"failed"`
+	Tasks       []taskReportItem `json:"tasks"`
+}
+
+type taskReportItem struct {
+	ID           uint              `json:"id"`
+	Title        string            `json:"title"`
+	Status       models.TaskStatus `json:"status"`
+	ResourceType string            `json:"resource_type"`
+	ResourceName string            `json:"resource_name"`
+	DurationMs   int64             `json:"duration_ms"`
+	Error        string            `json:"error,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	CompletedAt  *time.Time        `json:"completed_at,omitempty"`
+}
+
+// Export exports task runs as a JUnit XML or JSON report so evaluation
+// suites can feed results into CI dashboards
+func (h *TaskHandler) Export(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	limit := 100
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			limit = l
+		}
+	}
+
+	tasks, err := h.storage.Tasks().ListByUser(userID, limit, 0)
+	if err != nil {
+		h.logger.Error("Failed to list tasks", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tasks"})
+		return
+	}
+
+	switch c.DefaultQuery("format", "junit") {
+	case "junit", "xml":
+		suite := buildJUnitSuite(tasks)
+		data, err := xml.MarshalIndent(suite, "", "  ")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate report"})
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="xgent-tasks.xml"`)
+		c.Data(http.StatusOK, "application/xml", append([]byte(xml.Header), data...))
+
+	case "json":
+		c.Header("Content-Disposition", `attachment; filename="xgent-tasks.json"`)
+		c.JSON(http.StatusOK, buildJSONReport(tasks))
+
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported format (use junit or json)"})
+	}
+}
+
+// buildJUnitSuite converts task runs into a JUnit test suite
+func buildJUnitSuite(tasks []*models.Task) junitTestSuite {
+	suite := junitTestSuite{
+		Name:      "xgent-tasks",
+		Tests:     len(tasks),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	var totalTime float64
+	for _, task := range tasks {
+		seconds := float64(task.Duration) / 1000
+		totalTime += seconds
+
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("task-%d: %s", task.ID, task.Title),
+			ClassName: fmt.Sprintf("%s/%s", task.ResourceType, task.ResourceName),
+			Time:      seconds,
+		}
+
+		switch task.Status {
+		case models.TaskStatusFailed:
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: task.Error,
+				Content: task.Error,
+			}
+		case models.TaskStatusCompleted:
+			// Passing test case, nothing extra
+		default:
+			suite.Skipped++
+			testCase.Skipped = &junitSkipped{Message: string(task.Status)}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	suite.Time = totalTime
+	return suite
+}
+
+// buildJSONReport converts task runs into the JSON report format
+func buildJSONReport(tasks []*models.Task) taskReport {
+	report := taskReport{
+		GeneratedAt: time.Now().UTC(),
+		Total:       len(tasks),
+	}
+
+	for _, task := range tasks {
+		switch task.Status {
+		case models.TaskStatusCompleted:
+			report.Completed++
+		case models.TaskStatusFailed:
+			report.Failed++
+		}
+
+		report.Tasks = append(report.Tasks, taskReportItem{
+			ID:           task.ID,
+			Title:        task.Title,
+			Status:       task.Status,
+			ResourceType: task.ResourceType,
+			ResourceName: task.ResourceName,
+			DurationMs:   task.Duration,
+			Error:        task.Error,
+			CreatedAt:    task.CreatedAt,
+			CompletedAt:  task.CompletedAt,
+		})
+	}
+
+	return report
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/executor"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// TaskTemplateHandler manages reusable task templates; instantiation lives
+// on TaskHandler.CreateFromTemplate
+type TaskTemplateHandler struct {
+	storage *storage.Storage
+	logger  *zap.Logger
+}
+
+// NewTaskTemplateHandler creates a new task template handler
+func NewTaskTemplateHandler(storage *storage.Storage, logger *zap.Logger) *TaskTemplateHandler {
+	return &TaskTemplateHandler{storage: storage, logger: logger}
+}
+
+// TaskTemplateRequest represents template create/update requests
+type TaskTemplateRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Description  string `json:"description,omitempty"`
+	Title        string `json:"title" binding:"required"`
+	Prompt       string `json:"prompt" binding:"required"`
+	ResourceType string `json:"resource_type" binding:"required,oneof=robot team collaboration workflow"`
+	ResourceName string `json:"resource_name" binding:"required"`
+	Mode         string `json:"mode,omitempty"`
+	Environment  string `json:"environment,omitempty"`
+	GitURL       string `json:"git_url,omitempty"`
+	BranchName   string `json:"branch_name,omitempty"`
+	AutoPR       bool   `json:"auto_pr,omitempty"`
+	WorkspaceID  uint   `json:"workspace_id"`
+
+	// Parameters are default template variables; instantiation overrides win
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	Priority models.TaskPriority `json:"priority,omitempty" binding:"omitempty,oneof=high normal low"`
+}
+
+// Create stores a new task template
+func (h *TaskTemplateHandler) Create(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	var req TaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Use the active workspace if not specified
+	if req.WorkspaceID == 0 {
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.WorkspaceID = id
+	}
+
+	if !hasWorkspaceRole(h.storage, req.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	// A broken prompt template should fail here, not at every instantiation
+	if err := executor.ValidatePromptTemplate(req.Prompt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template := &models.TaskTemplate{
+		WorkspaceID:  req.WorkspaceID,
+		UserID:       userID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Title:        req.Title,
+		Prompt:       req.Prompt,
+		ResourceType: req.ResourceType,
+		ResourceName: req.ResourceName,
+		Mode:         req.Mode,
+		Environment:  req.Environment,
+		GitURL:       req.GitURL,
+		BranchName:   req.BranchName,
+		AutoPR:       req.AutoPR,
+		Priority:     req.Priority,
+	}
+	if template.Priority == "" {
+		template.Priority = models.TaskPriorityNormal
+	}
+	if len(req.Parameters) > 0 {
+		data, err := json.Marshal(req.Parameters)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters"})
+			return
+		}
+		template.Parameters = string(data)
+	}
+
+	if err := h.storage.TaskTemplates().Create(template); err != nil {
+		h.logger.Error("Failed to create task template", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create template"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// List returns the templates in a workspace
+func (h *TaskTemplateHandler) List(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	workspaceID, err := strconv.ParseUint(c.Query("workspace_id"), 10, 32)
+	if err != nil || workspaceID == 0 {
+		id, err := activeWorkspace(h.storage, c, userID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		workspaceID = uint64(id)
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	templates, err := h.storage.TaskTemplates().ListByWorkspace(uint(workspaceID))
+	if err != nil {
+		h.logger.Error("Failed to list task templates", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates, "total": len(templates)})
+}
+
+// Get retrieves a template by ID
+func (h *TaskTemplateHandler) Get(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.storage.TaskTemplates().GetByID(uint(templateID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, template.WorkspaceID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Update replaces a template's definition
+func (h *TaskTemplateHandler) Update(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.storage.TaskTemplates().GetByID(uint(templateID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, template.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var req TaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := executor.ValidatePromptTemplate(req.Prompt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template.Name = req.Name
+	template.Description = req.Description
+	template.Title = req.Title
+	template.Prompt = req.Prompt
+	template.ResourceType = req.ResourceType
+	template.ResourceName = req.ResourceName
+	template.Mode = req.Mode
+	template.Environment = req.Environment
+	template.GitURL = req.GitURL
+	template.BranchName = req.BranchName
+	template.AutoPR = req.AutoPR
+	if req.Priority != "" {
+		template.Priority = req.Priority
+	}
+	template.Parameters = ""
+	if len(req.Parameters) > 0 {
+		data, err := json.Marshal(req.Parameters)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parameters"})
+			return
+		}
+		template.Parameters = string(data)
+	}
+
+	if err := h.storage.TaskTemplates().Update(template); err != nil {
+		h.logger.Error("Failed to update task template", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Delete removes a template
+func (h *TaskTemplateHandler) Delete(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	templateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.storage.TaskTemplates().GetByID(uint(templateID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, template.WorkspaceID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if err := h.storage.TaskTemplates().Delete(template.ID); err != nil {
+		h.logger.Error("Failed to delete task template", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted"})
+}
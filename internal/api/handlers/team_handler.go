@@ -28,15 +28,15 @@ func NewTeamHandler(storage *storage.Storage, logger *zap.Logger) *TeamHandler {
 func (h *TeamHandler) List(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 
-	// Get default workspace
-	workspaces, err := h.storage.Workspaces().ListByUser(userID)
-	if err != nil || len(workspaces) == 0 {
+	// Resolve the active workspace
+	workspaceID, err := activeWorkspace(h.storage, c, userID)
+	if err != nil {
 		c.JSON(http.StatusOK, gin.H{"teams": []models.Resource{}})
 		return
 	}
 
 	// Get all team resources
-	teams, err := h.storage.Resources().List(workspaces[0].ID, models.ResourceTypeTeam, 100, 0)
+	teams, err := h.storage.Resources().List(workspaceID, models.ResourceTypeTeam, 100, 0)
 	if err != nil {
 		h.logger.Error("Failed to list teams", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list teams"})
@@ -51,15 +51,15 @@ func (h *TeamHandler) Get(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 	teamName := c.Param("name")
 
-	// Get default workspace
-	workspaces, err := h.storage.Workspaces().ListByUser(userID)
-	if err != nil || len(workspaces) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No workspace found"})
+	// Resolve the active workspace
+	workspaceID, err := activeWorkspace(h.storage, c, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Get team resource
-	team, err := h.storage.Resources().GetByName(workspaces[0].ID, teamName, models.ResourceTypeTeam)
+	team, err := h.storage.Resources().GetByName(workspaceID, teamName, models.ResourceTypeTeam)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
 		return
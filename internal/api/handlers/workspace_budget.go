@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/orchestrator"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// budgetRequest sets a workspace's token budget
+type budgetRequest struct {
+	Tokens int `json:"tokens" binding:"min=0"`
+}
+
+// SetWorkspaceBudget sets the workspace's token budget over the rolling
+// budget window; 0 removes the cap (admin only)
+func (h *AdminHandler) SetWorkspaceBudget(c *gin.Context) {
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	var req budgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace.TokenBudget = req.Tokens
+	if err := h.storage.Workspaces().Update(workspace); err != nil {
+		h.logger.Error("Failed to update workspace budget", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+// Budget reports a workspace's token budget and what remains of it over
+// the rolling budget window
+func (h *WorkspaceHandler) Budget(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	since := time.Now().Add(-orchestrator.WorkspaceBudgetWindow)
+	used, err := h.storage.Tasks().SumTokensForWorkspace(workspace.ID, since)
+	if err != nil {
+		h.logger.Error("Failed to sum workspace token usage", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute usage"})
+		return
+	}
+
+	response := gin.H{
+		"workspace_id": workspace.ID,
+		"token_budget": workspace.TokenBudget,
+		"used_tokens":  used,
+		"window_days":  int(orchestrator.WorkspaceBudgetWindow.Hours() / 24),
+	}
+	if workspace.TokenBudget > 0 {
+		remaining := int64(workspace.TokenBudget) - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		response["remaining_tokens"] = remaining
+	}
+
+	c.JSON(http.StatusOK, response)
+}
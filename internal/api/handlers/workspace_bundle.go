@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/executor"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// secretPlaceholder replaces secret values in exported bundles; the keys
+// stay in place as references to fill in at import time
+const secretPlaceholder = "<redacted>"
+
+// Export produces a multi-document YAML bundle of every resource in the
+// workspace, for moving robots between environments. Secret values are
+// redacted; only the keys travel with the bundle.
+func (h *WorkspaceHandler) Export(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if _, err := h.storage.Workspaces().GetByID(uint(workspaceID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	resources, err := h.storage.Resources().List(uint(workspaceID), "", -1, 0)
+	if err != nil {
+		h.logger.Error("Failed to list resources for export", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list resources"})
+		return
+	}
+
+	docs := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		spec := resource.Spec
+		if resource.Type == models.ResourceTypeSecret {
+			spec = redactSecretSpec(spec)
+		}
+		docs = append(docs, strings.TrimSpace(spec))
+	}
+
+	bundle := strings.Join(docs, "\n---\n") + "\n"
+	c.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=workspace-%d-bundle.yaml", workspaceID))
+	c.Data(http.StatusOK, "application/x-yaml", []byte(bundle))
+}
+
+// redactSecretSpec blanks a Secret's data values so bundles can move
+// between environments without leaking credentials
+func redactSecretSpec(spec string) string {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(spec), &doc); err != nil {
+		return spec
+	}
+	specSection, ok := doc["spec"].(map[string]interface{})
+	if !ok {
+		return spec
+	}
+	data, ok := specSection["data"].(map[string]interface{})
+	if !ok {
+		return spec
+	}
+	for key := range data {
+		data[key] = secretPlaceholder
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return spec
+	}
+	return string(out)
+}
+
+// bundleEntry is one parsed document of an import bundle
+type bundleEntry struct {
+	resource     crd.Resource
+	resourceType models.ResourceType
+	raw          []byte
+}
+
+// Import applies a multi-document YAML bundle to the workspace. Every
+// document is parsed and validated before anything is written, so a bad
+// bundle does not leave the workspace half-imported. Secrets whose values
+// are still redacted are skipped rather than overwriting real ones.
+func (h *WorkspaceHandler) Import(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if _, err := h.storage.Workspaces().GetByID(uint(workspaceID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	// Members need at least editor rights to import resources
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+
+	// First pass: parse and validate the whole bundle before writing
+	entries, skipped, err := parseBundle(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	applied := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		metadata := entry.resource.GetMetadata()
+
+		existing, err := h.storage.Resources().GetByName(uint(workspaceID), metadata.Name, entry.resourceType)
+		if err == nil {
+			existing.Spec = string(entry.raw)
+			existing.Description = metadata.Description
+			if err := h.storage.Resources().Update(existing); err != nil {
+				h.logger.Error("Failed to update imported resource", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to import %s", metadata.Name)})
+				return
+			}
+			executor.InvalidateResource(existing.WorkspaceID, existing.Name, existing.Type)
+			recordAudit(h.storage, h.logger, c, uint(workspaceID),
+				models.AuditActionResourceUpdate, string(entry.resourceType), existing.ID, existing.Name, "")
+		} else {
+			created := &models.Resource{
+				WorkspaceID: uint(workspaceID),
+				Type:        entry.resourceType,
+				Name:        metadata.Name,
+				Description: metadata.Description,
+				Spec:        string(entry.raw),
+			}
+			if err := h.storage.Resources().Create(created); err != nil {
+				h.logger.Error("Failed to create imported resource", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to import %s", metadata.Name)})
+				return
+			}
+			recordAudit(h.storage, h.logger, c, uint(workspaceID),
+				models.AuditActionResourceCreate, string(entry.resourceType), created.ID, created.Name, "")
+		}
+		applied = append(applied, fmt.Sprintf("%s/%s", entry.resourceType, metadata.Name))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"applied": applied,
+		"skipped": skipped,
+	})
+}
+
+// parseBundle splits a multi-document YAML bundle, validating every
+// document; secrets with redacted values are reported as skipped
+func parseBundle(data []byte) ([]bundleEntry, []string, error) {
+	parser := crd.NewParser()
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	var entries []bundleEntry
+	var skipped []string
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode YAML document: %w", err)
+		}
+
+		raw, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal document: %w", err)
+		}
+
+		resource, err := parser.Parse(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resourceType, ok := resourceTypeForKind(resource.GetKind())
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown resource kind: %s", resource.GetKind())
+		}
+
+		// Exported bundles carry redacted secret values; importing them
+		// would overwrite real credentials with placeholders
+		if secret, ok := resource.(*crd.Secret); ok && secretIsRedacted(secret) {
+			skipped = append(skipped, fmt.Sprintf("secret/%s", secret.Metadata.Name))
+			continue
+		}
+
+		entries = append(entries, bundleEntry{
+			resource:     resource,
+			resourceType: resourceType,
+			raw:          raw,
+		})
+	}
+	return entries, skipped, nil
+}
+
+// secretIsRedacted reports whether any of a secret's values is still the
+// export placeholder
+func secretIsRedacted(secret *crd.Secret) bool {
+	for _, value := range secret.Spec.Data {
+		if value == secretPlaceholder {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceTypeForKind maps a CRD kind to its stored resource type
+func resourceTypeForKind(kind crd.ResourceKind) (models.ResourceType, bool) {
+	switch kind {
+	case crd.KindSoul:
+		return models.ResourceTypeSoul, true
+	case crd.KindMind:
+		return models.ResourceTypeMind, true
+	case crd.KindCraft:
+		return models.ResourceTypeCraft, true
+	case crd.KindRobot:
+		return models.ResourceTypeRobot, true
+	case crd.KindTeam:
+		return models.ResourceTypeTeam, true
+	case crd.KindCollaboration:
+		return models.ResourceTypeCollaboration, true
+	case crd.KindWorkflow:
+		return models.ResourceTypeWorkflow, true
+	case crd.KindEnvironment:
+		return models.ResourceTypeEnvironment, true
+	case crd.KindKnowledge:
+		return models.ResourceTypeKnowledge, true
+	case crd.KindSecret:
+		return models.ResourceTypeSecret, true
+	case crd.KindTrigger:
+		return models.ResourceTypeTrigger, true
+	}
+	return "", false
+}
@@ -3,9 +3,12 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/events"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"go.uber.org/zap"
@@ -29,6 +32,8 @@ func NewWorkspaceHandler(storage *storage.Storage, logger *zap.Logger) *Workspac
 type CreateWorkspaceRequest struct {
 	Name        string `json:"name" binding:"required,min=1,max=100"`
 	Description string `json:"description"`
+	Proxy       string `json:"proxy,omitempty"`
+	RedactPII   bool   `json:"redact_pii,omitempty"`
 }
 
 // Create creates a new workspace
@@ -44,6 +49,8 @@ func (h *WorkspaceHandler) Create(c *gin.Context) {
 	workspace := &models.Workspace{
 		Name:        req.Name,
 		Description: req.Description,
+		Proxy:       req.Proxy,
+		RedactPII:   req.RedactPII,
 		UserID:      userID,
 	}
 
@@ -71,8 +78,8 @@ func (h *WorkspaceHandler) Get(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if workspace.UserID != userID {
+	// Check access
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleViewer) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -84,7 +91,7 @@ func (h *WorkspaceHandler) Get(c *gin.Context) {
 func (h *WorkspaceHandler) List(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 
-	workspaces, err := h.storage.Workspaces().ListByUser(userID)
+	workspaces, err := h.storage.Workspaces().ListAccessible(userID)
 	if err != nil {
 		h.logger.Error("Failed to list workspaces", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list workspaces"})
@@ -94,6 +101,121 @@ func (h *WorkspaceHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"workspaces": workspaces})
 }
 
+// SetDefault flags a workspace the user owns as their default, used when a
+// request names no workspace
+func (h *WorkspaceHandler) SetDefault(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	// Only owned workspaces can be the default; the flag selects among
+	// the user's own workspaces
+	if workspace.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if err := h.storage.Workspaces().SetDefault(userID, workspace.ID); err != nil {
+		h.logger.Error("Failed to set default workspace", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set default workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Default workspace set"})
+}
+
+// Stream multiplexes events for every task in the workspace over one
+// WebSocket connection, for dashboards. Optional status and type query
+// parameters filter the forwarded events.
+func (h *WorkspaceHandler) Stream(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if _, err := h.storage.Workspaces().GetByID(uint(workspaceID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	filterStatus := c.Query("status")
+	filterType := c.Query("type")
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("Failed to upgrade WebSocket", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	drain := registerStreamClient(conn)
+	defer unregisterStreamClient(conn)
+
+	eventCh := events.GetBroadcaster().SubscribeWorkspace(uint(workspaceID))
+	defer events.GetBroadcaster().UnsubscribeWorkspace(uint(workspaceID), eventCh)
+
+	keepalive := time.NewTicker(30 * time.Second)
+	defer keepalive.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(done)
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-drain:
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(time.Second))
+			return
+
+		case event := <-eventCh:
+			if filterStatus != "" && event.Status != filterStatus {
+				continue
+			}
+			if filterType != "" && event.EventType != filterType {
+				continue
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				h.logger.Error("Failed to write WebSocket message", zap.Error(err))
+				return
+			}
+
+		case <-keepalive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil,
+				time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
+
 // Update updates a workspace
 func (h *WorkspaceHandler) Update(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
@@ -109,8 +231,8 @@ func (h *WorkspaceHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if workspace.UserID != userID {
+	// Check access
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleEditor) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
@@ -123,6 +245,8 @@ func (h *WorkspaceHandler) Update(c *gin.Context) {
 
 	workspace.Name = req.Name
 	workspace.Description = req.Description
+	workspace.Proxy = req.Proxy
+	workspace.RedactPII = req.RedactPII
 
 	if err := h.storage.Workspaces().Update(workspace); err != nil {
 		h.logger.Error("Failed to update workspace", zap.Error(err))
@@ -148,8 +272,8 @@ func (h *WorkspaceHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Check ownership
-	if workspace.UserID != userID {
+	// Check access
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleOwner) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
 		return
 	}
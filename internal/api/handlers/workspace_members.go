@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// AddMemberRequest invites a user to a workspace under a role
+type AddMemberRequest struct {
+	Username string               `json:"username" binding:"required"`
+	Role     models.WorkspaceRole `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+// UpdateMemberRequest changes a member's role
+type UpdateMemberRequest struct {
+	Role models.WorkspaceRole `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+// ListMembers lists a workspace's members; any member may look
+func (h *WorkspaceHandler) ListMembers(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	members, err := h.storage.WorkspaceMembers().ListByWorkspace(uint(workspaceID))
+	if err != nil {
+		h.logger.Error("Failed to list members", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AddMember invites a user to the workspace; only the owner may invite
+func (h *WorkspaceHandler) AddMember(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the workspace owner can invite members"})
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.storage.Users().GetByUsername(req.Username)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if user.ID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot invite yourself"})
+		return
+	}
+
+	if _, err := h.storage.WorkspaceMembers().Get(uint(workspaceID), user.ID); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already a member"})
+		return
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: uint(workspaceID),
+		UserID:      user.ID,
+		Role:        req.Role,
+		InvitedBy:   userID,
+	}
+	if err := h.storage.WorkspaceMembers().Create(member); err != nil {
+		h.logger.Error("Failed to add member", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add member"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateMember changes a member's role; only the owner may change roles
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	memberID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the workspace owner can change roles"})
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.storage.WorkspaceMembers().Get(uint(workspaceID), uint(memberID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		return
+	}
+
+	member.Role = req.Role
+	if err := h.storage.WorkspaceMembers().Update(member); err != nil {
+		h.logger.Error("Failed to update member", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember removes a member; the owner may remove anyone and members may
+// remove themselves
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+	memberID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	isOwner := hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleOwner)
+	if !isOwner && uint(memberID) != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	if err := h.storage.WorkspaceMembers().Delete(uint(workspaceID), uint(memberID)); err != nil {
+		h.logger.Error("Failed to remove member", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove member"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// quotaResourceTypes are the kinds counted in usage summaries and eligible
+// for per-workspace quotas
+var quotaResourceTypes = []models.ResourceType{
+	models.ResourceTypeSoul,
+	models.ResourceTypeMind,
+	models.ResourceTypeCraft,
+	models.ResourceTypeRobot,
+	models.ResourceTypeTeam,
+	models.ResourceTypeCollaboration,
+}
+
+// checkResourceQuota returns an informative error when creating another
+// resource of this type would exceed the workspace's quota
+func checkResourceQuota(store *storage.Storage, workspaceID uint, resourceType models.ResourceType) error {
+	workspace, err := store.Workspaces().GetByID(workspaceID)
+	if err != nil {
+		return nil
+	}
+	quota := workspace.QuotaFor(resourceType)
+	if quota == 0 {
+		return nil
+	}
+
+	count, err := store.Resources().CountByType(workspaceID, resourceType)
+	if err != nil {
+		return nil
+	}
+	if count >= int64(quota) {
+		return fmt.Errorf("workspace quota reached: at most %d %s resources allowed (currently %d)", quota, resourceType, count)
+	}
+	return nil
+}
+
+// Usage summarizes a workspace's resource counts against its quotas
+func (h *WorkspaceHandler) Usage(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	usage := make(map[string]gin.H, len(quotaResourceTypes))
+	for _, resourceType := range quotaResourceTypes {
+		count, err := h.storage.Resources().CountByType(workspace.ID, resourceType)
+		if err != nil {
+			h.logger.Error("Failed to count resources", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute usage"})
+			return
+		}
+		entry := gin.H{"count": count}
+		if quota := workspace.QuotaFor(resourceType); quota > 0 {
+			entry["quota"] = quota
+		}
+		usage[string(resourceType)] = entry
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workspace_id": workspace.ID,
+		"usage":        usage,
+	})
+}
+
+// SetWorkspaceQuotas replaces a workspace's per-type resource quotas
+// (admin only)
+func (h *AdminHandler) SetWorkspaceQuotas(c *gin.Context) {
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	var quotas map[string]int
+	if err := c.ShouldBindJSON(&quotas); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	valid := make(map[string]bool, len(quotaResourceTypes))
+	for _, resourceType := range quotaResourceTypes {
+		valid[string(resourceType)] = true
+	}
+	for name, max := range quotas {
+		if !valid[name] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown resource type: %s", name)})
+			return
+		}
+		if max < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "quotas must not be negative"})
+			return
+		}
+	}
+
+	if len(quotas) == 0 {
+		workspace.ResourceQuotas = ""
+	} else {
+		encoded, err := json.Marshal(quotas)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode quotas"})
+			return
+		}
+		workspace.ResourceQuotas = string(encoded)
+	}
+
+	if err := h.storage.Workspaces().Update(workspace); err != nil {
+		h.logger.Error("Failed to update workspace", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workspace_id": workspace.ID, "quotas": quotas})
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// activeWorkspace resolves the workspace a request operates on: the explicit
+// selection from the X-Xgent-Workspace header or workspace query parameter
+// when present (and accessible to the caller), otherwise the user's default
+// workspace
+func activeWorkspace(store *storage.Storage, c *gin.Context, userID uint) (uint, error) {
+	if id, ok := middleware.GetWorkspaceID(c); ok {
+		if !hasWorkspaceRole(store, id, userID, models.WorkspaceRoleViewer) {
+			return 0, fmt.Errorf("access denied")
+		}
+		return id, nil
+	}
+
+	workspace, err := store.Workspaces().GetDefault(userID)
+	if err != nil {
+		return 0, fmt.Errorf("no workspace found")
+	}
+	return workspace.ID, nil
+}
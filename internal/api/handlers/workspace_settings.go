@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// GetSettings returns the workspace's execution defaults
+func (h *WorkspaceHandler) GetSettings(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace.SettingsValues())
+}
+
+// UpdateSettings replaces the workspace's execution defaults
+func (h *WorkspaceHandler) UpdateSettings(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	// Check access
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var settings models.WorkspaceSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if settings.Timezone != "" {
+		if _, err := time.LoadLocation(settings.Timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown timezone: " + settings.Timezone})
+			return
+		}
+	}
+
+	blob, err := json.Marshal(settings)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace.Settings = string(blob)
+	if err := h.storage.Workspaces().Update(workspace); err != nil {
+		h.logger.Error("Failed to update workspace settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// storageQuotaRequest sets a workspace's storage quota
+type storageQuotaRequest struct {
+	Bytes int64 `json:"bytes" binding:"min=0"`
+	Files int   `json:"files" binding:"min=0"`
+}
+
+// SetWorkspaceStorageQuota sets the workspace's attachment and artifact
+// storage quota; 0 removes a cap (admin only)
+func (h *AdminHandler) SetWorkspaceStorageQuota(c *gin.Context) {
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	var req storageQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace.StorageQuotaBytes = req.Bytes
+	workspace.StorageQuotaFiles = req.Files
+	if err := h.storage.Workspaces().Update(workspace); err != nil {
+		h.logger.Error("Failed to update workspace storage quota", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workspace"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+// StorageUsage reports a workspace's attachment and artifact footprint
+// against its storage quota
+func (h *WorkspaceHandler) StorageUsage(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, err := h.storage.Workspaces().GetByID(uint(workspaceID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workspace not found"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, workspace.ID, userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	usage, err := h.storage.WorkspaceStorageUsage(workspace.ID)
+	if err != nil {
+		h.logger.Error("Failed to compute storage usage", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute usage"})
+		return
+	}
+
+	response := gin.H{
+		"workspace_id": workspace.ID,
+		"used_bytes":   usage.Bytes,
+		"used_files":   usage.Files,
+	}
+	warning := false
+	if workspace.StorageQuotaBytes > 0 {
+		response["quota_bytes"] = workspace.StorageQuotaBytes
+		remaining := workspace.StorageQuotaBytes - usage.Bytes
+		if remaining < 0 {
+			remaining = 0
+		}
+		response["remaining_bytes"] = remaining
+		warning = warning || usage.Bytes*10 >= workspace.StorageQuotaBytes*8
+	}
+	if workspace.StorageQuotaFiles > 0 {
+		response["quota_files"] = workspace.StorageQuotaFiles
+		remaining := int64(workspace.StorageQuotaFiles) - usage.Files
+		if remaining < 0 {
+			remaining = 0
+		}
+		response["remaining_files"] = remaining
+		warning = warning || usage.Files*10 >= int64(workspace.StorageQuotaFiles)*8
+	}
+	response["warning"] = warning
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// webhookEvents are the events a webhook may subscribe to: task lifecycle
+// transitions and periodic report digests
+var webhookEvents = map[string]bool{
+	"task.started":   true,
+	"task.completed": true,
+	"task.failed":    true,
+	"task.cancelled": true,
+	"digest.daily":   true,
+	"digest.weekly":  true,
+}
+
+// CreateWebhookRequest registers a webhook endpoint for a workspace
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"` // empty subscribes to all events
+}
+
+// UpdateWebhookRequest changes a webhook's endpoint, filter or state
+type UpdateWebhookRequest struct {
+	URL    string    `json:"url,omitempty" binding:"omitempty,url"`
+	Secret *string   `json:"secret,omitempty"`
+	Events *[]string `json:"events,omitempty"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+// ListWebhooks lists a workspace's webhooks; any member may look
+func (h *WorkspaceHandler) ListWebhooks(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleViewer) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	webhooks, err := h.storage.Webhooks().ListByWorkspace(uint(workspaceID))
+	if err != nil {
+		h.logger.Error("Failed to list webhooks", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list webhooks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// CreateWebhook registers a webhook; editors and the owner may manage them
+func (h *WorkspaceHandler) CreateWebhook(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := encodeWebhookEvents(req.Events)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook := &models.Webhook{
+		WorkspaceID: uint(workspaceID),
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      events,
+		Active:      true,
+		CreatedBy:   userID,
+	}
+	if err := h.storage.Webhooks().Create(webhook); err != nil {
+		h.logger.Error("Failed to create webhook", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// UpdateWebhook changes a webhook's endpoint, event filter or active state
+func (h *WorkspaceHandler) UpdateWebhook(c *gin.Context) {
+	webhook, ok := h.loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.URL != "" {
+		webhook.URL = req.URL
+	}
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.Events != nil {
+		events, err := encodeWebhookEvents(*req.Events)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		webhook.Events = events
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := h.storage.Webhooks().Update(webhook); err != nil {
+		h.logger.Error("Failed to update webhook", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook removes a webhook
+func (h *WorkspaceHandler) DeleteWebhook(c *gin.Context) {
+	webhook, ok := h.loadWebhook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.storage.Webhooks().Delete(webhook.ID); err != nil {
+		h.logger.Error("Failed to delete webhook", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+// loadWebhook resolves the webhook from the route, checking that it belongs
+// to the workspace and that the caller may manage it
+func (h *WorkspaceHandler) loadWebhook(c *gin.Context) (*models.Webhook, bool) {
+	userID, _ := middleware.GetUserID(c)
+	workspaceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return nil, false
+	}
+	webhookID, err := strconv.ParseUint(c.Param("webhook_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return nil, false
+	}
+
+	if !hasWorkspaceRole(h.storage, uint(workspaceID), userID, models.WorkspaceRoleEditor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return nil, false
+	}
+
+	webhook, err := h.storage.Webhooks().GetByID(uint(webhookID))
+	if err != nil || webhook.WorkspaceID != uint(workspaceID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// encodeWebhookEvents validates an event filter and encodes it for storage
+func encodeWebhookEvents(events []string) (string, error) {
+	if len(events) == 0 {
+		return "", nil
+	}
+	for _, event := range events {
+		if !webhookEvents[event] {
+			return "", fmt.Errorf("unknown event: %s", event)
+		}
+	}
+	encoded, err := json.Marshal(events)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// streamClients tracks connected stream WebSocket clients so shutdown can
+// notify them and wait for them to disconnect instead of dropping the
+// connections mid-task. Each streaming loop selects on the drain channel and
+// performs its own farewell write, keeping all writes on one goroutine.
+var streamClients = struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	drain   chan struct{}
+	started bool
+}{drain: make(chan struct{})}
+
+// registerStreamClient adds a client connection and returns the channel that
+// closes when the server starts draining
+func registerStreamClient(conn *websocket.Conn) <-chan struct{} {
+	streamClients.mu.Lock()
+	defer streamClients.mu.Unlock()
+	streamClients.wg.Add(1)
+	return streamClients.drain
+}
+
+// unregisterStreamClient removes a client connection
+func unregisterStreamClient(conn *websocket.Conn) {
+	streamClients.wg.Done()
+}
+
+// closingMessage is the farewell event sent to stream clients on shutdown;
+// reconnect_in_ms hints when the client should retry
+func closingMessage(taskID uint) gin.H {
+	return gin.H{
+		"type":            "closing",
+		"task_id":         taskID,
+		"message":         "Server is shutting down, please reconnect",
+		"reconnect_in_ms": 2000,
+	}
+}
+
+// DrainWebSockets tells every connected stream client to reconnect and waits
+// up to timeout for them to disconnect. Called by the server before the HTTP
+// listener stops.
+func DrainWebSockets(timeout time.Duration, logger *zap.Logger) {
+	streamClients.mu.Lock()
+	if !streamClients.started {
+		streamClients.started = true
+		close(streamClients.drain)
+	}
+	streamClients.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		streamClients.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		logger.Warn("WebSocket drain timed out, closing remaining connections")
+	}
+}
@@ -10,17 +10,34 @@ import (
 
 // Claims represents JWT claims
 type Claims struct {
-	UserID   uint   `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	UserID   uint     `json:"user_id"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Scopes   []string `json:"scopes,omitempty"` // empty means full access
 	jwt.RegisteredClaims
 }
 
-// Auth creates a JWT authentication middleware
-func Auth(jwtSecret string) gin.HandlerFunc {
+// TokenValidator checks whether an issued token (by JWT ID) is still valid,
+// so revoked sessions stop authenticating before their expiry
+type TokenValidator interface {
+	ValidateToken(tokenID, ip string) bool
+}
+
+// Auth creates a JWT authentication middleware. tokens may be nil to skip
+// revocation checks.
+func Auth(jwtSecret string, tokens TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
+
+		// Browsers cannot set headers on WebSocket handshakes; accept the
+		// token from the query string or the subprotocol list instead
+		if authHeader == "" && isWebSocketUpgrade(c.Request) {
+			if token := webSocketToken(c); token != "" {
+				authHeader = "Bearer " + token
+			}
+		}
+
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
 			c.Abort()
@@ -49,10 +66,28 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 		}
 
 		if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+			// Special-purpose tokens mark themselves via the subject
+			// claim (embed tokens set "xgent-embed"); they are only
+			// valid on their own routes, never against the normal API
+			if claims.Subject != "" {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+				c.Abort()
+				return
+			}
+
+			// Reject revoked sessions
+			if tokens != nil && claims.ID != "" && !tokens.ValidateToken(claims.ID, c.ClientIP()) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
+				c.Abort()
+				return
+			}
+
 			// Set user info in context
 			c.Set("user_id", claims.UserID)
 			c.Set("username", claims.Username)
 			c.Set("email", claims.Email)
+			c.Set("scopes", claims.Scopes)
+			c.Set("token_id", claims.ID)
 			c.Next()
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
@@ -62,6 +97,29 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 	}
 }
 
+// isWebSocketUpgrade reports whether the request is a WebSocket handshake
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// webSocketToken extracts a bearer token from a WebSocket handshake: the
+// token query parameter, or a Sec-WebSocket-Protocol header of the form
+// "bearer, <token>" (the upgrader echoes "bearer" back during negotiation)
+func webSocketToken(c *gin.Context) string {
+	if token := c.Query("token"); token != "" {
+		return token
+	}
+	protocols := strings.Split(c.GetHeader("Sec-WebSocket-Protocol"), ",")
+	for i := range protocols {
+		protocols[i] = strings.TrimSpace(protocols[i])
+	}
+	if len(protocols) == 2 && protocols[0] == "bearer" {
+		return protocols[1]
+	}
+	return ""
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(c *gin.Context) (uint, bool) {
 	userID, exists := c.Get("user_id")
@@ -72,6 +130,11 @@ func GetUserID(c *gin.Context) (uint, bool) {
 	return id, ok
 }
 
+// GetTokenID extracts the JWT ID (jti) from context
+func GetTokenID(c *gin.Context) string {
+	return c.GetString("token_id")
+}
+
 // GetUsername extracts username from context
 func GetUsername(c *gin.Context) (string, bool) {
 	username, exists := c.Get("username")
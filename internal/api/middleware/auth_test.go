@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testJWTSecret = "test-secret"
+
+// authRequest runs one request with the given bearer token through a router
+// protected by Auth and returns the response status
+func authRequest(t *testing.T, token string) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/protected", Auth(testJWTSecret, nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+// signClaims signs claims with the test secret, as the server would
+func signClaims(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestAuthAcceptsUserToken(t *testing.T) {
+	token := signClaims(t, &Claims{
+		UserID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+
+	if code := authRequest(t, token); code != http.StatusOK {
+		t.Fatalf("user token rejected with status %d", code)
+	}
+}
+
+// TestAuthRejectsEmbedToken guards against embed tokens — signed with the
+// same secret but marked by their subject claim — authenticating on the
+// normal API, where they would grant the minting user's full access
+func TestAuthRejectsEmbedToken(t *testing.T) {
+	token := signClaims(t, jwt.MapClaims{
+		"user_id": 1,
+		"task_id": 2,
+		"origins": []string{"https://portal.example.com"},
+		"sub":     "xgent-embed",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+
+	if code := authRequest(t, token); code != http.StatusUnauthorized {
+		t.Fatalf("embed token accepted with status %d, want %d", code, http.StatusUnauthorized)
+	}
+}
@@ -3,17 +3,32 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/xcode-ai/xgent-go/internal/storage"
 )
 
-// RequestID adds a unique request ID to each request
+// RequestID tags each request with an ID echoed in the X-Request-ID response
+// header, so clients can quote it when reporting problems. A client-supplied
+// X-Request-ID (or X-Trace-ID from tracing setups) is kept as-is.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = c.GetHeader("X-Trace-ID")
+		}
 		if requestID == "" {
 			requestID = uuid.New().String()
 		}
 		c.Set("request_id", requestID)
 		c.Header("X-Request-ID", requestID)
+
+		// Attach the ID to the request context so slow-query logs can be
+		// correlated with the originating request
+		c.Request = c.Request.WithContext(storage.WithRequestID(c.Request.Context(), requestID))
 		c.Next()
 	}
 }
+
+// GetRequestID extracts the request ID from context
+func GetRequestID(c *gin.Context) string {
+	return c.GetString("request_id")
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleSource resolves a user's platform-wide role, so roles changed in the
+// database take effect without reissuing tokens
+type RoleSource interface {
+	GetRole(userID uint) (string, error)
+}
+
+// RequireRole rejects requests from users without the given platform-wide
+// role; admins pass every check
+func RequireRole(users RoleSource, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			c.Abort()
+			return
+		}
+
+		actual, err := users.GetRole(userID)
+		if err != nil || (actual != role && actual != "admin") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient role"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
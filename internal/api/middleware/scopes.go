@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Scopes that can be embedded in tokens and API keys. Tokens issued by the
+// normal login flow carry no scopes and retain full access; integration
+// tokens list exactly the scopes they are allowed.
+const (
+	ScopeTasksRead      = "tasks:read"
+	ScopeTasksWrite     = "tasks:write"
+	ScopeResourcesRead  = "resources:read"
+	ScopeResourcesWrite = "resources:write"
+	ScopeAdmin          = "admin"
+)
+
+// KnownScopes lists every valid scope, for validating mint requests
+var KnownScopes = []string{
+	ScopeTasksRead,
+	ScopeTasksWrite,
+	ScopeResourcesRead,
+	ScopeResourcesWrite,
+	ScopeAdmin,
+}
+
+// IsKnownScope reports whether s is a recognized scope
+func IsKnownScope(s string) bool {
+	for _, scope := range KnownScopes {
+		if scope == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GetScopes extracts token scopes from context; nil means an unscoped token
+// with full access
+func GetScopes(c *gin.Context) []string {
+	value, exists := c.Get("scopes")
+	if !exists {
+		return nil
+	}
+	scopes, _ := value.([]string)
+	return scopes
+}
+
+// HasScope reports whether the request's token grants the scope. Unscoped
+// tokens and tokens holding the admin scope grant everything.
+func HasScope(c *gin.Context, scope string) bool {
+	scopes := GetScopes(c)
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, s := range scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireScope creates a middleware that rejects tokens lacking the scope
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasScope(c, scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Token missing required scope: " + scope})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
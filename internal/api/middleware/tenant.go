@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// WorkspaceSource lists the workspaces a user may access, for building the
+// request's tenant scope
+type WorkspaceSource interface {
+	ListAccessible(userID uint) ([]*models.Workspace, error)
+}
+
+// Tenant attaches the authenticated user's tenant — their ID and accessible
+// workspace IDs — to the request context. Handlers querying through
+// storage.Scoped(c.Request.Context()) are then automatically constrained to
+// that tenant's rows. Must run after Auth.
+func Tenant(workspaces WorkspaceSource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		tenant := &storage.Tenant{UserID: userID}
+		// A lookup failure leaves the workspace list empty, which fails
+		// closed: workspace-owned rows match nothing
+		if accessible, err := workspaces.ListAccessible(userID); err == nil {
+			tenant.WorkspaceIDs = make([]uint, 0, len(accessible))
+			for _, workspace := range accessible {
+				tenant.WorkspaceIDs = append(tenant.WorkspaceIDs, workspace.ID)
+			}
+		}
+
+		c.Request = c.Request.WithContext(storage.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xcode-ai/xgent-go/internal/tracing"
+)
+
+// Tracing opens a server span per request, continuing the trace from a
+// client-supplied W3C traceparent header when present. Route, method and
+// status land on the span so slow endpoints stand out in the collector.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if traceparent := c.GetHeader("traceparent"); traceparent != "" {
+			ctx = tracing.ContextFromTraceparent(ctx, traceparent)
+		}
+
+		ctx, span := tracing.StartSpan(ctx, "HTTP "+c.Request.Method+" "+c.FullPath(), tracing.KindServer)
+		span.SetAttribute("http.method", c.Request.Method)
+		span.SetAttribute("http.route", c.FullPath())
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		span.SetAttribute("http.status_code", fmt.Sprintf("%d", c.Writer.Status()))
+		if c.Writer.Status() >= 500 {
+			span.RecordError(fmt.Errorf("HTTP %d", c.Writer.Status()))
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceHeader names the header clients use to select a workspace for
+// requests that do not take one explicitly
+const WorkspaceHeader = "X-Xgent-Workspace"
+
+// WorkspaceSelector reads the workspace selection from the X-Xgent-Workspace
+// header or the workspace query parameter and stores it in the request
+// context, so every handler resolves the active workspace the same way. A
+// malformed value is rejected rather than silently ignored.
+func WorkspaceSelector() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := c.GetHeader(WorkspaceHeader)
+		if value == "" {
+			value = c.Query("workspace")
+		}
+		if value == "" {
+			c.Next()
+			return
+		}
+
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace selection"})
+			c.Abort()
+			return
+		}
+
+		c.Set("selected_workspace", uint(id))
+		c.Next()
+	}
+}
+
+// GetWorkspaceID extracts the selected workspace from context; ok is false
+// when the request named none
+func GetWorkspaceID(c *gin.Context) (uint, bool) {
+	value, exists := c.Get("selected_workspace")
+	if !exists {
+		return 0, false
+	}
+	id, ok := value.(uint)
+	return id, ok
+}
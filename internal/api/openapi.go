@@ -0,0 +1,293 @@
+package api
+
+import "strings"
+
+// Minimal OpenAPI 3 document types — just enough to describe this API
+// without pulling in a spec-generation dependency. The document is built
+// from the route table below; keep the table in sync with setupRoutes.
+
+// OpenAPI is the root of an OpenAPI 3 document
+type OpenAPI struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       OpenAPIInfo            `json:"info"`
+	Servers    []OpenAPIServer        `json:"servers"`
+	Tags       []OpenAPITag           `json:"tags"`
+	Paths      map[string]OpenAPIPath `json:"paths"`
+	Components OpenAPIComponents      `json:"components"`
+}
+
+// OpenAPIInfo describes the API
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// OpenAPIServer is a base URL the API is served from
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+// OpenAPITag groups operations in documentation UIs
+type OpenAPITag struct {
+	Name string `json:"name"`
+}
+
+// OpenAPIPath maps lowercase HTTP methods to operations
+type OpenAPIPath map[string]OpenAPIOperation
+
+// OpenAPIOperation describes one endpoint
+type OpenAPIOperation struct {
+	Tags       []string              `json:"tags,omitempty"`
+	Summary    string                `json:"summary"`
+	Parameters []OpenAPIParameter    `json:"parameters,omitempty"`
+	Responses  map[string]OpenAPIRef `json:"responses"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+// OpenAPIParameter describes a path parameter
+type OpenAPIParameter struct {
+	Name     string            `json:"name"`
+	In       string            `json:"in"`
+	Required bool              `json:"required"`
+	Schema   map[string]string `json:"schema"`
+}
+
+// OpenAPIRef is a response description
+type OpenAPIRef struct {
+	Description string `json:"description"`
+}
+
+// OpenAPIComponents holds the security scheme definitions
+type OpenAPIComponents struct {
+	SecuritySchemes map[string]OpenAPISecurityScheme `json:"securitySchemes"`
+}
+
+// OpenAPISecurityScheme describes how callers authenticate
+type OpenAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// apiRoute is one row of the route table the spec is generated from.
+// Paths use gin syntax (:param); public routes skip the bearer security
+// requirement.
+type apiRoute struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+	public  bool
+}
+
+var apiRoutes = []apiRoute{
+	{"GET", "/", "meta", "API name and entry points", true},
+	{"GET", "/health", "meta", "Health check", true},
+	{"GET", "/metrics", "meta", "Prometheus metrics", true},
+	{"GET", "/openapi.json", "meta", "This OpenAPI document", true},
+
+	{"POST", "/api/v1/auth/register", "auth", "Register a new user", true},
+	{"POST", "/api/v1/auth/login", "auth", "Log in and receive a JWT", true},
+	{"POST", "/api/v1/auth/refresh", "auth", "Refresh an access token", true},
+	{"GET", "/api/v1/auth/github", "auth", "Start GitHub OAuth login", true},
+	{"GET", "/api/v1/auth/github/callback", "auth", "GitHub OAuth callback", true},
+	{"POST", "/api/v1/auth/tokens", "auth", "Mint a scoped API token", false},
+	{"POST", "/api/v1/auth/logout", "auth", "Revoke the current session", false},
+	{"GET", "/api/v1/auth/sessions", "auth", "List active login sessions", false},
+	{"DELETE", "/api/v1/auth/sessions/:id", "auth", "Revoke a login session", false},
+
+	{"POST", "/api/v1/hooks/github", "hooks", "GitHub webhook receiver", true},
+
+	{"GET", "/api/v1/workspaces", "workspaces", "List accessible workspaces", false},
+	{"POST", "/api/v1/workspaces", "workspaces", "Create a workspace", false},
+	{"GET", "/api/v1/workspaces/:id", "workspaces", "Get a workspace", false},
+	{"PUT", "/api/v1/workspaces/:id", "workspaces", "Update a workspace", false},
+	{"DELETE", "/api/v1/workspaces/:id", "workspaces", "Delete a workspace", false},
+	{"PUT", "/api/v1/workspaces/:id/default", "workspaces", "Set the user's default workspace", false},
+	{"GET", "/api/v1/workspaces/:id/settings", "workspaces", "Workspace execution defaults", false},
+	{"PUT", "/api/v1/workspaces/:id/settings", "workspaces", "Update workspace execution defaults", false},
+	{"GET", "/api/v1/workspaces/:id/usage", "workspaces", "Workspace usage summary", false},
+	{"GET", "/api/v1/workspaces/:id/budget", "workspaces", "Workspace token budget and usage", false},
+	{"GET", "/api/v1/workspaces/:id/storage", "workspaces", "Workspace storage usage and quota", false},
+	{"GET", "/api/v1/workspaces/:id/stream", "workspaces", "WebSocket stream of all workspace task events", false},
+	{"GET", "/api/v1/workspaces/:id/export", "workspaces", "Export all resources as a YAML bundle", false},
+	{"POST", "/api/v1/workspaces/:id/import", "workspaces", "Import a YAML resource bundle", false},
+	{"GET", "/api/v1/workspaces/:id/members", "workspaces", "List workspace members", false},
+	{"POST", "/api/v1/workspaces/:id/members", "workspaces", "Invite a workspace member", false},
+	{"PUT", "/api/v1/workspaces/:id/members/:user_id", "workspaces", "Change a member's role", false},
+	{"DELETE", "/api/v1/workspaces/:id/members/:user_id", "workspaces", "Remove a workspace member", false},
+	{"GET", "/api/v1/workspaces/:id/webhooks", "webhooks", "List workspace webhooks", false},
+	{"POST", "/api/v1/workspaces/:id/webhooks", "webhooks", "Create a workspace webhook", false},
+	{"PUT", "/api/v1/workspaces/:id/webhooks/:webhook_id", "webhooks", "Update a webhook", false},
+	{"DELETE", "/api/v1/workspaces/:id/webhooks/:webhook_id", "webhooks", "Delete a webhook", false},
+
+	{"GET", "/api/v1/resources", "resources", "List resources", false},
+	{"GET", "/api/v1/resources/watch", "resources", "Long-poll for resource changes", false},
+	{"POST", "/api/v1/resources", "resources", "Create a resource", false},
+	{"GET", "/api/v1/resources/:id", "resources", "Get a resource", false},
+	{"PUT", "/api/v1/resources/:id", "resources", "Update a resource", false},
+	{"DELETE", "/api/v1/resources/:id", "resources", "Delete a resource", false},
+	{"POST", "/api/v1/resources/apply", "resources", "Apply YAML resource definitions", false},
+	{"POST", "/api/v1/resources/bulk", "resources", "Bulk delete resources", false},
+	{"GET", "/api/v1/resources/:id/canary", "resources", "Canary rollout status", false},
+	{"POST", "/api/v1/resources/:id/canary/promote", "resources", "Promote a canary revision", false},
+	{"POST", "/api/v1/resources/:id/canary/rollback", "resources", "Roll back a canary revision", false},
+
+	{"POST", "/api/v1/tasks", "tasks", "Create and submit a task", false},
+	{"POST", "/api/v1/tasks/bulk", "tasks", "Bulk delete, cancel or retry tasks", false},
+	{"POST", "/api/v1/tasks/from-template/:id", "tasks", "Create a task from a template", false},
+	{"GET", "/api/v1/tasks", "tasks", "List tasks", false},
+	{"GET", "/api/v1/tasks/export", "tasks", "Export tasks as CSV/JSON", false},
+	{"GET", "/api/v1/tasks/:id", "tasks", "Get a task", false},
+	{"DELETE", "/api/v1/tasks/:id", "tasks", "Delete a task", false},
+	{"POST", "/api/v1/tasks/:id/cancel", "tasks", "Cancel a running task", false},
+	{"POST", "/api/v1/tasks/:id/replay", "tasks", "Replay a task against pinned resource specs", false},
+	{"GET", "/api/v1/tasks/:id/logs", "tasks", "Task logs and paginated events", false},
+	{"GET", "/api/v1/tasks/:id/stream", "tasks", "WebSocket stream of task events", false},
+	{"POST", "/api/v1/tasks/:id/embed-token", "tasks", "Mint a read-only embed token", false},
+	{"GET", "/api/v1/tasks/:id/artifacts", "tasks", "List task artifacts", false},
+	{"GET", "/api/v1/tasks/:id/artifacts/:artifact_id/download", "tasks", "Download a task artifact", false},
+	{"GET", "/api/v1/tasks/:id/subtasks", "tasks", "List a task's subtasks", false},
+	{"GET", "/api/v1/tasks/:id/plan", "tasks", "Get a task's execution plan", false},
+
+	{"GET", "/api/v1/task-templates", "task-templates", "List task templates", false},
+	{"POST", "/api/v1/task-templates", "task-templates", "Create a task template", false},
+	{"GET", "/api/v1/task-templates/:id", "task-templates", "Get a task template", false},
+	{"PUT", "/api/v1/task-templates/:id", "task-templates", "Update a task template", false},
+	{"DELETE", "/api/v1/task-templates/:id", "task-templates", "Delete a task template", false},
+
+	{"GET", "/api/v1/embed/tasks/:id/stream", "tasks", "Embed token authenticated task stream", true},
+
+	{"GET", "/api/v1/subtasks/:id", "subtasks", "Get a subtask", false},
+	{"PATCH", "/api/v1/subtasks/:id/status", "subtasks", "Update a subtask's status (resolves approvals)", false},
+	{"GET", "/api/v1/subtasks/:id/logs", "subtasks", "Get a subtask's logs", false},
+
+	{"GET", "/api/v1/runners", "runners", "List connected runners", false},
+	{"GET", "/api/v1/runners/connect", "runners", "Runner WebSocket registration", false},
+
+	{"GET", "/api/v1/bots", "bots", "List robots", false},
+	{"GET", "/api/v1/bots/:name", "bots", "Get a robot", false},
+	{"POST", "/api/v1/bots/:name/chat", "bots", "Chat with a robot", false},
+	{"POST", "/api/v1/bots/:name/chat/stream", "bots", "Streaming chat with a robot", false},
+	{"POST", "/api/v1/minds/:name/complete", "bots", "Raw completion against a Mind", false},
+
+	{"GET", "/api/v1/teams", "teams", "List teams", false},
+	{"GET", "/api/v1/teams/:name", "teams", "Get a team", false},
+
+	{"GET", "/api/v1/sessions", "sessions", "List chat sessions", false},
+	{"GET", "/api/v1/sessions/:id", "sessions", "Get a session", false},
+	{"DELETE", "/api/v1/sessions/:id", "sessions", "Delete a session", false},
+	{"GET", "/api/v1/sessions/:id/messages", "sessions", "Get a session's messages", false},
+	{"GET", "/api/v1/sessions/:id/summary", "sessions", "Session running summary", false},
+	{"DELETE", "/api/v1/sessions/:id/summary", "sessions", "Reset session summary", false},
+
+	{"GET", "/api/v1/audit", "admin", "Query the audit trail (admin)", false},
+	{"GET", "/api/v1/admin/users", "admin", "List all users (admin)", false},
+	{"GET", "/api/v1/admin/tasks", "admin", "List tasks across users (admin)", false},
+	{"POST", "/api/v1/admin/tasks/:id/cancel", "admin", "Force-cancel any task (admin)", false},
+	{"PUT", "/api/v1/admin/workspaces/:id/quotas", "admin", "Set workspace resource quotas (admin)", false},
+	{"PUT", "/api/v1/admin/workspaces/:id/retention", "admin", "Set workspace log retention (admin)", false},
+	{"PUT", "/api/v1/admin/workspaces/:id/budget", "admin", "Set workspace token budget (admin)", false},
+	{"PUT", "/api/v1/admin/workspaces/:id/storage-quota", "admin", "Set workspace storage quota (admin)", false},
+	{"GET", "/api/v1/admin/log-archival", "admin", "Log archival status and archives (admin)", false},
+	{"POST", "/api/v1/admin/tasks/{id}/requeue", "admin", "Requeue a dead-lettered or failed task (admin)", false},
+	{"GET", "/api/v1/admin/workers", "admin", "Registered worker processes and liveness (admin)", false},
+	{"POST", "/api/v1/admin/log-archival/run", "admin", "Trigger a log archival pass (admin)", false},
+
+	{"POST", "/api/v1/attachments/upload", "attachments", "Upload an attachment", false},
+	{"GET", "/api/v1/attachments", "attachments", "List attachments", false},
+	{"GET", "/api/v1/attachments/:id", "attachments", "Get attachment metadata", false},
+	{"GET", "/api/v1/attachments/:id/download", "attachments", "Download an attachment", false},
+	{"GET", "/api/v1/attachments/:id/content", "attachments", "Extracted attachment text", false},
+	{"DELETE", "/api/v1/attachments/:id", "attachments", "Delete an attachment", false},
+	{"POST", "/api/v1/attachments/:id/attach", "attachments", "Attach an upload to a task", false},
+	{"GET", "/api/v1/tasks/:id/attachments", "attachments", "List a task's attachments", false},
+	{"GET", "/api/v1/subtasks/:id/attachments", "attachments", "List a subtask's attachments", false},
+
+	{"POST", "/v1/chat/completions", "compat", "OpenAI-compatible chat completions proxy", false},
+}
+
+// BuildOpenAPISpec assembles the OpenAPI 3 document from the route table
+func BuildOpenAPISpec() *OpenAPI {
+	spec := &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info: OpenAPIInfo{
+			Title:       "Xgent-Go API",
+			Description: "Agent orchestration platform: declarative CRD resources, task execution, and real-time event streams.",
+			Version:     "1.0.0",
+		},
+		Servers: []OpenAPIServer{{URL: "/"}},
+		Paths:   make(map[string]OpenAPIPath),
+		Components: OpenAPIComponents{
+			SecuritySchemes: map[string]OpenAPISecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	seenTags := make(map[string]bool)
+	for _, route := range apiRoutes {
+		if !seenTags[route.tag] {
+			seenTags[route.tag] = true
+			spec.Tags = append(spec.Tags, OpenAPITag{Name: route.tag})
+		}
+
+		path, params := openAPIPath(route.path)
+		operation := OpenAPIOperation{
+			Tags:       []string{route.tag},
+			Summary:    route.summary,
+			Parameters: params,
+			Responses:  map[string]OpenAPIRef{"200": {Description: "Success"}},
+		}
+		if !route.public {
+			operation.Security = []map[string][]string{{"bearerAuth": {}}}
+			operation.Responses["401"] = OpenAPIRef{Description: "Unauthorized"}
+		}
+
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = make(OpenAPIPath)
+		}
+		spec.Paths[path][strings.ToLower(route.method)] = operation
+	}
+
+	return spec
+}
+
+// openAPIPath converts a gin route path to OpenAPI syntax and derives its
+// path parameters (":id" becomes "{id}")
+func openAPIPath(path string) (string, []OpenAPIParameter) {
+	var params []OpenAPIParameter
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := segment[1:]
+			segments[i] = "{" + name + "}"
+			params = append(params, OpenAPIParameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   map[string]string{"type": "string"},
+			})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// swaggerUIPage renders Swagger UI from a CDN against /openapi.json
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8"/>
+  <title>Xgent-Go API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css"/>
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
@@ -4,15 +4,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/xcode-ai/xgent-go/internal/api/handlers"
 	"github.com/xcode-ai/xgent-go/internal/api/middleware"
+	"github.com/xcode-ai/xgent-go/internal/llm"
 	"github.com/xcode-ai/xgent-go/internal/orchestrator"
 	"github.com/xcode-ai/xgent-go/internal/services/attachment"
 	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"go.uber.org/zap"
 )
 
@@ -33,6 +36,21 @@ type Config struct {
 	Mode         string // debug, release
 	JWTSecret    string
 	AllowOrigins []string
+
+	// AccessTokenTTL is the JWT lifetime; zero uses the default of 24h
+	AccessTokenTTL time.Duration
+
+	// GitHub OAuth application credentials; empty disables OAuth login
+	GitHubClientID     string
+	GitHubClientSecret string
+	GitHubRedirectURL  string
+
+	// Attachment storage backend
+	Attachment attachment.Config
+
+	// Embeddings provider for semantic attachment indexing; an empty
+	// provider disables it
+	Embeddings llm.Config
 }
 
 // NewServer creates a new API server
@@ -51,6 +69,9 @@ func NewServer(cfg *Config, storage *storage.Storage, orch *orchestrator.Orchest
 		config:       cfg,
 	}
 
+	// WebSocket upgrades honor the same origin allow list as CORS
+	handlers.ConfigureWebSocketOrigins(cfg.AllowOrigins)
+
 	server.setupMiddleware()
 	server.setupRoutes()
 
@@ -59,8 +80,14 @@ func NewServer(cfg *Config, storage *storage.Storage, orch *orchestrator.Orchest
 
 // setupMiddleware configures global middleware
 func (s *Server) setupMiddleware() {
-	// Recovery middleware
-	s.router.Use(gin.Recovery())
+	// Recovery middleware; panics turn into errors carrying the request ID
+	// so users can quote it to support
+	s.router.Use(gin.CustomRecovery(func(c *gin.Context, _ interface{}) {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error":      "Internal server error",
+			"request_id": middleware.GetRequestID(c),
+		})
+	}))
 
 	// Logger middleware
 	s.router.Use(middleware.Logger(s.logger))
@@ -76,8 +103,14 @@ func (s *Server) setupMiddleware() {
 	}
 	s.router.Use(cors.New(corsConfig))
 
+	// Security headers, hardened further in release mode
+	s.router.Use(middleware.SecurityHeaders(s.config.Mode))
+
 	// Request ID middleware
 	s.router.Use(middleware.RequestID())
+
+	// One server span per request; a no-op unless tracing is initialized
+	s.router.Use(middleware.Tracing())
 }
 
 // setupRoutes configures all API routes
@@ -97,21 +130,62 @@ func (s *Server) setupRoutes() {
 		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
 
+	// Prometheus metrics; expose on internal networks only
+	s.router.GET("/metrics", func(c *gin.Context) {
+		var b strings.Builder
+		storage.QueryMetrics().Render(&b)
+		c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+	})
+
+	// Unknown routes answer with the request ID too
+	s.router.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":      "Not found",
+			"request_id": middleware.GetRequestID(c),
+		})
+	})
+
 	// API v1 routes
 	v1 := s.router.Group("/api/v1")
 	{
 		// Public routes
+		authHandler := handlers.NewAuthHandler(s.storage, s.config.JWTSecret, s.logger)
 		auth := v1.Group("/auth")
 		{
-			authHandler := handlers.NewAuthHandler(s.storage, s.config.JWTSecret, s.logger)
+			authHandler.ConfigureGitHub(handlers.GitHubOAuthConfig{
+				ClientID:     s.config.GitHubClientID,
+				ClientSecret: s.config.GitHubClientSecret,
+				RedirectURL:  s.config.GitHubRedirectURL,
+			})
+			if s.config.AccessTokenTTL > 0 {
+				authHandler.SetAccessTokenTTL(s.config.AccessTokenTTL)
+			}
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.GET("/github", authHandler.GitHubLogin)
+			auth.GET("/github/callback", authHandler.GitHubCallback)
+		}
+
+		// Webhook routes authenticate via HMAC signatures, not JWTs
+		hooks := v1.Group("/hooks")
+		{
+			githubHook := handlers.NewGitHubHookHandler(s.storage, s.orchestrator, s.logger)
+			hooks.POST("/github", githubHook.Handle)
 		}
 
 		// Protected routes
 		protected := v1.Group("")
-		protected.Use(middleware.Auth(s.config.JWTSecret))
+		protected.Use(middleware.Auth(s.config.JWTSecret, s.storage.LoginSessions()))
+		protected.Use(middleware.Tenant(s.storage.Workspaces()))
+		protected.Use(middleware.WorkspaceSelector())
 		{
+			// Scoped integration tokens and login sessions
+			protected.POST("/auth/tokens", authHandler.MintToken)
+			protected.POST("/auth/logout", authHandler.Logout)
+			protected.GET("/auth/sessions", authHandler.ListSessions)
+			protected.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
+
 			// Workspaces
 			workspaceHandler := handlers.NewWorkspaceHandler(s.storage, s.logger)
 			workspaces := protected.Group("/workspaces")
@@ -121,36 +195,93 @@ func (s *Server) setupRoutes() {
 				workspaces.GET("/:id", workspaceHandler.Get)
 				workspaces.PUT("/:id", workspaceHandler.Update)
 				workspaces.DELETE("/:id", workspaceHandler.Delete)
+				workspaces.PUT("/:id/default", workspaceHandler.SetDefault)
+				workspaces.GET("/:id/settings", workspaceHandler.GetSettings)
+				workspaces.PUT("/:id/settings", workspaceHandler.UpdateSettings)
+				workspaces.GET("/:id/usage", workspaceHandler.Usage)
+				workspaces.GET("/:id/budget", workspaceHandler.Budget)
+				workspaces.GET("/:id/storage", workspaceHandler.StorageUsage)
+				workspaces.GET("/:id/stream", workspaceHandler.Stream)
+				workspaces.GET("/:id/export", workspaceHandler.Export)
+				workspaces.POST("/:id/import", workspaceHandler.Import)
+				workspaces.GET("/:id/members", workspaceHandler.ListMembers)
+				workspaces.POST("/:id/members", workspaceHandler.AddMember)
+				workspaces.PUT("/:id/members/:user_id", workspaceHandler.UpdateMember)
+				workspaces.DELETE("/:id/members/:user_id", workspaceHandler.RemoveMember)
+				workspaces.GET("/:id/webhooks", workspaceHandler.ListWebhooks)
+				workspaces.POST("/:id/webhooks", workspaceHandler.CreateWebhook)
+				workspaces.PUT("/:id/webhooks/:webhook_id", workspaceHandler.UpdateWebhook)
+				workspaces.DELETE("/:id/webhooks/:webhook_id", workspaceHandler.DeleteWebhook)
 			}
 
 			// Resources (CRD)
 			resourceHandler := handlers.NewResourceHandler(s.storage, s.logger)
 			resources := protected.Group("/resources")
 			{
-				resources.GET("", resourceHandler.List)
-				resources.POST("", resourceHandler.Create)
-				resources.GET("/:id", resourceHandler.Get)
-				resources.PUT("/:id", resourceHandler.Update)
-				resources.DELETE("/:id", resourceHandler.Delete)
-				resources.POST("/apply", resourceHandler.Apply) // Apply YAML
+				readResources := middleware.RequireScope(middleware.ScopeResourcesRead)
+				writeResources := middleware.RequireScope(middleware.ScopeResourcesWrite)
+				resources.GET("", readResources, resourceHandler.List)
+				resources.GET("/watch", readResources, resourceHandler.Watch)
+				resources.POST("", writeResources, resourceHandler.Create)
+				resources.GET("/:id", readResources, resourceHandler.Get)
+				resources.PUT("/:id", writeResources, resourceHandler.Update)
+				resources.DELETE("/:id", writeResources, resourceHandler.Delete)
+				resources.POST("/apply", writeResources, resourceHandler.Apply) // Apply YAML
+				resources.POST("/bulk", writeResources, resourceHandler.Bulk)
+				resources.GET("/:id/canary", readResources, resourceHandler.CanaryStatus)
+				resources.POST("/:id/canary/promote", writeResources, resourceHandler.PromoteCanary)
+				resources.POST("/:id/canary/rollback", writeResources, resourceHandler.RollbackCanary)
 			}
 
 			// Tasks
 			taskHandler := handlers.NewTaskHandler(s.storage, s.orchestrator, s.logger)
+			embedHandler := handlers.NewEmbedHandler(s.storage, s.config.JWTSecret, s.logger)
+			artifactBlobs, err := attachment.NewBlobStore(s.config.Attachment)
+			if err != nil {
+				s.logger.Fatal("failed to initialize artifact storage", zap.Error(err))
+			}
+			artifactHandler := handlers.NewArtifactHandler(s.storage, artifactBlobs, s.logger)
 			tasks := protected.Group("/tasks")
 			{
-				tasks.POST("", taskHandler.Create)
-				tasks.GET("", taskHandler.List)
-				tasks.GET("/:id", taskHandler.Get)
-				tasks.DELETE("/:id", taskHandler.Delete)
-				tasks.POST("/:id/cancel", taskHandler.Cancel)
-				tasks.GET("/:id/logs", taskHandler.GetLogs)
-				tasks.GET("/:id/stream", taskHandler.Stream)
+				readTasks := middleware.RequireScope(middleware.ScopeTasksRead)
+				writeTasks := middleware.RequireScope(middleware.ScopeTasksWrite)
+				tasks.POST("", writeTasks, taskHandler.Create)
+				tasks.POST("/bulk", writeTasks, taskHandler.Bulk)
+				tasks.POST("/from-template/:id", writeTasks, taskHandler.CreateFromTemplate)
+				tasks.GET("", readTasks, taskHandler.List)
+				tasks.GET("/export", readTasks, taskHandler.Export)
+				tasks.GET("/:id", readTasks, taskHandler.Get)
+				tasks.DELETE("/:id", writeTasks, taskHandler.Delete)
+				tasks.POST("/:id/cancel", writeTasks, taskHandler.Cancel)
+				tasks.POST("/:id/replay", writeTasks, taskHandler.Replay)
+				tasks.GET("/:id/logs", readTasks, taskHandler.GetLogs)
+				tasks.GET("/:id/stream", readTasks, taskHandler.Stream)
+				tasks.POST("/:id/embed-token", readTasks, embedHandler.MintToken)
+				tasks.GET("/:id/artifacts", readTasks, artifactHandler.List)
+				tasks.GET("/:id/artifacts/:artifact_id/download", readTasks, artifactHandler.Download)
 			}
 
+			// Task templates
+			templateHandler := handlers.NewTaskTemplateHandler(s.storage, s.logger)
+			templates := protected.Group("/task-templates")
+			{
+				readTemplates := middleware.RequireScope(middleware.ScopeTasksRead)
+				writeTemplates := middleware.RequireScope(middleware.ScopeTasksWrite)
+				templates.GET("", readTemplates, templateHandler.List)
+				templates.POST("", writeTemplates, templateHandler.Create)
+				templates.GET("/:id", readTemplates, templateHandler.Get)
+				templates.PUT("/:id", writeTemplates, templateHandler.Update)
+				templates.DELETE("/:id", writeTemplates, templateHandler.Delete)
+			}
+
+			// Public embed streaming: authenticated by the embed token
+			// itself, restricted to the origins baked into it
+			v1.GET("/embed/tasks/:id/stream", embedHandler.Auth(), taskHandler.Stream)
+
 			// Subtasks
 			subtaskHandler := handlers.NewSubtaskHandler(s.storage, s.logger)
 			tasks.GET("/:id/subtasks", subtaskHandler.ListByTask)
+			tasks.GET("/:id/plan", subtaskHandler.Plan)
 			subtasks := protected.Group("/subtasks")
 			{
 				subtasks.GET("/:id", subtaskHandler.Get)
@@ -158,14 +289,28 @@ func (s *Server) setupRoutes() {
 				subtasks.GET("/:id/logs", subtaskHandler.GetLogs)
 			}
 
+			// Runners
+			runnerHandler := handlers.NewRunnerHandler(s.logger)
+			runners := protected.Group("/runners")
+			{
+				runners.GET("", runnerHandler.List)
+				runners.GET("/connect", runnerHandler.Connect)
+			}
+
 			// Bots
 			botHandler := handlers.NewBotHandler(s.storage, s.logger)
 			bots := protected.Group("/bots")
 			{
 				bots.GET("", botHandler.List)
 				bots.GET("/:name", botHandler.Get)
+				bots.POST("/:name/chat", botHandler.Chat)
+				bots.POST("/:name/chat/stream", botHandler.ChatStream)
 			}
 
+			// Minds (direct model access, used by the CLI benchmark)
+			mindHandler := handlers.NewMindHandler(s.storage, s.logger)
+			protected.POST("/minds/:name/complete", mindHandler.Complete)
+
 			// Teams
 			teamHandler := handlers.NewTeamHandler(s.storage, s.logger)
 			teams := protected.Group("/teams")
@@ -182,10 +327,40 @@ func (s *Server) setupRoutes() {
 				sessions.GET("/:id", sessionHandler.Get)
 				sessions.DELETE("/:id", sessionHandler.Delete)
 				sessions.GET("/:id/messages", sessionHandler.GetMessages)
+				sessions.GET("/:id/summary", sessionHandler.GetSummary)
+				sessions.DELETE("/:id/summary", sessionHandler.ResetSummary)
+			}
+
+			// Audit trail, admin-only
+			auditHandler := handlers.NewAuditHandler(s.storage, s.logger)
+			protected.GET("/audit",
+				middleware.RequireRole(s.storage.Users(), models.UserRoleAdmin),
+				auditHandler.List)
+
+			// Admin-only endpoints with cross-user visibility
+			adminHandler := handlers.NewAdminHandler(s.storage, s.orchestrator, s.logger)
+			admin := protected.Group("/admin")
+			admin.Use(middleware.RequireRole(s.storage.Users(), models.UserRoleAdmin))
+			{
+				admin.GET("/users", adminHandler.ListUsers)
+				admin.GET("/tasks", adminHandler.ListTasks)
+				admin.POST("/tasks/:id/cancel", adminHandler.CancelTask)
+				admin.POST("/tasks/:id/requeue", adminHandler.RequeueTask)
+				admin.PUT("/workspaces/:id/quotas", adminHandler.SetWorkspaceQuotas)
+				admin.PUT("/workspaces/:id/retention", adminHandler.SetWorkspaceRetention)
+				admin.PUT("/workspaces/:id/budget", adminHandler.SetWorkspaceBudget)
+				admin.PUT("/workspaces/:id/storage-quota", adminHandler.SetWorkspaceStorageQuota)
+				admin.GET("/log-archival", adminHandler.GetLogArchival)
+				admin.GET("/workers", adminHandler.ListWorkers)
+				admin.POST("/log-archival/run", adminHandler.RunLogArchival)
 			}
 
 			// Attachments
-			attachmentService := attachment.NewService(s.storage, "/tmp/xgent-uploads", s.logger)
+			attachmentService, err := attachment.NewService(s.storage, s.config.Attachment, s.logger)
+			if err != nil {
+				s.logger.Fatal("failed to initialize attachment storage", zap.Error(err))
+			}
+			attachmentService.ConfigureIndexing(s.config.Embeddings)
 			attachmentHandler := handlers.NewAttachmentHandler(s.storage, attachmentService, s.logger)
 			attachments := protected.Group("/attachments")
 			{
@@ -197,11 +372,28 @@ func (s *Server) setupRoutes() {
 				attachments.DELETE("/:id", attachmentHandler.Delete)
 				attachments.POST("/:id/attach", attachmentHandler.AttachToTask)
 			}
+			protected.GET("/tasks/:id/attachments", attachmentHandler.ListByTask)
+			protected.GET("/subtasks/:id/attachments", attachmentHandler.ListBySubtask)
 		}
 	}
 
-	// Swagger documentation (optional)
-	// s.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// OpenAI-compatible proxy: the model name selects a Robot, so stock
+	// OpenAI client libraries can talk to robots with just a base URL and
+	// an Xgent token
+	compat := s.router.Group("/v1")
+	compat.Use(middleware.Auth(s.config.JWTSecret, s.storage.LoginSessions()))
+	compat.Use(middleware.Tenant(s.storage.Workspaces()))
+	compat.Use(middleware.WorkspaceSelector())
+	compat.POST("/chat/completions", handlers.NewOpenAICompatHandler(s.storage, s.logger).ChatCompletions)
+
+	// Machine-readable API description and interactive documentation.
+	// The same document is written to docs/openapi.json by cmd/openapi.
+	s.router.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, BuildOpenAPISpec())
+	})
+	s.router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+	})
 }
 
 // Start starts the HTTP server
@@ -225,10 +417,19 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// Stop gracefully stops the HTTP server
+// Stop gracefully stops the HTTP server. Stream WebSocket clients get a
+// closing event with a reconnect hint and a short drain period first.
 func (s *Server) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping API server")
 
+	drainTimeout := 5 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) / 2; remaining < drainTimeout {
+			drainTimeout = remaining
+		}
+	}
+	handlers.DrainWebSockets(drainTimeout, s.logger)
+
 	if s.httpServer != nil {
 		return s.httpServer.Shutdown(ctx)
 	}
@@ -44,57 +44,100 @@ func (p *Parser) Parse(data []byte) (Resource, error) {
 		return nil, fmt.Errorf("unsupported API version: %s", meta.APIVersion)
 	}
 
-	// Parse based on kind
+	// Parse based on kind. Strict decoding rejects unknown fields, with
+	// the offending line in the error.
 	var resource Resource
 	switch meta.Kind {
 	case KindSoul:
 		var soul Soul
-		if err := yaml.Unmarshal(data, &soul); err != nil {
+		if err := decodeStrict(data, &soul); err != nil {
 			return nil, fmt.Errorf("failed to parse Soul: %w", err)
 		}
 		resource = &soul
 	case KindMind:
 		var mind Mind
-		if err := yaml.Unmarshal(data, &mind); err != nil {
+		if err := decodeStrict(data, &mind); err != nil {
 			return nil, fmt.Errorf("failed to parse Mind: %w", err)
 		}
 		resource = &mind
 	case KindCraft:
 		var craft Craft
-		if err := yaml.Unmarshal(data, &craft); err != nil {
+		if err := decodeStrict(data, &craft); err != nil {
 			return nil, fmt.Errorf("failed to parse Craft: %w", err)
 		}
 		resource = &craft
 	case KindRobot:
 		var robot Robot
-		if err := yaml.Unmarshal(data, &robot); err != nil {
+		if err := decodeStrict(data, &robot); err != nil {
 			return nil, fmt.Errorf("failed to parse Robot: %w", err)
 		}
 		resource = &robot
 	case KindTeam:
 		var team Team
-		if err := yaml.Unmarshal(data, &team); err != nil {
+		if err := decodeStrict(data, &team); err != nil {
 			return nil, fmt.Errorf("failed to parse Team: %w", err)
 		}
 		resource = &team
 	case KindCollaboration:
 		var collab Collaboration
-		if err := yaml.Unmarshal(data, &collab); err != nil {
+		if err := decodeStrict(data, &collab); err != nil {
 			return nil, fmt.Errorf("failed to parse Collaboration: %w", err)
 		}
 		resource = &collab
+	case KindWorkflow:
+		var workflow Workflow
+		if err := decodeStrict(data, &workflow); err != nil {
+			return nil, fmt.Errorf("failed to parse Workflow: %w", err)
+		}
+		resource = &workflow
+	case KindEnvironment:
+		var env Environment
+		if err := decodeStrict(data, &env); err != nil {
+			return nil, fmt.Errorf("failed to parse Environment: %w", err)
+		}
+		resource = &env
+	case KindKnowledge:
+		var knowledge Knowledge
+		if err := decodeStrict(data, &knowledge); err != nil {
+			return nil, fmt.Errorf("failed to parse Knowledge: %w", err)
+		}
+		resource = &knowledge
+	case KindSecret:
+		var secret Secret
+		if err := decodeStrict(data, &secret); err != nil {
+			return nil, fmt.Errorf("failed to parse Secret: %w", err)
+		}
+		resource = &secret
+	case KindTrigger:
+		var trigger Trigger
+		if err := decodeStrict(data, &trigger); err != nil {
+			return nil, fmt.Errorf("failed to parse Trigger: %w", err)
+		}
+		resource = &trigger
 	default:
 		return nil, fmt.Errorf("unknown resource kind: %s", meta.Kind)
 	}
 
-	// Validate the resource
-	if err := resource.Validate(); err != nil {
+	// Validate the resource, resolving each violation back to its YAML
+	// path and line number
+	if err := validateResource(resource, newSpecLocator(data)); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
 	return resource, nil
 }
 
+// decodeStrict unmarshals with unknown-field detection enabled, so typos
+// like "personalty" fail the parse instead of silently dropping the value
+func decodeStrict(data []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
 // ParseMultiple parses multiple resources from a YAML file with --- separators
 func (p *Parser) ParseMultiple(data []byte) ([]Resource, error) {
 	// Split by --- separator
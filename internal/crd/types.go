@@ -1,6 +1,9 @@
 package crd
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // APIVersion and Kind constants
 const (
@@ -17,6 +20,11 @@ const (
 	KindRobot         ResourceKind = "Robot"
 	KindTeam          ResourceKind = "Team"
 	KindCollaboration ResourceKind = "Collaboration"
+	KindWorkflow      ResourceKind = "Workflow"
+	KindEnvironment   ResourceKind = "Environment"
+	KindKnowledge     ResourceKind = "Knowledge"
+	KindSecret        ResourceKind = "Secret"
+	KindTrigger       ResourceKind = "Trigger"
 )
 
 // Resource is the base interface for all CRD resources
@@ -75,10 +83,36 @@ type MindSpec struct {
 	Provider    string            `yaml:"provider" json:"provider"` // openai, anthropic, custom
 	ModelID     string            `yaml:"model_id" json:"model_id"`
 	APIKey      string            `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	APIKeyFrom  *SecretKeyRef     `yaml:"api_key_from,omitempty" json:"api_key_from,omitempty"`
 	BaseURL     string            `yaml:"base_url,omitempty" json:"base_url,omitempty"`
+	Proxy       string            `yaml:"proxy,omitempty" json:"proxy,omitempty"` // HTTP proxy for calls to this model
 	Temperature float32           `yaml:"temperature,omitempty" json:"temperature,omitempty"`
 	MaxTokens   int               `yaml:"max_tokens,omitempty" json:"max_tokens,omitempty"`
 	Headers     map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// Azure OpenAI: deployment name (defaults to model_id) and api-version
+	// query parameter
+	Deployment string `yaml:"deployment,omitempty" json:"deployment,omitempty"`
+	APIVersion string `yaml:"api_version,omitempty" json:"api_version,omitempty"`
+
+	// Fallbacks are tried in declaration order when the primary provider
+	// keeps failing
+	Fallbacks []MindFallback `yaml:"fallbacks,omitempty" json:"fallbacks,omitempty"`
+
+	// Cache serves repeated identical prompts from a response cache;
+	// cache_ttl_seconds defaults to 15 minutes when zero
+	Cache           bool `yaml:"cache,omitempty" json:"cache,omitempty"`
+	CacheTTLSeconds int  `yaml:"cache_ttl_seconds,omitempty" json:"cache_ttl_seconds,omitempty"`
+}
+
+// MindFallback is one fallback model in a Mind's chain
+type MindFallback struct {
+	Provider   string        `yaml:"provider" json:"provider"`
+	ModelID    string        `yaml:"model_id" json:"model_id"`
+	APIKey     string        `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	APIKeyFrom *SecretKeyRef `yaml:"api_key_from,omitempty" json:"api_key_from,omitempty"`
+	BaseURL    string        `yaml:"base_url,omitempty" json:"base_url,omitempty"`
+	Deployment string        `yaml:"deployment,omitempty" json:"deployment,omitempty"`
 }
 
 func (m *Mind) GetKind() ResourceKind { return KindMind }
@@ -106,6 +140,11 @@ type CraftSpec struct {
 	Instructions string            `yaml:"instructions,omitempty" json:"instructions,omitempty"`
 	Environment  map[string]string `yaml:"environment,omitempty" json:"environment,omitempty"`
 	MCP          *MCPConfig        `yaml:"mcp,omitempty" json:"mcp,omitempty"`
+
+	// Runner overrides the bridge process for robots using this craft
+	// (command and arguments). The process must speak the JSON event
+	// protocol over stdio. Empty means the bundled Python runner.
+	Runner []string `yaml:"runner,omitempty" json:"runner,omitempty"`
 }
 
 type ToolConfig struct {
@@ -149,6 +188,25 @@ type RobotSpec struct {
 	Craft      string `yaml:"craft,omitempty" json:"craft,omitempty"` // Reference to Craft resource
 	SessionID  string `yaml:"session_id,omitempty" json:"session_id,omitempty"`
 	MaxHistory int    `yaml:"max_history,omitempty" json:"max_history,omitempty"`
+
+	// Environment references an Environment resource describing where the
+	// robot's tasks run; empty means the local host
+	Environment string `yaml:"environment,omitempty" json:"environment,omitempty"`
+
+	// Limits caps how much work the robot may do; nil means unlimited
+	Limits *RobotLimits `yaml:"limits,omitempty" json:"limits,omitempty"`
+
+	// Knowledge references Knowledge resources whose most relevant chunks
+	// are retrieved and injected into each execution's prompt
+	Knowledge []string `yaml:"knowledge,omitempty" json:"knowledge,omitempty"`
+}
+
+// RobotLimits are optional usage caps enforced at task submission, guarding
+// robots that external triggers could otherwise spam. Zero values mean no
+// limit.
+type RobotLimits struct {
+	MaxTasksPerHour int `yaml:"max_tasks_per_hour,omitempty" json:"max_tasks_per_hour,omitempty"`
+	MaxTokensPerDay int `yaml:"max_tokens_per_day,omitempty" json:"max_tokens_per_day,omitempty"`
 }
 
 func (r *Robot) GetKind() ResourceKind { return KindRobot }
@@ -229,16 +287,296 @@ func (c *Collaboration) Validate() error {
 	return nil
 }
 
+// Workflow chains typed steps — robot runs, shell commands, HTTP calls and
+// manual approvals — with output bindings between them. Where Collaboration
+// orchestrates agents only, Workflow mixes agents with plain automation.
+type Workflow struct {
+	APIVersion string       `yaml:"apiVersion" json:"apiVersion"`
+	Kind       ResourceKind `yaml:"kind" json:"kind"`
+	Metadata   Metadata     `yaml:"metadata" json:"metadata"`
+	Spec       WorkflowSpec `yaml:"spec" json:"spec"`
+}
+
+type WorkflowSpec struct {
+	Steps []WorkflowStep `yaml:"steps" json:"steps"`
+}
+
+// WorkflowStepType selects a step's executor
+type WorkflowStepType string
+
+const (
+	StepTypeRobot    WorkflowStepType = "robot"
+	StepTypeShell    WorkflowStepType = "shell"
+	StepTypeHTTP     WorkflowStepType = "http"
+	StepTypeApproval WorkflowStepType = "approval"
+)
+
+// WorkflowStep is one node in a workflow. Steps without depends_on chain
+// onto the previous step. String fields (prompt, command, url, body, with
+// values) may reference earlier outputs as {{steps.<name>.output}}; with
+// entries are additionally expanded as {{inputs.<key>}}.
+type WorkflowStep struct {
+	Name      string           `yaml:"name" json:"name"`
+	Type      WorkflowStepType `yaml:"type" json:"type"`
+	DependsOn []string         `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+
+	// With binds named inputs for the step; values may reference earlier
+	// step outputs
+	With map[string]string `yaml:"with,omitempty" json:"with,omitempty"`
+
+	// type: robot
+	Robot  string `yaml:"robot,omitempty" json:"robot,omitempty"`
+	Prompt string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
+
+	// type: shell
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// type: http
+	URL     string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Method  string            `yaml:"method,omitempty" json:"method,omitempty"` // defaults to GET
+	Body    string            `yaml:"body,omitempty" json:"body,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// type: approval
+	Message        string `yaml:"message,omitempty" json:"message,omitempty"`
+	TimeoutSeconds int    `yaml:"timeout_seconds,omitempty" json:"timeout_seconds,omitempty"`
+}
+
+func (w *Workflow) GetKind() ResourceKind { return KindWorkflow }
+func (w *Workflow) GetMetadata() Metadata { return w.Metadata }
+func (w *Workflow) Validate() error {
+	if w.Metadata.Name == "" {
+		return ErrInvalidMetadata
+	}
+	if len(w.Spec.Steps) == 0 {
+		return ErrInvalidSpec
+	}
+	names := make(map[string]bool, len(w.Spec.Steps))
+	for _, step := range w.Spec.Steps {
+		if step.Name == "" || names[step.Name] {
+			return ErrInvalidSpec
+		}
+		names[step.Name] = true
+
+		switch step.Type {
+		case StepTypeRobot:
+			if step.Robot == "" {
+				return ErrInvalidSpec
+			}
+		case StepTypeShell:
+			if step.Command == "" {
+				return ErrInvalidSpec
+			}
+		case StepTypeHTTP:
+			if step.URL == "" {
+				return ErrInvalidSpec
+			}
+		case StepTypeApproval:
+		default:
+			return ErrInvalidSpec
+		}
+	}
+	for _, step := range w.Spec.Steps {
+		for _, dep := range step.DependsOn {
+			if !names[dep] {
+				return ErrInvalidSpec
+			}
+		}
+	}
+	return nil
+}
+
+// Environment describes where tasks execute: on the local host, inside a
+// docker image, or on a remote runner
+type Environment struct {
+	APIVersion string          `yaml:"apiVersion" json:"apiVersion"`
+	Kind       ResourceKind    `yaml:"kind" json:"kind"`
+	Metadata   Metadata        `yaml:"metadata" json:"metadata"`
+	Spec       EnvironmentSpec `yaml:"spec" json:"spec"`
+}
+
+type EnvironmentSpec struct {
+	Type       string            `yaml:"type" json:"type"`                                 // local, docker, remote
+	Image      string            `yaml:"image,omitempty" json:"image,omitempty"`           // Docker image for type=docker
+	RunnerURL  string            `yaml:"runner_url,omitempty" json:"runner_url,omitempty"` // Runner endpoint for type=remote
+	WorkingDir string            `yaml:"working_dir,omitempty" json:"working_dir,omitempty"`
+	Env        map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// Runner overrides the bridge process run in this environment (command
+	// and arguments, e.g. ["node", "runner.js"]). The process must speak
+	// the JSON event protocol over stdio. Empty means the bundled Python
+	// runner.
+	Runner []string `yaml:"runner,omitempty" json:"runner,omitempty"`
+}
+
+const (
+	EnvironmentTypeLocal  = "local"
+	EnvironmentTypeDocker = "docker"
+	EnvironmentTypeRemote = "remote"
+)
+
+func (e *Environment) GetKind() ResourceKind { return KindEnvironment }
+func (e *Environment) GetMetadata() Metadata { return e.Metadata }
+func (e *Environment) Validate() error {
+	if e.Metadata.Name == "" {
+		return ErrInvalidMetadata
+	}
+	switch e.Spec.Type {
+	case EnvironmentTypeLocal:
+	case EnvironmentTypeDocker:
+		if e.Spec.Image == "" {
+			return ErrInvalidSpec
+		}
+	case EnvironmentTypeRemote:
+		if e.Spec.RunnerURL == "" {
+			return ErrInvalidSpec
+		}
+	default:
+		return ErrInvalidSpec
+	}
+	return nil
+}
+
+// Knowledge represents retrieval sources for RAG: inline documents, URLs and
+// uploaded attachments that are chunked and matched against each prompt
+type Knowledge struct {
+	APIVersion string        `yaml:"apiVersion" json:"apiVersion"`
+	Kind       ResourceKind  `yaml:"kind" json:"kind"`
+	Metadata   Metadata      `yaml:"metadata" json:"metadata"`
+	Spec       KnowledgeSpec `yaml:"spec" json:"spec"`
+}
+
+type KnowledgeSpec struct {
+	// Documents are inline text sources
+	Documents []KnowledgeDocument `yaml:"documents,omitempty" json:"documents,omitempty"`
+
+	// URLs are fetched at execution time
+	URLs []string `yaml:"urls,omitempty" json:"urls,omitempty"`
+
+	// AttachmentIDs reference uploaded attachments whose extracted text is
+	// used as a source
+	AttachmentIDs []uint `yaml:"attachment_ids,omitempty" json:"attachment_ids,omitempty"`
+
+	// ChunkSize is the approximate chunk length in characters; 0 uses the
+	// default
+	ChunkSize int `yaml:"chunk_size,omitempty" json:"chunk_size,omitempty"`
+
+	// TopK is how many chunks are injected per execution; 0 uses the default
+	TopK int `yaml:"top_k,omitempty" json:"top_k,omitempty"`
+}
+
+type KnowledgeDocument struct {
+	Name    string `yaml:"name" json:"name"`
+	Content string `yaml:"content" json:"content"`
+}
+
+func (k *Knowledge) GetKind() ResourceKind { return KindKnowledge }
+func (k *Knowledge) GetMetadata() Metadata { return k.Metadata }
+func (k *Knowledge) Validate() error {
+	if k.Metadata.Name == "" {
+		return ErrInvalidMetadata
+	}
+	if len(k.Spec.Documents) == 0 && len(k.Spec.URLs) == 0 && len(k.Spec.AttachmentIDs) == 0 {
+		return ErrInvalidSpec
+	}
+	return nil
+}
+
+// SecretKeyRef points at one key inside a Secret resource, so specs can be
+// committed to git without embedding credentials
+type SecretKeyRef struct {
+	SecretRef string `yaml:"secretRef" json:"secretRef"` // Secret resource name
+	Key       string `yaml:"key" json:"key"`             // key within the secret's data
+}
+
+// Secret holds workspace credentials referenced from other specs. Values
+// are encrypted at rest by the storage layer and only decrypted at the
+// point of use.
+type Secret struct {
+	APIVersion string       `yaml:"apiVersion" json:"apiVersion"`
+	Kind       ResourceKind `yaml:"kind" json:"kind"`
+	Metadata   Metadata     `yaml:"metadata" json:"metadata"`
+	Spec       SecretSpec   `yaml:"spec" json:"spec"`
+}
+
+type SecretSpec struct {
+	Data map[string]string `yaml:"data" json:"-"` // never exposed in JSON
+}
+
+func (s *Secret) GetKind() ResourceKind { return KindSecret }
+func (s *Secret) GetMetadata() Metadata { return s.Metadata }
+func (s *Secret) Validate() error {
+	if s.Metadata.Name == "" {
+		return ErrInvalidMetadata
+	}
+	if len(s.Spec.Data) == 0 {
+		return ErrInvalidSpec
+	}
+	return nil
+}
+
+// Trigger links repository webhook events to a robot or team: matching
+// events create a task from the prompt template automatically
+type Trigger struct {
+	APIVersion string       `yaml:"apiVersion" json:"apiVersion"`
+	Kind       ResourceKind `yaml:"kind" json:"kind"`
+	Metadata   Metadata     `yaml:"metadata" json:"metadata"`
+	Spec       TriggerSpec  `yaml:"spec" json:"spec"`
+}
+
+type TriggerSpec struct {
+	// Repository is the full name the webhook reports, e.g. "acme/api"
+	Repository string `yaml:"repository" json:"repository"`
+	// Events are the webhook events that fire the trigger: push,
+	// pull_request, issue_comment
+	Events []string `yaml:"events" json:"events"`
+	// Secret verifies the webhook's HMAC signature
+	Secret string `yaml:"secret,omitempty" json:"-"`
+
+	// ResourceType and ResourceName select the robot or team to run
+	ResourceType string `yaml:"resource_type" json:"resource_type"`
+	ResourceName string `yaml:"resource_name" json:"resource_name"`
+
+	// Prompt is the task prompt template; {{placeholders}} are filled from
+	// the event payload (repo, event, ref, sender, pr_number, pr_title,
+	// pr_url, comment)
+	Prompt string `yaml:"prompt" json:"prompt"`
+}
+
+func (t *Trigger) GetKind() ResourceKind { return KindTrigger }
+func (t *Trigger) GetMetadata() Metadata { return t.Metadata }
+func (t *Trigger) Validate() error {
+	if t.Metadata.Name == "" {
+		return ErrInvalidMetadata
+	}
+	if t.Spec.Repository == "" || len(t.Spec.Events) == 0 ||
+		t.Spec.ResourceType == "" || t.Spec.ResourceName == "" || t.Spec.Prompt == "" {
+		return ErrInvalidSpec
+	}
+	return nil
+}
+
 // Errors
 var (
 	ErrInvalidMetadata = &ValidationError{Message: "invalid metadata"}
 	ErrInvalidSpec     = &ValidationError{Message: "invalid spec"}
 )
 
+// ValidationError pinpoints one invalid field. Path is the dotted YAML
+// path to the field (e.g. "spec.mode") and Line its line in the source
+// document; both are zero-valued for errors raised outside the parser.
 type ValidationError struct {
+	Path    string
+	Line    int
 	Message string
 }
 
 func (e *ValidationError) Error() string {
-	return e.Message
+	if e.Path == "" {
+		return e.Message
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s: %s (line %d)", e.Path, e.Message, e.Line)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
 }
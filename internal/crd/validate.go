@@ -0,0 +1,242 @@
+package crd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationErrors aggregates every field error found in one document, so a
+// bad spec reports all of its problems in a single parse instead of one at
+// a time
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// specLocator maps dotted YAML paths like "spec.steps.0.name" back to line
+// numbers in the source document
+type specLocator struct {
+	root *yaml.Node
+}
+
+func newSpecLocator(data []byte) *specLocator {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return &specLocator{}
+	}
+	return &specLocator{root: doc.Content[0]}
+}
+
+// line resolves a path to the line its value appears on, or 0 when the
+// path is absent from the document. Numeric segments index sequences.
+func (l *specLocator) line(path string) int {
+	node := l.root
+	if node == nil {
+		return 0
+	}
+	for _, segment := range strings.Split(path, ".") {
+		node = childNode(node, segment)
+		if node == nil {
+			return 0
+		}
+	}
+	return node.Line
+}
+
+// nearestLine resolves a path like line, but falls back to the closest
+// present ancestor so errors about missing fields still point somewhere
+// useful (e.g. the enclosing "spec:" mapping)
+func (l *specLocator) nearestLine(path string) int {
+	segments := strings.Split(path, ".")
+	for end := len(segments); end > 0; end-- {
+		if line := l.line(strings.Join(segments[:end], ".")); line > 0 {
+			return line
+		}
+	}
+	return 0
+}
+
+func childNode(node *yaml.Node, segment string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		if idx, err := strconv.Atoi(segment); err == nil && idx >= 0 && idx < len(node.Content) {
+			return node.Content[idx]
+		}
+	}
+	return nil
+}
+
+// fieldChecker accumulates ValidationErrors, resolving each path's line
+// number through the locator
+type fieldChecker struct {
+	loc  *specLocator
+	errs ValidationErrors
+}
+
+func (f *fieldChecker) addf(path, format string, args ...interface{}) {
+	f.errs = append(f.errs, &ValidationError{
+		Path:    path,
+		Line:    f.loc.nearestLine(path),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// validateResource runs kind-specific field checks mirroring each kind's
+// Validate method, but reporting the YAML path and line of every violation
+// instead of a bare "invalid spec"
+func validateResource(resource Resource, loc *specLocator) error {
+	f := &fieldChecker{loc: loc}
+
+	if resource.GetMetadata().Name == "" {
+		f.addf("metadata.name", "name is required")
+	}
+
+	switch r := resource.(type) {
+	case *Soul:
+		if r.Spec.Personality == "" {
+			f.addf("spec.personality", "personality is required")
+		}
+	case *Mind:
+		if r.Spec.Provider == "" {
+			f.addf("spec.provider", "provider is required")
+		}
+		if r.Spec.ModelID == "" {
+			f.addf("spec.model_id", "model_id is required")
+		}
+	case *Robot:
+		if r.Spec.Soul == "" {
+			f.addf("spec.soul", "soul reference is required")
+		}
+		if r.Spec.Mind == "" {
+			f.addf("spec.mind", "mind reference is required")
+		}
+	case *Team:
+		if len(r.Spec.Members) == 0 {
+			f.addf("spec.members", "at least one member is required")
+		}
+		switch r.Spec.Mode {
+		case "", ModeCoordinate, ModeCollaborate, ModeRoute:
+		default:
+			f.addf("spec.mode", "must be one of %s, %s, %s", ModeCoordinate, ModeCollaborate, ModeRoute)
+		}
+	case *Collaboration:
+		switch r.Spec.Type {
+		case "", "sequential", "parallel", "conditional":
+		default:
+			f.addf("spec.type", "must be one of sequential, parallel, conditional")
+		}
+		for i, step := range r.Spec.Steps {
+			if step.Name == "" {
+				f.addf(fmt.Sprintf("spec.steps.%d.name", i), "step name is required")
+			}
+			if step.Agent == "" {
+				f.addf(fmt.Sprintf("spec.steps.%d.agent", i), "agent reference is required")
+			}
+		}
+	case *Workflow:
+		validateWorkflowFields(r, f)
+	case *Environment:
+		switch r.Spec.Type {
+		case EnvironmentTypeLocal:
+		case EnvironmentTypeDocker:
+			if r.Spec.Image == "" {
+				f.addf("spec.image", "image is required for type %s", EnvironmentTypeDocker)
+			}
+		case EnvironmentTypeRemote:
+			if r.Spec.RunnerURL == "" {
+				f.addf("spec.runner_url", "runner_url is required for type %s", EnvironmentTypeRemote)
+			}
+		default:
+			f.addf("spec.type", "must be one of %s, %s, %s", EnvironmentTypeLocal, EnvironmentTypeDocker, EnvironmentTypeRemote)
+		}
+	case *Knowledge:
+		if len(r.Spec.Documents) == 0 && len(r.Spec.URLs) == 0 && len(r.Spec.AttachmentIDs) == 0 {
+			f.addf("spec", "at least one of documents, urls or attachment_ids is required")
+		}
+	case *Secret:
+		if len(r.Spec.Data) == 0 {
+			f.addf("spec.data", "at least one data entry is required")
+		}
+	case *Trigger:
+		if r.Spec.Repository == "" {
+			f.addf("spec.repository", "repository is required")
+		}
+		if len(r.Spec.Events) == 0 {
+			f.addf("spec.events", "at least one event is required")
+		}
+		if r.Spec.ResourceType == "" {
+			f.addf("spec.resource_type", "resource_type is required")
+		}
+		if r.Spec.ResourceName == "" {
+			f.addf("spec.resource_name", "resource_name is required")
+		}
+		if r.Spec.Prompt == "" {
+			f.addf("spec.prompt", "prompt is required")
+		}
+	}
+
+	if len(f.errs) > 0 {
+		return f.errs
+	}
+
+	// Backstop: any rule not mirrored above still fails the parse, just
+	// without a location
+	return resource.Validate()
+}
+
+func validateWorkflowFields(w *Workflow, f *fieldChecker) {
+	if len(w.Spec.Steps) == 0 {
+		f.addf("spec.steps", "at least one step is required")
+		return
+	}
+	names := make(map[string]bool, len(w.Spec.Steps))
+	for i, step := range w.Spec.Steps {
+		path := func(field string) string { return fmt.Sprintf("spec.steps.%d.%s", i, field) }
+
+		if step.Name == "" {
+			f.addf(path("name"), "step name is required")
+		} else if names[step.Name] {
+			f.addf(path("name"), "duplicate step name %q", step.Name)
+		}
+		names[step.Name] = true
+
+		switch step.Type {
+		case StepTypeRobot:
+			if step.Robot == "" {
+				f.addf(path("robot"), "robot reference is required for type %s", StepTypeRobot)
+			}
+		case StepTypeShell:
+			if step.Command == "" {
+				f.addf(path("command"), "command is required for type %s", StepTypeShell)
+			}
+		case StepTypeHTTP:
+			if step.URL == "" {
+				f.addf(path("url"), "url is required for type %s", StepTypeHTTP)
+			}
+		case StepTypeApproval:
+		default:
+			f.addf(path("type"), "must be one of %s, %s, %s, %s", StepTypeRobot, StepTypeShell, StepTypeHTTP, StepTypeApproval)
+		}
+	}
+	for i, step := range w.Spec.Steps {
+		for j, dep := range step.DependsOn {
+			if !names[dep] {
+				f.addf(fmt.Sprintf("spec.steps.%d.depends_on.%d", i, j), "unknown step %q", dep)
+			}
+		}
+	}
+}
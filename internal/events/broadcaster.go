@@ -1,4 +1,4 @@
-package handlers
+package events
 
 import (
 	"log"
@@ -13,14 +13,20 @@ const (
 
 // TaskEvent represents a real-time task event
 type TaskEvent struct {
-	TaskID    uint                   `json:"task_id"`
-	Type      string                 `json:"type"`
-	Content   string                 `json:"content,omitempty"`
-	Details   map[string]interface{} `json:"details,omitempty"`
-	Progress  int                    `json:"progress,omitempty"`
-	Status    string                 `json:"status,omitempty"`
-	EventType string                 `json:"event_type,omitempty"`
-	Timestamp time.Time              `json:"timestamp"`
+	TaskID      uint                   `json:"task_id"`
+	WorkspaceID uint                   `json:"workspace_id,omitempty"`
+	Type        string                 `json:"type"`
+	Content     string                 `json:"content,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+	Progress    int                    `json:"progress,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	EventType   string                 `json:"event_type,omitempty"`
+	Timestamp   time.Time              `json:"timestamp"`
+
+	// Seq increases monotonically per task so clients can order and dedupe
+	// replayed events; Schema is the event schema version
+	Seq    uint64 `json:"seq"`
+	Schema int    `json:"schema"`
 }
 
 // eventBuffer stores recent events for replay to late subscribers
@@ -34,6 +40,12 @@ type EventBroadcaster struct {
 	mu          sync.RWMutex
 	subscribers map[uint][]chan TaskEvent // taskID -> list of subscriber channels
 	buffers     map[uint]*eventBuffer     // taskID -> buffered events for replay
+	seqs        map[uint]uint64           // taskID -> last assigned sequence number
+
+	// wsSubscribers receive every event in a workspace, for dashboards
+	// that watch all task activity over one connection. Workspace streams
+	// are live-only: no buffering or replay.
+	wsSubscribers map[uint][]chan TaskEvent
 }
 
 // Global broadcaster instance
@@ -47,8 +59,10 @@ func GetBroadcaster() *EventBroadcaster {
 // NewEventBroadcaster creates a new event broadcaster
 func NewEventBroadcaster() *EventBroadcaster {
 	b := &EventBroadcaster{
-		subscribers: make(map[uint][]chan TaskEvent),
-		buffers:     make(map[uint]*eventBuffer),
+		subscribers:   make(map[uint][]chan TaskEvent),
+		buffers:       make(map[uint]*eventBuffer),
+		seqs:          make(map[uint]uint64),
+		wsSubscribers: make(map[uint][]chan TaskEvent),
 	}
 	// Start cleanup goroutine
 	go b.cleanupLoop()
@@ -64,6 +78,7 @@ func (b *EventBroadcaster) cleanupLoop() {
 		for taskID, buf := range b.buffers {
 			if now.Sub(buf.createdAt) > bufferTTL {
 				delete(b.buffers, taskID)
+				delete(b.seqs, taskID)
 			}
 		}
 		b.mu.Unlock()
@@ -102,6 +117,48 @@ func (b *EventBroadcaster) Subscribe(taskID uint) chan TaskEvent {
 	return ch
 }
 
+// SubscribeWorkspace creates a subscription receiving every event in a
+// workspace. Unlike per-task subscriptions there is no replay.
+func (b *EventBroadcaster) SubscribeWorkspace(workspaceID uint) chan TaskEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan TaskEvent, 200)
+	b.wsSubscribers[workspaceID] = append(b.wsSubscribers[workspaceID], ch)
+	return ch
+}
+
+// UnsubscribeWorkspace removes a workspace subscription channel
+func (b *EventBroadcaster) UnsubscribeWorkspace(workspaceID uint, ch chan TaskEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs := b.wsSubscribers[workspaceID]
+	for i, sub := range subs {
+		if sub == ch {
+			b.wsSubscribers[workspaceID] = append(subs[:i], subs[i+1:]...)
+			close(ch)
+			break
+		}
+	}
+
+	if len(b.wsSubscribers[workspaceID]) == 0 {
+		delete(b.wsSubscribers, workspaceID)
+	}
+}
+
+// BufferedCount returns the number of events buffered for a task. A zero
+// count for a finished task means the buffer has expired and replay must
+// come from persisted events instead.
+func (b *EventBroadcaster) BufferedCount(taskID uint) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if buf, ok := b.buffers[taskID]; ok {
+		return len(buf.events)
+	}
+	return 0
+}
+
 // Unsubscribe removes a subscription channel
 func (b *EventBroadcaster) Unsubscribe(taskID uint, ch chan TaskEvent) {
 	b.mu.Lock()
@@ -130,6 +187,11 @@ func (b *EventBroadcaster) Broadcast(event TaskEvent) {
 		event.TaskID, event.Type, event.EventType, event.Content[:min(len(event.Content), 50)], len(b.subscribers[event.TaskID]))
 
 	b.mu.Lock()
+	// Stamp the schema version and the task's next sequence number
+	b.seqs[event.TaskID]++
+	event.Seq = b.seqs[event.TaskID]
+	event.Schema = SchemaVersion
+
 	// Buffer the event for late subscribers
 	buf, ok := b.buffers[event.TaskID]
 	if !ok {
@@ -143,9 +205,12 @@ func (b *EventBroadcaster) Broadcast(event TaskEvent) {
 		buf.events = append(buf.events, event)
 	}
 
-	// Get subscribers snapshot
+	// Get subscribers snapshot, including workspace-wide watchers
 	subs := make([]chan TaskEvent, len(b.subscribers[event.TaskID]))
 	copy(subs, b.subscribers[event.TaskID])
+	if event.WorkspaceID != 0 {
+		subs = append(subs, b.wsSubscribers[event.WorkspaceID]...)
+	}
 	b.mu.Unlock()
 
 	// Send to all subscribers
@@ -164,6 +229,7 @@ func (b *EventBroadcaster) ClearBuffer(taskID uint) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	delete(b.buffers, taskID)
+	delete(b.seqs, taskID)
 }
 
 // BroadcastToTask is a convenience method
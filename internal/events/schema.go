@@ -0,0 +1,111 @@
+package events
+
+// SchemaVersion identifies the task event schema. Every broadcast event
+// carries it, and it is bumped when event types or payload shapes change
+// incompatibly so replaying clients can detect mismatches.
+const SchemaVersion = 1
+
+// TaskEventType enumerates every event type the executors and the Python
+// bridge may emit. Bridge events with types outside this set are dropped
+// at the boundary.
+type TaskEventType string
+
+const (
+	// Lifecycle
+	EventStarted   TaskEventType = "started"
+	EventCompleted TaskEventType = "completed"
+	EventCancelled TaskEventType = "cancelled"
+	EventError     TaskEventType = "error"
+	EventWarning   TaskEventType = "warning"
+
+	// Streamed model output and reasoning
+	EventContent      TaskEventType = "content"
+	EventReasoning    TaskEventType = "reasoning"
+	EventThinkingStep TaskEventType = "thinking_step"
+
+	// Agent and team runs
+	EventRunStarted       TaskEventType = "run_started"
+	EventRunCompleted     TaskEventType = "run_completed"
+	EventTeamRunStarted   TaskEventType = "team_run_started"
+	EventTeamRunCompleted TaskEventType = "team_run_completed"
+
+	// Tool calls
+	EventToolCall            TaskEventType = "tool_call"
+	EventToolCallStarted     TaskEventType = "tool_call_started"
+	EventToolCallCompleted   TaskEventType = "tool_call_completed"
+	EventMemberToolStarted   TaskEventType = "member_tool_started"
+	EventMemberToolCompleted TaskEventType = "member_tool_completed"
+
+	// Plans and steps
+	EventPlan          TaskEventType = "plan"
+	EventStepStarted   TaskEventType = "step_started"
+	EventStepCompleted TaskEventType = "step_completed"
+	EventStepFailed    TaskEventType = "step_failed"
+
+	// Execution infrastructure
+	EventMCPConnected       TaskEventType = "mcp_connected"
+	EventSessionReused      TaskEventType = "session_reused"
+	EventGitDownloaded      TaskEventType = "git_downloaded"
+	EventGitWorkspaceReady  TaskEventType = "git_workspace_ready"
+	EventPRCreated          TaskEventType = "pr_created"
+	EventArtifact           TaskEventType = "artifact"
+	EventArtifactRegistered TaskEventType = "artifact_registered"
+	EventModelServed        TaskEventType = "model_served"
+	EventApprovalRequired   TaskEventType = "approval_required"
+)
+
+// knownEventTypes is the validation set derived from the constants above
+var knownEventTypes = map[TaskEventType]bool{
+	EventStarted: true, EventCompleted: true, EventCancelled: true,
+	EventError: true, EventWarning: true,
+	EventContent: true, EventReasoning: true, EventThinkingStep: true,
+	EventRunStarted: true, EventRunCompleted: true,
+	EventTeamRunStarted: true, EventTeamRunCompleted: true,
+	EventToolCall: true, EventToolCallStarted: true, EventToolCallCompleted: true,
+	EventMemberToolStarted: true, EventMemberToolCompleted: true,
+	EventPlan: true, EventStepStarted: true, EventStepCompleted: true,
+	EventStepFailed:   true,
+	EventMCPConnected: true, EventSessionReused: true, EventGitDownloaded: true,
+	EventGitWorkspaceReady: true, EventPRCreated: true,
+	EventArtifact: true, EventArtifactRegistered: true,
+	EventModelServed: true, EventApprovalRequired: true,
+}
+
+// ValidEventType reports whether a type string is part of the schema; the
+// executor uses it to reject unrecognized events from the Python bridge
+func ValidEventType(eventType string) bool {
+	return knownEventTypes[TaskEventType(eventType)]
+}
+
+// Typed payloads for the structured event types. They document the shape of
+// the details map each event carries; emitters that still build maps keep
+// these field names.
+
+// ToolCallPayload accompanies tool_call* events
+type ToolCallPayload struct {
+	Tool      string `json:"tool"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ArtifactPayload accompanies artifact and artifact_registered events
+type ArtifactPayload struct {
+	ID   uint   `json:"id,omitempty"`
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// ModelServedPayload accompanies model_served events
+type ModelServedPayload struct {
+	Model string `json:"model"`
+}
+
+// PlanPayload accompanies plan events
+type PlanPayload struct {
+	Steps []string `json:"steps"`
+}
+
+// ApprovalPayload accompanies approval_required events
+type ApprovalPayload struct {
+	SubtaskID uint `json:"subtask_id"`
+}
@@ -5,15 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/events"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/secrets"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -21,14 +26,59 @@ import (
 type AgnoExecutor struct {
 	storage *storage.Storage
 	logger  *zap.Logger
+	secrets *secrets.Store
+	proxy   string // default HTTP proxy for bridge processes, empty leaves the environment alone
+
+	// artifacts is set by ConfigureArtifacts; nil disables artifact
+	// registration
+	artifacts *artifactStore
+
+	// embedder is set by ConfigureEmbeddings; nil disables semantic
+	// attachment retrieval
+	embedder llm.Embeddings
+
+	// pool keeps warm bridge daemons for plain local runs; nil execs the
+	// bridge per task
+	pool *bridgePool
 }
 
 // NewAgnoExecutor creates a new agno executor
 func NewAgnoExecutor(storage *storage.Storage, logger *zap.Logger) *AgnoExecutor {
-	return &AgnoExecutor{
+	e := &AgnoExecutor{
 		storage: storage,
 		logger:  logger,
+		// Server-wide default proxy; Minds and workspaces can override it
+		proxy: os.Getenv("XGENT_PROXY"),
+	}
+
+	// Warm bridge daemons skip interpreter startup for plain local runs;
+	// XGENT_BRIDGE_POOL sets the pool size, 0 falls back to per-task exec
+	poolSize := defaultBridgePoolSize
+	if value := os.Getenv("XGENT_BRIDGE_POOL"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			poolSize = n
+		}
+	}
+	if poolSize > 0 {
+		cwd, _ := os.Getwd()
+		scriptPath := filepath.Join(cwd, "scripts", "agno_runner.py")
+		if _, err := os.Stat(scriptPath); err == nil {
+			e.pool = newBridgePool([]string{"python3", scriptPath, "--serve"}, poolSize, logger)
+		}
+	}
+
+	// Secret resolution is optional: without an encryption key, secretRef
+	// values in Craft MCP env cannot be resolved and are skipped.
+	if key := os.Getenv("XGENT_ENCRYPTION_KEY"); key != "" {
+		store, err := secrets.NewStore(storage.Secrets(), key)
+		if err != nil {
+			logger.Warn("Failed to initialize secret store", zap.Error(err))
+		} else {
+			e.secrets = store
+		}
 	}
+
+	return e
 }
 
 // Execute executes a task
@@ -39,6 +89,24 @@ func (e *AgnoExecutor) Execute(ctx context.Context, task *models.Task, callback
 		zap.String("resource_name", task.ResourceName),
 	)
 
+	// Clamp reported progress so it never moves backwards
+	callback = monotonicCallback(callback)
+
+	// Scrub PII and credentials from everything this run persists when the
+	// workspace opted in
+	redact := redactionEnabled(e.storage, task.WorkspaceID)
+	if redact {
+		callback = redactingCallback(callback)
+	}
+
+	// Reuse an earlier identical run's result when the task opted in
+	if task.UseCache {
+		task.CacheKey = e.cacheKey(task)
+		if cached := e.lookupCache(task); cached != nil {
+			return e.completeFromCache(task, cached, callback)
+		}
+	}
+
 	// Update task status to running
 	task.Status = models.TaskStatusRunning
 	now := time.Now()
@@ -62,6 +130,10 @@ func (e *AgnoExecutor) Execute(ctx context.Context, task *models.Task, callback
 		result, eventLogs, err = e.executeBot(ctx, task, callback)
 	case "team":
 		result, eventLogs, err = e.executeTeam(ctx, task, callback)
+	case "collaboration":
+		result, eventLogs, err = e.executeCollaboration(ctx, task, callback)
+	case "workflow":
+		result, eventLogs, err = e.executeWorkflow(ctx, task, callback)
 	default:
 		err = fmt.Errorf("unsupported resource type: %s", task.ResourceType)
 	}
@@ -71,13 +143,21 @@ func (e *AgnoExecutor) Execute(ctx context.Context, task *models.Task, callback
 	task.CompletedAt = &completed
 	task.Duration = completed.Sub(*task.StartedAt).Milliseconds()
 
+	if redact {
+		result = redactSensitive(result)
+		eventLogs = redactSensitive(eventLogs)
+	}
+
 	if err != nil {
 		task.Status = models.TaskStatusFailed
 		task.Error = err.Error()
+		if redact {
+			task.Error = redactSensitive(task.Error)
+		}
 		e.storage.Tasks().Update(task)
 
 		if callback != nil {
-			callback(task.ID, 0, models.TaskStatusFailed, err.Error(), nil)
+			callback(task.ID, task.Progress, models.TaskStatusFailed, task.Error, nil)
 		}
 		return err
 	}
@@ -85,11 +165,23 @@ func (e *AgnoExecutor) Execute(ctx context.Context, task *models.Task, callback
 	task.Status = models.TaskStatusCompleted
 	task.Result = result
 	task.Progress = 100
-	task.EventLogs = eventLogs
+	task.TokensUsed = estimateTokens(task.Prompt + result)
+	completion := "Task completed"
+	// The bridge reports usage only once it finishes, so a crossed budget
+	// is flagged after the fact rather than aborting mid-run
+	if task.MaxTokens > 0 && task.TokensUsed > task.MaxTokens {
+		task.Status = models.TaskStatusBudgetExceeded
+		task.Error = fmt.Sprintf("task used ~%d of %d budgeted tokens", task.TokensUsed, task.MaxTokens)
+		completion = task.Error
+	}
 	e.storage.Tasks().Update(task)
 
+	e.persistEventRows(task.ID, eventLogs)
+
+	logReplayOutcome(e.storage, e.logger, task)
+
 	if callback != nil {
-		callback(task.ID, 100, models.TaskStatusCompleted, "Task completed", map[string]interface{}{
+		callback(task.ID, 100, task.Status, completion, map[string]interface{}{
 			"result": result,
 		})
 	}
@@ -107,12 +199,27 @@ type AgnoConfig struct {
 	Context   AgnoContextConfig `json:"context"`
 	SessionID string            `json:"session_id"`
 	MCPTools  []AgnoMCPConfig   `json:"mcp_tools,omitempty"`
+	ToolEnv   map[string]string `json:"tool_env,omitempty"` // craft environment exported to tools
 
 	// Execution options
 	Stream       bool `json:"stream"`        // Enable streaming mode (default true)
 	Debug        bool `json:"debug"`         // Enable debug mode
 	DebugLevel   int  `json:"debug_level"`   // Debug level (1-3)
 	ReuseSession bool `json:"reuse_session"` // Enable session reuse caching
+
+	// Environment describes where the bridge process runs; nil means the
+	// local host. Not part of the bridge payload.
+	Environment *crd.EnvironmentSpec `json:"-"`
+
+	// Proxy is the HTTP proxy for the bridge process; empty leaves the
+	// process environment untouched. Not part of the bridge payload.
+	Proxy string `json:"-"`
+
+	// Runner is the bridge command override declared on the craft; it must
+	// speak the JSON event protocol over stdio. Empty falls back to the
+	// environment's runner, then the bundled Python runner. Not part of the
+	// bridge payload.
+	Runner []string `json:"-"`
 }
 
 type AgnoModelConfig struct {
@@ -127,6 +234,23 @@ type AgnoSoulConfig struct {
 	Personality string `json:"personality"`
 }
 
+// taskContext builds the bridge execution context. A clone prepared by the
+// orchestrator's git flow takes precedence over the bridge downloading the
+// repository itself.
+func taskContext(task *models.Task) AgnoContextConfig {
+	if task.WorkDir != "" {
+		return AgnoContextConfig{
+			Cwd:         task.WorkDir,
+			ProjectPath: task.WorkDir,
+			Branch:      task.BranchName,
+		}
+	}
+	return AgnoContextConfig{
+		GitURL: task.GitURL,
+		Branch: task.BranchName,
+	}
+}
+
 // AgnoContextConfig represents execution context
 type AgnoContextConfig struct {
 	Cwd         string `json:"cwd,omitempty"`
@@ -167,91 +291,141 @@ type AgnoMemberConfig struct {
 
 // executeBot executes a robot task
 func (e *AgnoExecutor) executeBot(ctx context.Context, task *models.Task, callback ProgressCallback) (string, string, error) {
+	prompt := RenderPrompt(task.Prompt, task, e.storage) + attachmentContext(e.storage, e.embedder, task, callback)
+	config, err := e.buildRobotConfig(task, task.ResourceName, prompt)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Execute Python script
+	return e.runAgnoScript(ctx, *config, task.ID, callback)
+}
+
+// buildRobotConfig loads a robot and its soul, mind and craft, and builds the
+// bridge configuration to run the given prompt against it
+func (e *AgnoExecutor) buildRobotConfig(task *models.Task, robotName, prompt string) (*AgnoConfig, error) {
 	// Load robot resource
-	robotResource, err := e.storage.Resources().GetByName(task.WorkspaceID, task.ResourceName, models.ResourceTypeRobot)
+	robotResource, err := resolveResource(e.storage, task.WorkspaceID, robotName, models.ResourceTypeRobot)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to load robot: %w", err)
+		return nil, fmt.Errorf("failed to load robot: %w", err)
 	}
 
 	// Parse robot spec
-	parser := crd.NewParser()
-	resource, err := parser.Parse([]byte(robotResource.Spec))
+	resource, err := parseResolved(robotResource, resolveSpec(task, robotResource))
 	if err != nil {
-		return "", "", fmt.Errorf("failed to parse robot spec: %w", err)
+		return nil, fmt.Errorf("failed to parse robot spec: %w", err)
 	}
 
 	robot, ok := resource.(*crd.Robot)
 	if !ok {
-		return "", "", fmt.Errorf("invalid robot resource")
+		return nil, fmt.Errorf("invalid robot resource")
+	}
+
+	// Resolve execution environment (task override, then robot)
+	env, err := e.resolveEnvironment(task, robot.Spec.Environment)
+	if err != nil {
+		return nil, err
 	}
 
 	// Load soul
-	soulResource, err := e.storage.Resources().GetByName(task.WorkspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
+	soulResource, err := resolveResource(e.storage, task.WorkspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to load soul: %w", err)
+		return nil, fmt.Errorf("failed to load soul: %w", err)
 	}
-	soulDef, _ := parser.Parse([]byte(soulResource.Spec))
+	soulDef, _ := parseResolved(soulResource, resolveSpec(task, soulResource))
 	soul := soulDef.(*crd.Soul)
 
 	// Load mind
-	mindResource, err := e.storage.Resources().GetByName(task.WorkspaceID, robot.Spec.Mind, models.ResourceTypeMind)
+	mindResource, err := resolveResource(e.storage, task.WorkspaceID,
+		defaultedMind(e.storage, task.WorkspaceID, robot.Spec.Mind), models.ResourceTypeMind)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to load mind: %w", err)
+		return nil, fmt.Errorf("failed to load mind: %w", err)
 	}
-	mindDef, _ := parser.Parse([]byte(mindResource.Spec))
+	mindDef, _ := parseResolved(mindResource, resolveSpec(task, mindResource))
 	mind := mindDef.(*crd.Mind)
 
-	// Load MCP tools if craft is configured
+	// Load the craft for MCP tools, instructions and tool environment
 	var mcpTools []AgnoMCPConfig
+	var craft *crd.Craft
 	if robot.Spec.Craft != "" {
-		mcpTools = e.loadMCPTools(task.WorkspaceID, robot.Spec.Craft, parser)
+		if craft = e.loadCraft(task.WorkspaceID, robot.Spec.Craft); craft != nil {
+			mcpTools = e.loadMCPTools(task.WorkspaceID, craft)
+		}
 	}
 
 	// Build session ID
 	sessionID := fmt.Sprintf("task-%d", task.ID)
 
 	// Prepare Config
-	config := AgnoConfig{
+	config := &AgnoConfig{
 		Type:      "robot",
-		Prompt:    task.Prompt,
+		Prompt:    prompt,
 		SessionID: sessionID,
 		Model: AgnoModelConfig{
 			Provider: mind.Spec.Provider,
 			ModelID:  mind.Spec.ModelID,
-			APIKey:   mind.Spec.APIKey,
+			APIKey:   e.mindAPIKey(task.WorkspaceID, mind),
 			BaseURL:  mind.Spec.BaseURL,
 		},
 		Soul: AgnoSoulConfig{
 			Name:        robot.Metadata.Name,
 			Personality: soul.Spec.Personality,
 		},
-		Context: AgnoContextConfig{
-			GitURL: task.GitURL,
-			Branch: task.BranchName,
-		},
+		Context:  taskContext(task),
 		MCPTools: mcpTools,
 		// Execution options
 		Stream:       true, // Default to streaming
 		Debug:        false,
 		DebugLevel:   2,
 		ReuseSession: true,
+		Environment:  env,
+		Proxy:        e.resolveProxy(task, mind.Spec.Proxy),
 	}
 
-	// Execute Python script
-	return e.runAgnoScript(ctx, config, task.ID, callback)
+	// Craft instructions reach the model through the system prompt; the
+	// craft environment is exported to tool execution by the bridge
+	if craft != nil {
+		if craft.Spec.Instructions != "" {
+			config.Soul.Personality += "\n\n# Tool instructions\n" + craft.Spec.Instructions
+		}
+		config.ToolEnv = e.resolveEnvRefs(task.WorkspaceID, "craft "+robot.Spec.Craft, craft.Spec.Environment)
+		config.Runner = craft.Spec.Runner
+	}
+
+	// Relevant knowledge chunks are retrieved per prompt and appended to it
+	config.Prompt += knowledgeContext(e.storage, task, robot.Spec.Knowledge, e.logger)
+
+	return config, nil
+}
+
+// resolveProxy picks the HTTP proxy for a bridge process: the Mind's proxy
+// wins, then the workspace's (settings first, then the legacy field), then
+// the server-wide default. Empty everywhere means no proxy is injected.
+func (e *AgnoExecutor) resolveProxy(task *models.Task, mindProxy string) string {
+	if mindProxy != "" {
+		return mindProxy
+	}
+	if workspace, err := e.storage.Workspaces().GetByID(task.WorkspaceID); err == nil {
+		if proxy := workspace.SettingsValues().Proxy; proxy != "" {
+			return proxy
+		}
+		if workspace.Proxy != "" {
+			return workspace.Proxy
+		}
+	}
+	return e.proxy
 }
 
 // executeTeam executes a team task
 func (e *AgnoExecutor) executeTeam(ctx context.Context, task *models.Task, callback ProgressCallback) (string, string, error) {
 	// Load team resource
-	teamResource, err := e.storage.Resources().GetByName(task.WorkspaceID, task.ResourceName, models.ResourceTypeTeam)
+	teamResource, err := resolveResource(e.storage, task.WorkspaceID, task.ResourceName, models.ResourceTypeTeam)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to load team: %w", err)
 	}
 
 	// Parse team spec
-	parser := crd.NewParser()
-	resource, err := parser.Parse([]byte(teamResource.Spec))
+	resource, err := parseResolved(teamResource, resolveSpec(task, teamResource))
 	if err != nil {
 		return "", "", fmt.Errorf("failed to parse team spec: %w", err)
 	}
@@ -272,7 +446,7 @@ func (e *AgnoExecutor) executeTeam(ctx context.Context, task *models.Task, callb
 	// Load leader if specified
 	var leaderMind *crd.Mind
 	if teamDef.Spec.Leader != "" {
-		leaderMember, leaderMindDef, err := e.loadRobotAsMember(task.WorkspaceID, teamDef.Spec.Leader, parser)
+		leaderMember, leaderMindDef, err := e.loadRobotAsMember(task.WorkspaceID, teamDef.Spec.Leader)
 		if err != nil {
 			return "", "", fmt.Errorf("failed to load leader robot: %w", err)
 		}
@@ -282,7 +456,7 @@ func (e *AgnoExecutor) executeTeam(ctx context.Context, task *models.Task, callb
 
 	// Load member robots
 	for _, memberName := range teamDef.Spec.Members {
-		member, mindDef, err := e.loadRobotAsMember(task.WorkspaceID, memberName, parser)
+		member, mindDef, err := e.loadRobotAsMember(task.WorkspaceID, memberName)
 		if err != nil {
 			e.logger.Warn("Failed to load member robot, skipping",
 				zap.String("member", memberName),
@@ -300,65 +474,90 @@ func (e *AgnoExecutor) executeTeam(ctx context.Context, task *models.Task, callb
 		return "", "", fmt.Errorf("team has no valid members or leader")
 	}
 
+	// One subtask per member up front, so clients see the roster before the
+	// bridge starts streaming; the event consumer keeps them updated
+	for i, member := range teamConfig.Members {
+		subtask := &models.SubTask{
+			TaskID:    task.ID,
+			Status:    models.TaskStatusPending,
+			Title:     fmt.Sprintf("member %s", member.Name),
+			AgentID:   member.Name,
+			StepOrder: i + 1,
+		}
+		if err := e.storage.Tasks().CreateSubTask(subtask); err != nil {
+			e.logger.Warn("Failed to create member subtask",
+				zap.String("member", member.Name), zap.Error(err))
+		}
+	}
+
 	// Use leader's mind or first member's mind for the team
 	if leaderMind == nil {
 		return "", "", fmt.Errorf("no mind found for team")
 	}
 
+	// Resolve execution environment (task-level only for teams)
+	env, err := e.resolveEnvironment(task, "")
+	if err != nil {
+		return "", "", err
+	}
+
 	// Build session ID
 	sessionID := fmt.Sprintf("task-%d", task.ID)
 
 	// Prepare Config
 	config := AgnoConfig{
 		Type:      "team",
-		Prompt:    task.Prompt,
+		Prompt:    RenderPrompt(task.Prompt, task, e.storage) + attachmentContext(e.storage, e.embedder, task, callback),
 		SessionID: sessionID,
 		Model: AgnoModelConfig{
 			Provider: leaderMind.Spec.Provider,
 			ModelID:  leaderMind.Spec.ModelID,
-			APIKey:   leaderMind.Spec.APIKey,
+			APIKey:   e.mindAPIKey(task.WorkspaceID, leaderMind),
 			BaseURL:  leaderMind.Spec.BaseURL,
 		},
-		Team: &teamConfig,
-		Context: AgnoContextConfig{
-			GitURL: task.GitURL,
-			Branch: task.BranchName,
-		},
+		Team:    &teamConfig,
+		Context: taskContext(task),
 		// Execution options
 		Stream:       true, // Default to streaming
 		Debug:        false,
 		DebugLevel:   2,
 		ReuseSession: true,
+		Environment:  env,
+		Proxy:        e.resolveProxy(task, leaderMind.Spec.Proxy),
 	}
 
 	// Execute Python script
 	return e.runAgnoScript(ctx, config, task.ID, callback)
 }
 
-// loadMCPTools loads MCP tools from a Craft resource
-func (e *AgnoExecutor) loadMCPTools(workspaceID uint, craftName string, parser *crd.Parser) []AgnoMCPConfig {
-	var mcpTools []AgnoMCPConfig
-
-	// Load craft resource
-	craftResource, err := e.storage.Resources().GetByName(workspaceID, craftName, models.ResourceTypeCraft)
+// loadCraft loads and parses a Craft resource, returning nil (with a
+// warning) when it is missing or invalid
+func (e *AgnoExecutor) loadCraft(workspaceID uint, craftName string) *crd.Craft {
+	craftResource, err := resolveResource(e.storage, workspaceID, craftName, models.ResourceTypeCraft)
 	if err != nil {
 		e.logger.Warn("Failed to load craft resource", zap.String("craft", craftName), zap.Error(err))
-		return mcpTools
+		return nil
 	}
 
-	// Parse craft spec
-	resource, err := parser.Parse([]byte(craftResource.Spec))
+	resource, err := parseResolved(craftResource, specForExecution(craftResource))
 	if err != nil {
 		e.logger.Warn("Failed to parse craft spec", zap.Error(err))
-		return mcpTools
+		return nil
 	}
 
 	craft, ok := resource.(*crd.Craft)
 	if !ok {
 		e.logger.Warn("Invalid craft resource")
-		return mcpTools
+		return nil
 	}
 
+	return craft
+}
+
+// loadMCPTools extracts MCP tool configs from a Craft
+func (e *AgnoExecutor) loadMCPTools(workspaceID uint, craft *crd.Craft) []AgnoMCPConfig {
+	var mcpTools []AgnoMCPConfig
+
 	// Extract MCP servers
 	if craft.Spec.MCP != nil {
 		for _, server := range craft.Spec.MCP.Servers {
@@ -367,7 +566,7 @@ func (e *AgnoExecutor) loadMCPTools(workspaceID uint, craftName string, parser *
 				Type:    "stdio", // Default to stdio for command-based servers
 				Command: server.Command,
 				Args:    server.Args,
-				Env:     server.Env,
+				Env:     e.resolveEnvRefs(workspaceID, "mcp "+server.Name, server.Env),
 				Timeout: 300, // Default 5 minutes
 			}
 			mcpTools = append(mcpTools, mcpTool)
@@ -378,16 +577,84 @@ func (e *AgnoExecutor) loadMCPTools(workspaceID uint, craftName string, parser *
 	return mcpTools
 }
 
+// mindAPIKey resolves a mind's API key, following an api_key_from reference
+// when one is set. Resolution failures are logged and the model runs
+// without a key, which the provider rejects with a clear error.
+func (e *AgnoExecutor) mindAPIKey(workspaceID uint, mind *crd.Mind) string {
+	key, err := resolveMindAPIKey(e.storage, workspaceID, &mind.Spec)
+	if err != nil {
+		e.logger.Warn("Failed to resolve mind API key",
+			zap.String("mind", mind.Metadata.Name),
+			zap.Error(err))
+		return ""
+	}
+	return key
+}
+
+// resolveEnvRefs resolves secretRef: values in an env map at execution time
+// so plaintext secrets are only present in the spawned process, never in
+// the stored Craft spec or rendered config. "secretRef:name/key" reads from
+// a Secret resource's data; "secretRef:NAME" reads the workspace secret
+// store.
+func (e *AgnoExecutor) resolveEnvRefs(workspaceID uint, scope string, env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return env
+	}
+
+	resolved := make(map[string]string, len(env))
+	for key, value := range env {
+		if !secrets.IsRef(value) {
+			resolved[key] = value
+			continue
+		}
+
+		if name, dataKey, found := strings.Cut(secrets.RefName(value), "/"); found {
+			plaintext, err := resolveSecretKeyRef(e.storage, workspaceID, &crd.SecretKeyRef{
+				SecretRef: name,
+				Key:       dataKey,
+			})
+			if err != nil {
+				e.logger.Warn("Failed to resolve secret reference in env, skipping",
+					zap.String("scope", scope),
+					zap.String("env_key", key),
+					zap.Error(err))
+				continue
+			}
+			resolved[key] = plaintext
+			continue
+		}
+
+		if e.secrets == nil {
+			e.logger.Warn("Secret reference in env but no encryption key configured, skipping",
+				zap.String("scope", scope),
+				zap.String("env_key", key))
+			continue
+		}
+
+		plaintext, err := e.secrets.Resolve(workspaceID, value)
+		if err != nil {
+			e.logger.Warn("Failed to resolve secret reference in env, skipping",
+				zap.String("scope", scope),
+				zap.String("env_key", key),
+				zap.Error(err))
+			continue
+		}
+		resolved[key] = plaintext
+	}
+
+	return resolved
+}
+
 // loadRobotAsMember loads a robot and returns its member config
-func (e *AgnoExecutor) loadRobotAsMember(workspaceID uint, robotName string, parser *crd.Parser) (*AgnoMemberConfig, *crd.Mind, error) {
+func (e *AgnoExecutor) loadRobotAsMember(workspaceID uint, robotName string) (*AgnoMemberConfig, *crd.Mind, error) {
 	// Load robot resource
-	robotResource, err := e.storage.Resources().GetByName(workspaceID, robotName, models.ResourceTypeRobot)
+	robotResource, err := resolveResource(e.storage, workspaceID, robotName, models.ResourceTypeRobot)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load robot: %w", err)
 	}
 
 	// Parse robot spec
-	resource, err := parser.Parse([]byte(robotResource.Spec))
+	resource, err := parseResolved(robotResource, specForExecution(robotResource))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse robot spec: %w", err)
 	}
@@ -398,19 +665,20 @@ func (e *AgnoExecutor) loadRobotAsMember(workspaceID uint, robotName string, par
 	}
 
 	// Load soul
-	soulResource, err := e.storage.Resources().GetByName(workspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
+	soulResource, err := resolveResource(e.storage, workspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load soul: %w", err)
 	}
-	soulDef, _ := parser.Parse([]byte(soulResource.Spec))
+	soulDef, _ := parseResolved(soulResource, specForExecution(soulResource))
 	soul := soulDef.(*crd.Soul)
 
 	// Load mind
-	mindResource, err := e.storage.Resources().GetByName(workspaceID, robot.Spec.Mind, models.ResourceTypeMind)
+	mindResource, err := resolveResource(e.storage, workspaceID,
+		defaultedMind(e.storage, workspaceID, robot.Spec.Mind), models.ResourceTypeMind)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load mind: %w", err)
 	}
-	mindDef, _ := parser.Parse([]byte(mindResource.Spec))
+	mindDef, _ := parseResolved(mindResource, specForExecution(mindResource))
 	mind := mindDef.(*crd.Mind)
 
 	member := &AgnoMemberConfig{
@@ -419,7 +687,7 @@ func (e *AgnoExecutor) loadRobotAsMember(workspaceID uint, robotName string, par
 		Model: AgnoModelConfig{
 			Provider: mind.Spec.Provider,
 			ModelID:  mind.Spec.ModelID,
-			APIKey:   mind.Spec.APIKey,
+			APIKey:   e.mindAPIKey(workspaceID, mind),
 			BaseURL:  mind.Spec.BaseURL,
 		},
 	}
@@ -429,29 +697,67 @@ func (e *AgnoExecutor) loadRobotAsMember(workspaceID uint, robotName string, par
 
 // runAgnoScript runs the Python bridge script
 func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, taskID uint, callback ProgressCallback) (string, string, error) {
+	// The bridge runtime is usually the bulk of a task's wall time; give it
+	// its own span under task.execute
+	ctx, span := tracing.StartSpan(ctx, "bridge.run", tracing.KindInternal)
+	span.SetAttribute("task.id", fmt.Sprintf("%d", taskID))
+	span.SetAttribute("llm.provider", config.Model.Provider)
+	span.SetAttribute("llm.model", config.Model.ModelID)
+	defer span.End()
+
+	// Remote environments dispatch to a connected runner agent
+	if config.Environment != nil && config.Environment.Type == crd.EnvironmentTypeRemote {
+		return e.runRemote(ctx, config, taskID, callback)
+	}
+
 	// Locate script
 	// Assuming running from project root
 	cwd, _ := os.Getwd()
 	scriptPath := filepath.Join(cwd, "scripts", "agno_runner.py")
 
-	// Check if script exists
-	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
-		return "", "", fmt.Errorf("agno runner script not found at %s", scriptPath)
+	// The craft's runner wins over the environment's; with neither declared
+	// the bundled Python script runs, which must exist
+	runner := config.Runner
+	if len(runner) == 0 && config.Environment != nil {
+		runner = config.Environment.Runner
+	}
+	if len(runner) == 0 {
+		if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
+			return "", "", fmt.Errorf("agno runner script not found at %s", scriptPath)
+		}
 	}
 
-	// Prepare command
-	cmd := exec.CommandContext(ctx, "python3", scriptPath)
-	// cmd := exec.CommandContext(ctx, "python", scriptPath) // Try python if python3 fails?
+	// Plain local runs go through the warm daemon pool; overridden runners,
+	// proxies and custom environments still exec per task because their
+	// process environment differs per run
+	if e.pool != nil && len(runner) == 0 && config.Proxy == "" && bridgePoolEligible(config.Environment) {
+		return e.runViaBridgePool(ctx, config, taskID, callback)
+	}
 
-	// Set proxy environment variables
-	cmd.Env = append(os.Environ(),
-		"https_proxy=http://127.0.0.1:7890",
-		"http_proxy=http://127.0.0.1:7890",
-		"all_proxy=socks5://127.0.0.1:7890",
-		"HTTPS_PROXY=http://127.0.0.1:7890",
-		"HTTP_PROXY=http://127.0.0.1:7890",
-		"ALL_PROXY=socks5://127.0.0.1:7890",
-	)
+	// Prepare command for the configured execution environment
+	cmd, err := buildRunnerCommand(ctx, scriptPath, runner, config.Environment)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Inject the resolved proxy, if any; without one the user's environment
+	// is left untouched
+	cmd.Env = os.Environ()
+	if config.Proxy != "" {
+		cmd.Env = append(cmd.Env,
+			"https_proxy="+config.Proxy,
+			"http_proxy="+config.Proxy,
+			"all_proxy="+config.Proxy,
+			"HTTPS_PROXY="+config.Proxy,
+			"HTTP_PROXY="+config.Proxy,
+			"ALL_PROXY="+config.Proxy,
+		)
+	}
+	if config.Environment != nil {
+		for key, value := range config.Environment.Env {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
 
 	// Stdin pipe
 	stdin, err := cmd.StdinPipe()
@@ -473,7 +779,7 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 
 	// Start command
 	if err := cmd.Start(); err != nil {
-		return "", "", fmt.Errorf("failed to start python script: %w", err)
+		return "", "", fmt.Errorf("failed to start bridge process: %w", err)
 	}
 
 	// Write config to stdin
@@ -482,10 +788,6 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		json.NewEncoder(stdin).Encode(config)
 	}()
 
-	// Read output
-	var fullContent strings.Builder
-	scanner := bufio.NewScanner(stdout)
-
 	go func() {
 		// Read stderr for debugging
 		stderrScanner := bufio.NewScanner(stderr)
@@ -494,8 +796,124 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		}
 	}()
 
-	var lastError string
+	result, eventLogs, lastError, cancelled := e.consumeBridgeEvents(stdout, taskID, callback)
+	if cancelled {
+		return result, eventLogs, nil
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if lastError != "" {
+			return "", "", fmt.Errorf("bridge process error: %s", lastError)
+		}
+		return "", "", fmt.Errorf("bridge process finished with error: %w", err)
+	}
+
+	return result, eventLogs, nil
+}
+
+// bridgePoolEligible reports whether an execution environment can share a
+// pooled daemon: only the plain local host with no extra env vars
+func bridgePoolEligible(env *crd.EnvironmentSpec) bool {
+	if env == nil {
+		return true
+	}
+	return (env.Type == "" || env.Type == crd.EnvironmentTypeLocal) &&
+		len(env.Env) == 0 && len(env.Runner) == 0
+}
+
+// runViaBridgePool executes a task on a pooled daemon, streaming its events
+// through the shared consumer
+func (e *AgnoExecutor) runViaBridgePool(ctx context.Context, config AgnoConfig, taskID uint, callback ProgressCallback) (string, string, error) {
+	daemon, err := e.pool.acquire(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to acquire bridge daemon: %w", err)
+	}
+
+	events, sink := io.Pipe()
+	runErr := make(chan error, 1)
+	go func() {
+		err := daemon.run(ctx, config, sink)
+		sink.Close()
+		runErr <- err
+	}()
+
+	result, eventLogs, lastError, cancelled := e.consumeBridgeEvents(events, taskID, callback)
+	// The consumer can return before the call's response frame (e.g. on a
+	// cancelled event); closing the pipe lets the daemon goroutine drain
+	events.Close()
+	err = <-runErr
+	e.pool.release(daemon, err != nil)
+
+	if cancelled {
+		return result, eventLogs, nil
+	}
+	if err != nil {
+		if lastError != "" {
+			return "", "", fmt.Errorf("bridge process error: %s", lastError)
+		}
+		return "", "", fmt.Errorf("bridge daemon failed: %w", err)
+	}
+	return result, eventLogs, nil
+}
+
+// Close shuts down the warm bridge daemons
+func (e *AgnoExecutor) Close() {
+	if e.pool != nil {
+		e.pool.close()
+	}
+}
+
+// persistEventRows stores the accumulated event lines as task_events rows
+// so they can be paged and replayed after the in-memory buffer expires.
+// Sequence numbers continue from any rows persisted by earlier runs of
+// the same task (collaboration steps, replays).
+func (e *AgnoExecutor) persistEventRows(taskID uint, eventLogs string) {
+	if eventLogs == "" {
+		return
+	}
+
+	seq, err := e.storage.Tasks().LastEventSeq(taskID)
+	if err != nil {
+		e.logger.Warn("Failed to read last event sequence", zap.Uint("task_id", taskID), zap.Error(err))
+		return
+	}
+
+	var rows []*models.TaskEvent
+	for _, line := range strings.Split(eventLogs, "\n") {
+		if line == "" {
+			continue
+		}
+		var event struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue
+		}
+		seq++
+		rows = append(rows, &models.TaskEvent{
+			TaskID:  taskID,
+			Seq:     seq,
+			Type:    event.Type,
+			Payload: line,
+		})
+	}
+
+	if err := e.storage.Tasks().AddEvents(rows); err != nil {
+		e.logger.Warn("Failed to persist task events", zap.Uint("task_id", taskID), zap.Error(err))
+	}
+}
+
+// consumeBridgeEvents reads bridge event lines from output, drives the
+// progress callback and plan tracker, and accumulates the content and event
+// log. It is shared by local and remote executions.
+func (e *AgnoExecutor) consumeBridgeEvents(output io.Reader, taskID uint, callback ProgressCallback) (content, logs, lastError string, cancelled bool) {
+	var fullContent strings.Builder
 	var eventLogs []string
+	plan := newPlanTracker(e.storage, taskID)
+	members := newMemberTracker(e.storage, taskID)
+	estimator := newProgressEstimator(e.storage, taskID)
+
+	scanner := bufio.NewScanner(output)
 	for scanner.Scan() {
 		line := scanner.Text()
 		e.logger.Debug("Python script output", zap.String("line", line))
@@ -511,15 +929,34 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 			continue
 		}
 
+		// Bridge events must match the shared schema; anything else is a
+		// bridge bug and gets dropped rather than forwarded to clients
+		if !events.ValidEventType(event.Type) {
+			e.logger.Warn("Dropping bridge event with unknown type", zap.String("type", event.Type))
+			continue
+		}
+
 		// Store event for logs
 		eventLogs = append(eventLogs, line)
 
+		// Progress derives from the milestones seen so far; subtask rollups
+		// (team members, collaboration steps) win when they are ahead
+		progress := estimator.observe(event.Type, event.Content)
+		switch event.Type {
+		case "run_started", "run_completed", "team_run_started", "team_run_completed",
+			"tool_call_started", "tool_call_completed", "member_tool_started", "member_tool_completed",
+			"step_started", "step_completed", "step_failed":
+			if rollup := taskRollup(e.storage, taskID, progress); rollup > progress {
+				progress = rollup
+			}
+		}
+
 		// Process specific event types (callback is called within each case)
 		switch event.Type {
 		case "started":
 			e.logger.Info("Agno execution started", zap.String("content", event.Content))
 			if callback != nil {
-				callback(taskID, 20, models.TaskStatusRunning, "Agent started", map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, "Agent started", map[string]interface{}{
 					"type": "started",
 				})
 			}
@@ -527,31 +964,39 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		case "content":
 			fullContent.WriteString(event.Content)
 			if callback != nil {
-				callback(taskID, 50, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type": "content",
 				})
 			}
 
 		case "run_started", "team_run_started":
 			e.logger.Info("Agent/Team run started", zap.String("content", event.Content))
+			members.Start(event.Details)
 			if callback != nil {
-				callback(taskID, 30, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type": event.Type,
 				})
 			}
 
 		case "run_completed", "team_run_completed":
 			e.logger.Info("Agent/Team run completed", zap.String("content", event.Content))
+			if event.Type == "team_run_completed" {
+				// Not every bridge attributes per-member completions
+				members.CompleteAll()
+			} else {
+				members.Complete(event.Details)
+			}
 			if callback != nil {
-				callback(taskID, 90, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type": event.Type,
 				})
 			}
 
 		case "tool_call_started", "member_tool_started":
 			e.logger.Info("Tool call started", zap.Any("details", event.Details))
+			members.Touch(event.Details, 50)
 			if callback != nil {
-				callback(taskID, 60, models.TaskStatusRunning, "Tool call started", map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, "Tool call started", map[string]interface{}{
 					"type":    event.Type,
 					"details": event.Details,
 				})
@@ -559,8 +1004,9 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 
 		case "tool_call_completed", "member_tool_completed":
 			e.logger.Info("Tool call completed", zap.Any("details", event.Details))
+			members.Touch(event.Details, 75)
 			if callback != nil {
-				callback(taskID, 70, models.TaskStatusRunning, "Tool call completed", map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, "Tool call completed", map[string]interface{}{
 					"type":    event.Type,
 					"details": event.Details,
 				})
@@ -569,7 +1015,7 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		case "reasoning":
 			e.logger.Info("Team reasoning step", zap.Any("details", event.Details))
 			if callback != nil {
-				callback(taskID, 55, models.TaskStatusRunning, "Reasoning", map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, "Reasoning", map[string]interface{}{
 					"type":    "reasoning",
 					"details": event.Details,
 				})
@@ -578,7 +1024,7 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		case "mcp_connected":
 			e.logger.Info("MCP tool connected", zap.String("content", event.Content))
 			if callback != nil {
-				callback(taskID, 25, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type": "mcp_connected",
 				})
 			}
@@ -586,16 +1032,21 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		case "thinking_step":
 			e.logger.Info("Thinking step", zap.String("content", event.Content), zap.Any("details", event.Details))
 			if callback != nil {
-				callback(taskID, 40, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type":    "thinking_step",
 					"details": event.Details,
 				})
 			}
 
+		case "artifact":
+			// The agent reports an output file; copy it into the blob
+			// store so it survives the workspace directory
+			e.registerArtifact(taskID, event.Details, callback)
+
 		case "session_reused":
 			e.logger.Info("Session reused", zap.String("content", event.Content))
 			if callback != nil {
-				callback(taskID, 25, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type": "session_reused",
 				})
 			}
@@ -603,12 +1054,49 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		case "git_downloaded":
 			e.logger.Info("Git code downloaded", zap.String("content", event.Content), zap.Any("details", event.Details))
 			if callback != nil {
-				callback(taskID, 15, models.TaskStatusRunning, event.Content, map[string]interface{}{
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
 					"type":    "git_downloaded",
 					"details": event.Details,
 				})
 			}
 
+		case "plan":
+			steps := plan.Record(event.Content)
+			e.logger.Info("Agent emitted plan", zap.Int("steps", len(steps)))
+			if callback != nil && len(steps) > 0 {
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
+					"type":    "plan",
+					"details": map[string]interface{}{"steps": steps},
+				})
+			}
+
+		case "step_started":
+			plan.Update(event.Details, models.TaskStatusRunning, "")
+			if callback != nil {
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
+					"type":    "step_started",
+					"details": event.Details,
+				})
+			}
+
+		case "step_completed":
+			plan.Update(event.Details, models.TaskStatusCompleted, event.Content)
+			if callback != nil {
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
+					"type":    "step_completed",
+					"details": event.Details,
+				})
+			}
+
+		case "step_failed":
+			plan.Update(event.Details, models.TaskStatusFailed, "")
+			if callback != nil {
+				callback(taskID, progress, models.TaskStatusRunning, event.Content, map[string]interface{}{
+					"type":    "step_failed",
+					"details": event.Details,
+				})
+			}
+
 		case "warning":
 			e.logger.Warn("Agno script warning", zap.String("content", event.Content))
 
@@ -618,7 +1106,7 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 
 		case "cancelled":
 			e.logger.Info("Agno execution cancelled", zap.String("content", event.Content))
-			return fullContent.String(), strings.Join(eventLogs, "\n"), nil
+			return fullContent.String(), strings.Join(eventLogs, "\n"), lastError, true
 
 		case "error":
 			lastError = event.Content
@@ -626,12 +1114,5 @@ func (e *AgnoExecutor) runAgnoScript(ctx context.Context, config AgnoConfig, tas
 		}
 	}
 
-	if err := cmd.Wait(); err != nil {
-		if lastError != "" {
-			return "", "", fmt.Errorf("python script error: %s", lastError)
-		}
-		return "", "", fmt.Errorf("python script finished with error: %w", err)
-	}
-
-	return fullContent.String(), strings.Join(eventLogs, "\n"), nil
+	return fullContent.String(), strings.Join(eventLogs, "\n"), lastError, false
 }
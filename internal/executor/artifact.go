@@ -0,0 +1,154 @@
+package executor
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// artifactStore copies files the agent registers as task outputs into the
+// blob store and records them as Artifact rows, so results survive the
+// execution environment being torn down
+type artifactStore struct {
+	storage *storage.Storage
+	blobs   attachment.BlobStore
+	logger  *zap.Logger
+}
+
+// ConfigureArtifacts enables artifact registration against the given blob
+// storage backend. Without it, artifact events from the bridge are logged
+// and dropped.
+func (e *AgnoExecutor) ConfigureArtifacts(cfg attachment.Config) {
+	blobs, err := attachment.NewBlobStore(cfg)
+	if err != nil {
+		e.logger.Warn("Failed to initialize artifact storage, artifacts disabled", zap.Error(err))
+		return
+	}
+	e.artifacts = &artifactStore{
+		storage: e.storage,
+		blobs:   blobs,
+		logger:  e.logger,
+	}
+}
+
+// register copies one output file into the blob store and records it. The
+// path must be readable from this process, so remote environments need the
+// runner to ship the file back before reporting it. A non-empty warning
+// means the registration fit but pushed the workspace near its storage
+// quota.
+func (s *artifactStore) register(taskID uint, path, name string) (*models.Artifact, string, error) {
+	if path == "" {
+		return nil, "", fmt.Errorf("artifact event without a path")
+	}
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("artifact file not readable: %w", err)
+	}
+	if info.IsDir() {
+		return nil, "", fmt.Errorf("artifact path %s is a directory", path)
+	}
+	if info.Size() > models.MaxArtifactSize {
+		return nil, "", fmt.Errorf("artifact %s exceeds the %d byte limit", name, models.MaxArtifactSize)
+	}
+
+	var warning string
+	if task, err := s.storage.Tasks().GetByID(taskID); err == nil {
+		warning, err = s.storage.CheckWorkspaceStorageQuota(task.WorkspaceID, info.Size())
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open artifact: %w", err)
+	}
+	defer file.Close()
+
+	ext := filepath.Ext(name)
+	mimeType := mime.TypeByExtension(ext)
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	key := uuid.New().String() + ext
+	if err := s.blobs.Save(key, file, info.Size(), mimeType); err != nil {
+		return nil, "", fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	artifact := &models.Artifact{
+		TaskID:      taskID,
+		Name:        name,
+		Path:        path,
+		FileSize:    info.Size(),
+		MimeType:    mimeType,
+		StoragePath: key,
+	}
+	if err := s.storage.Artifacts().Create(artifact); err != nil {
+		s.blobs.Delete(key)
+		return nil, "", fmt.Errorf("failed to record artifact: %w", err)
+	}
+
+	return artifact, warning, nil
+}
+
+// registerArtifact handles one artifact bridge event and emits the result to
+// the progress callback so clients see registered outputs in the stream
+func (e *AgnoExecutor) registerArtifact(taskID uint, details map[string]interface{}, callback ProgressCallback) {
+	path, _ := details["path"].(string)
+	name, _ := details["name"].(string)
+
+	if e.artifacts == nil {
+		e.logger.Warn("Artifact event without configured artifact storage",
+			zap.Uint("task_id", taskID),
+			zap.String("path", path))
+		return
+	}
+
+	artifact, warning, err := e.artifacts.register(taskID, path, name)
+	if err != nil {
+		e.logger.Warn("Failed to register artifact",
+			zap.Uint("task_id", taskID),
+			zap.String("path", path),
+			zap.Error(err))
+		return
+	}
+
+	if warning != "" && callback != nil {
+		callback(taskID, 80, models.TaskStatusRunning, warning, map[string]interface{}{
+			"type": "quota_warning",
+			"details": map[string]interface{}{
+				"usage": warning,
+			},
+		})
+	}
+
+	e.logger.Info("Registered task artifact",
+		zap.Uint("task_id", taskID),
+		zap.Uint("artifact_id", artifact.ID),
+		zap.String("name", artifact.Name))
+
+	if callback != nil {
+		callback(taskID, 80, models.TaskStatusRunning,
+			fmt.Sprintf("Registered artifact %s", artifact.Name), map[string]interface{}{
+				"type": "artifact_registered",
+				"details": map[string]interface{}{
+					"artifact_id": artifact.ID,
+					"name":        artifact.Name,
+					"size":        artifact.FileSize,
+					"mime_type":   artifact.MimeType,
+				},
+			})
+	}
+}
@@ -0,0 +1,155 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+const (
+	// maxAttachmentChars caps the text injected per attachment, and
+	// maxAttachmentTotal caps the whole context section, so large documents
+	// don't blow the model's token budget
+	maxAttachmentChars = 12000
+	maxAttachmentTotal = 32000
+)
+
+// attachmentContext loads the attachments linked to a task and renders their
+// extracted text as a context section to append to the prompt. Documents too
+// large to inline are replaced with their indexed passages most relevant to
+// the prompt when an embedder is configured; otherwise they are truncated. An
+// attachment_loaded event is emitted per document so clients can show what
+// the agent received.
+func attachmentContext(store *storage.Storage, embedder llm.Embeddings, task *models.Task, callback ProgressCallback) string {
+	attachments, err := store.Attachments().ListByTask(task.ID)
+	if err != nil || len(attachments) == 0 {
+		return ""
+	}
+
+	attachments = waitForAttachments(store, task, attachments, callback)
+
+	passages := retrieveAttachmentPassages(store, embedder, task, attachments)
+
+	var b strings.Builder
+	total := 0
+
+	for _, att := range attachments {
+		if att.Status != models.AttachmentStatusCompleted || att.TextContent == "" {
+			continue
+		}
+
+		if chunks := passages[att.ID]; len(chunks) > 0 {
+			var section strings.Builder
+			section.WriteString(fmt.Sprintf("\n\n## Relevant passages from %s", att.Filename))
+			for _, chunk := range chunks {
+				section.WriteString("\n\n" + chunk.Content)
+			}
+			if total+section.Len() > maxAttachmentTotal {
+				break
+			}
+			total += section.Len()
+			b.WriteString(section.String())
+
+			if callback != nil {
+				callback(task.ID, task.Progress, models.TaskStatusRunning,
+					fmt.Sprintf("Loaded attachment %s", att.Filename), map[string]interface{}{
+						"type": "attachment_loaded",
+						"details": map[string]interface{}{
+							"attachment_id": att.ID,
+							"filename":      att.Filename,
+							"chars":         section.Len(),
+							"passages":      len(chunks),
+						},
+					})
+			}
+			continue
+		}
+
+		text := att.TextContent
+		truncated := false
+		if len(text) > maxAttachmentChars {
+			text = text[:maxAttachmentChars]
+			truncated = true
+		}
+		if total+len(text) > maxAttachmentTotal {
+			remaining := maxAttachmentTotal - total
+			if remaining <= 0 {
+				break
+			}
+			text = text[:remaining]
+			truncated = true
+		}
+		total += len(text)
+
+		b.WriteString(fmt.Sprintf("\n\n## Attached document: %s\n%s", att.Filename, text))
+		if truncated {
+			b.WriteString("\n[content truncated]")
+		}
+
+		if callback != nil {
+			callback(task.ID, task.Progress, models.TaskStatusRunning,
+				fmt.Sprintf("Loaded attachment %s", att.Filename), map[string]interface{}{
+					"type": "attachment_loaded",
+					"details": map[string]interface{}{
+						"attachment_id": att.ID,
+						"filename":      att.Filename,
+						"chars":         len(text),
+						"truncated":     truncated,
+					},
+				})
+		}
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n\n# Attached context" + b.String()
+}
+
+const (
+	// attachmentWaitTimeout bounds how long execution is delayed for text
+	// extraction of freshly uploaded attachments
+	attachmentWaitTimeout = 2 * time.Minute
+	attachmentPollEvery   = 2 * time.Second
+)
+
+// waitForAttachments blocks until the task's attachments have finished
+// processing (or the wait times out), so input documents uploaded right
+// before task creation are not silently skipped. It returns the refreshed
+// attachment rows.
+func waitForAttachments(store *storage.Storage, task *models.Task, attachments []*models.Attachment, callback ProgressCallback) []*models.Attachment {
+	deadline := time.Now().Add(attachmentWaitTimeout)
+
+	for {
+		pending := 0
+		for _, att := range attachments {
+			if att.Status == models.AttachmentStatusPending || att.Status == models.AttachmentStatusProcessing {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return attachments
+		}
+
+		if time.Now().After(deadline) {
+			if callback != nil {
+				callback(task.ID, task.Progress, models.TaskStatusRunning,
+					fmt.Sprintf("Timed out waiting for %d attachment(s) to finish processing", pending),
+					map[string]interface{}{"type": "warning"})
+			}
+			return attachments
+		}
+
+		time.Sleep(attachmentPollEvery)
+
+		refreshed, err := store.Attachments().ListByTask(task.ID)
+		if err != nil {
+			return attachments
+		}
+		attachments = refreshed
+	}
+}
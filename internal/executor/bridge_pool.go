@@ -0,0 +1,282 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultBridgePoolSize is how many daemons run without XGENT_BRIDGE_POOL
+	defaultBridgePoolSize = 2
+
+	// bridgePingTimeout bounds the health check before a daemon is reused
+	bridgePingTimeout = 2 * time.Second
+)
+
+// bridgePool keeps warm runner daemons around so plain local executions skip
+// interpreter startup and keep their MCP connections. Each daemon serves one
+// request at a time over line-delimited JSON-RPC on stdio; the pool provides
+// multiplexing by running several daemons, health-checks daemons before
+// reuse and replaces those that crashed.
+type bridgePool struct {
+	command []string
+	logger  *zap.Logger
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	idle   []*bridgeDaemon
+	size   int // daemons alive or being spawned
+	max    int
+	closed bool
+}
+
+// newBridgePool creates a pool running at most max daemons started from
+// command; daemons are spawned on demand
+func newBridgePool(command []string, max int, logger *zap.Logger) *bridgePool {
+	p := &bridgePool{
+		command: command,
+		logger:  logger,
+		max:     max,
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// acquire returns a healthy daemon, spawning or replacing one as needed,
+// and blocks when all daemons are busy
+func (p *bridgePool) acquire(ctx context.Context) (*bridgeDaemon, error) {
+	// Wake waiters when the caller gives up
+	stop := context.AfterFunc(ctx, func() { p.cond.Broadcast() })
+	defer stop()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		if p.closed {
+			return nil, fmt.Errorf("bridge pool is closed")
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if n := len(p.idle); n > 0 {
+			daemon := p.idle[n-1]
+			p.idle = p.idle[:n-1]
+
+			if daemon.healthy() {
+				return daemon, nil
+			}
+			// Crashed while idle; drop it and let the loop spawn a fresh one
+			p.logger.Warn("Replacing unhealthy bridge daemon")
+			daemon.kill()
+			p.size--
+			continue
+		}
+
+		if p.size < p.max {
+			p.size++
+			p.mu.Unlock()
+			daemon, err := p.spawn()
+			p.mu.Lock()
+			if err != nil {
+				p.size--
+				p.cond.Signal()
+				return nil, err
+			}
+			return daemon, nil
+		}
+
+		p.cond.Wait()
+	}
+}
+
+// release returns a daemon to the pool; broken daemons are killed and their
+// slot freed for a replacement
+func (p *bridgePool) release(daemon *bridgeDaemon, broken bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if broken || p.closed {
+		daemon.kill()
+		p.size--
+	} else {
+		p.idle = append(p.idle, daemon)
+	}
+	p.cond.Signal()
+}
+
+// close kills the idle daemons; busy ones die when released
+func (p *bridgePool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for _, daemon := range p.idle {
+		daemon.kill()
+		p.size--
+	}
+	p.idle = nil
+	p.cond.Broadcast()
+}
+
+// spawn starts one daemon process and its stdout reader
+func (p *bridgePool) spawn() (*bridgeDaemon, error) {
+	cmd := exec.Command(p.command[0], p.command[1:]...)
+	cmd.Env = os.Environ()
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start bridge daemon: %w", err)
+	}
+	p.logger.Info("Started bridge daemon", zap.Int("pid", cmd.Process.Pid))
+
+	daemon := &bridgeDaemon{
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan string, 64),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			p.logger.Error("Bridge daemon stderr", zap.String("line", scanner.Text()))
+		}
+	}()
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			daemon.lines <- scanner.Text()
+		}
+		close(daemon.lines)
+		cmd.Wait()
+	}()
+
+	return daemon, nil
+}
+
+// bridgeDaemon is one persistent runner process. Calls are serialized: the
+// pool hands a daemon to one execution at a time.
+type bridgeDaemon struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	lines  chan string
+	nextID int
+}
+
+// rpcResponse is the JSON-RPC response frame the daemon emits when a call
+// finishes; event lines carry no jsonrpc field and pass through untouched
+type rpcResponse struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// send writes one JSON-RPC request and returns its id
+func (d *bridgeDaemon) send(method string, params interface{}) (int, error) {
+	d.nextID++
+	request := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      d.nextID,
+		"method":  method,
+	}
+	if params != nil {
+		request["params"] = params
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := d.stdin.Write(append(data, '\n')); err != nil {
+		return 0, err
+	}
+	return d.nextID, nil
+}
+
+// healthy pings the daemon and waits briefly for the pong
+func (d *bridgeDaemon) healthy() bool {
+	id, err := d.send("ping", nil)
+	if err != nil {
+		return false
+	}
+
+	deadline := time.After(bridgePingTimeout)
+	for {
+		select {
+		case line, ok := <-d.lines:
+			if !ok {
+				return false
+			}
+			var resp rpcResponse
+			if json.Unmarshal([]byte(line), &resp) == nil && resp.JSONRPC != "" && resp.ID == id {
+				return resp.Error == nil
+			}
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// run executes one task through the daemon, forwarding event lines to sink
+// until the call's response arrives. Cancellation kills the daemon: a run
+// in flight cannot be separated from its process.
+func (d *bridgeDaemon) run(ctx context.Context, config AgnoConfig, sink io.Writer) error {
+	id, err := d.send("run", config)
+	if err != nil {
+		return fmt.Errorf("failed to send run request: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.kill()
+			return ctx.Err()
+		case line, ok := <-d.lines:
+			if !ok {
+				return fmt.Errorf("bridge daemon exited mid-run")
+			}
+			var resp rpcResponse
+			if json.Unmarshal([]byte(line), &resp) == nil && resp.JSONRPC != "" && resp.ID == id {
+				if resp.Error != nil {
+					return fmt.Errorf("bridge daemon error: %s", resp.Error.Message)
+				}
+				return nil
+			}
+			fmt.Fprintln(sink, line)
+		}
+	}
+}
+
+// kill terminates the daemon process
+func (d *bridgeDaemon) kill() {
+	d.stdin.Close()
+	if d.cmd.Process != nil {
+		d.cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// resourceTypeForTask maps a task's resource type string to the stored
+// resource type
+func resourceTypeForTask(taskType string) models.ResourceType {
+	switch taskType {
+	case "bot", "robot":
+		return models.ResourceTypeRobot
+	case "team":
+		return models.ResourceTypeTeam
+	case "collaboration":
+		return models.ResourceTypeCollaboration
+	default:
+		return ""
+	}
+}
+
+// cacheKey hashes everything that determines a task's output: the prompt and
+// its parameters, the execution target and its current spec revision, and the
+// git inputs. Two tasks with the same key would run identically.
+func (e *AgnoExecutor) cacheKey(task *models.Task) string {
+	parts := []string{
+		task.ResourceType,
+		task.ResourceName,
+		task.Prompt,
+		task.Parameters,
+		task.Mode,
+		task.Environment,
+		task.GitURL,
+		task.BranchName,
+	}
+
+	// Include the target resource's spec and revision so edits to the
+	// robot/team (or its canary state) invalidate earlier results
+	if resourceType := resourceTypeForTask(task.ResourceType); resourceType != "" {
+		if resource, err := resolveResource(e.storage, task.WorkspaceID, task.ResourceName, resourceType); err == nil {
+			parts = append(parts, resource.Spec, resource.UpdatedAt.UTC().Format(time.RFC3339Nano))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// lookupCache returns an earlier completed task whose inputs hashed to the
+// same cache key, or nil when there is nothing to reuse
+func (e *AgnoExecutor) lookupCache(task *models.Task) *models.Task {
+	if task.CacheKey == "" {
+		return nil
+	}
+	cached, err := e.storage.Tasks().FindCachedResult(task.WorkspaceID, task.CacheKey, task.ID)
+	if err != nil {
+		return nil
+	}
+	return cached
+}
+
+// completeFromCache finishes a task with another run's result, flagged as
+// cached so clients can tell it apart from a fresh execution
+func (e *AgnoExecutor) completeFromCache(task, cached *models.Task, callback ProgressCallback) error {
+	now := time.Now()
+	task.Status = models.TaskStatusCompleted
+	task.Result = cached.Result
+	task.CachedFromID = &cached.ID
+	task.Progress = 100
+	task.StartedAt = &now
+	task.CompletedAt = &now
+	task.Duration = 0
+
+	if err := e.storage.Tasks().Update(task); err != nil {
+		return fmt.Errorf("failed to update task status: %w", err)
+	}
+
+	e.logger.Info("Serving task result from cache",
+		zap.Uint("task_id", task.ID),
+		zap.Uint("cached_from", cached.ID))
+
+	if callback != nil {
+		callback(task.ID, 100, models.TaskStatusCompleted,
+			fmt.Sprintf("Result reused from task %d (identical inputs)", cached.ID),
+			map[string]interface{}{
+				"result": cached.Result,
+				"type":   "cached_result",
+				"details": map[string]interface{}{
+					"cached_from": cached.ID,
+				},
+			})
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package executor
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// specForExecution returns the spec revision that should serve this
+// execution. While a canary rollout is active the previous revision keeps
+// serving CanaryPercent of new tasks so failure rates can be compared
+// before full cutover.
+func specForExecution(resource *models.Resource) []byte {
+	if resource.CanaryPercent > 0 && resource.PreviousSpec != "" &&
+		resource.CanaryExpiresAt != nil && time.Now().Before(*resource.CanaryExpiresAt) {
+		if rand.Intn(100) < resource.CanaryPercent {
+			return []byte(resource.PreviousSpec)
+		}
+	}
+	return []byte(resource.Spec)
+}
@@ -0,0 +1,307 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// stepOutcome records a finished collaboration step
+type stepOutcome struct {
+	status models.TaskStatus
+	result string
+}
+
+// executeCollaboration runs a Collaboration resource as a DAG of steps. A
+// SubTask row is created per step, independent steps run concurrently, and
+// each step's prompt carries the outputs of the steps it depends on. Steps
+// whose dependencies failed (or whose condition is false) are cancelled.
+func (e *AgnoExecutor) executeCollaboration(ctx context.Context, task *models.Task, callback ProgressCallback) (string, string, error) {
+	collabResource, err := resolveResource(e.storage, task.WorkspaceID, task.ResourceName, models.ResourceTypeCollaboration)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load collaboration: %w", err)
+	}
+
+	resource, err := parseResolved(collabResource, resolveSpec(task, collabResource))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse collaboration spec: %w", err)
+	}
+
+	collab, ok := resource.(*crd.Collaboration)
+	if !ok {
+		return "", "", fmt.Errorf("invalid collaboration resource")
+	}
+
+	steps, err := normalizeSteps(collab)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Create one subtask per step up front so clients see the full plan
+	subtasks := make(map[string]*models.SubTask, len(steps))
+	for _, step := range steps {
+		subtask := &models.SubTask{
+			TaskID:  task.ID,
+			Status:  models.TaskStatusPending,
+			Title:   step.Name,
+			AgentID: step.Agent,
+		}
+		if err := e.storage.Tasks().CreateSubTask(subtask); err != nil {
+			return "", "", fmt.Errorf("failed to create subtask for step %s: %w", step.Name, err)
+		}
+		subtasks[step.Name] = subtask
+	}
+
+	var (
+		mu       sync.Mutex
+		outcomes = make(map[string]stepOutcome, len(steps))
+		logs     []string
+	)
+
+	basePrompt := RenderPrompt(task.Prompt, task, e.storage) + attachmentContext(e.storage, e.embedder, task, callback)
+
+	for len(outcomes) < len(steps) {
+		ready, skipped := schedulable(steps, collab, outcomes)
+
+		// Cancel steps whose dependencies failed or condition is false
+		for _, step := range skipped {
+			outcomes[step.Name] = stepOutcome{status: models.TaskStatusCancelled}
+			subtask := subtasks[step.Name]
+			subtask.Status = models.TaskStatusCancelled
+			e.storage.Tasks().UpdateSubTask(subtask)
+		}
+
+		if len(ready) == 0 {
+			if len(skipped) == 0 {
+				return "", "", fmt.Errorf("collaboration %s has a dependency cycle", collab.Metadata.Name)
+			}
+			continue
+		}
+
+		// Run all ready steps concurrently
+		var wg sync.WaitGroup
+		for _, step := range ready {
+			wg.Add(1)
+			go func(step crd.CollaborationStep) {
+				defer wg.Done()
+
+				subtask := subtasks[step.Name]
+				subtask.Status = models.TaskStatusRunning
+				e.storage.Tasks().UpdateSubTask(subtask)
+
+				if callback != nil {
+					callback(task.ID, taskRollup(e.storage, task.ID, 10), models.TaskStatusRunning,
+						fmt.Sprintf("Running step %s (%s)", step.Name, step.Agent), map[string]interface{}{
+							"type": "step_started",
+						})
+				}
+
+				mu.Lock()
+				prompt := stepPrompt(basePrompt, step, outcomes)
+				mu.Unlock()
+
+				result, eventLog, stepErr := e.runStep(ctx, task, step, prompt, callback)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if eventLog != "" {
+					logs = append(logs, eventLog)
+				}
+
+				eventType := "step_completed"
+				if stepErr != nil {
+					outcomes[step.Name] = stepOutcome{status: models.TaskStatusFailed}
+					subtask.Status = models.TaskStatusFailed
+					subtask.Error = stepErr.Error()
+					eventType = "step_failed"
+				} else {
+					outcomes[step.Name] = stepOutcome{status: models.TaskStatusCompleted, result: result}
+					subtask.Status = models.TaskStatusCompleted
+					subtask.Result = result
+					subtask.Progress = 100
+				}
+				e.storage.Tasks().UpdateSubTask(subtask)
+
+				if callback != nil {
+					callback(task.ID, taskRollup(e.storage, task.ID, 10), models.TaskStatusRunning,
+						fmt.Sprintf("Step %s finished", step.Name), map[string]interface{}{
+							"type": eventType,
+						})
+				}
+			}(step)
+		}
+		wg.Wait()
+	}
+
+	var failed []string
+	for name, outcome := range outcomes {
+		if outcome.status == models.TaskStatusFailed {
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return "", strings.Join(logs, "\n"), fmt.Errorf("collaboration steps failed: %s", strings.Join(failed, ", "))
+	}
+
+	return finalResult(steps, outcomes), strings.Join(logs, "\n"), nil
+}
+
+// runStep executes a single collaboration step through its agent robot
+func (e *AgnoExecutor) runStep(ctx context.Context, task *models.Task, step crd.CollaborationStep, prompt string, callback ProgressCallback) (string, string, error) {
+	config, err := e.buildRobotConfig(task, step.Agent, prompt)
+	if err != nil {
+		return "", "", err
+	}
+	// Give each step its own session so agents don't share history
+	config.SessionID = fmt.Sprintf("task-%d-step-%s", task.ID, step.Name)
+
+	return e.runAgnoScript(ctx, *config, task.ID, callback)
+}
+
+// normalizeSteps validates the step graph and, for sequential
+// collaborations, chains steps without explicit dependencies in declared
+// order
+func normalizeSteps(collab *crd.Collaboration) ([]crd.CollaborationStep, error) {
+	steps := make([]crd.CollaborationStep, len(collab.Spec.Steps))
+	copy(steps, collab.Spec.Steps)
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("collaboration %s has no steps", collab.Metadata.Name)
+	}
+
+	names := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		if step.Name == "" || step.Agent == "" {
+			return nil, fmt.Errorf("collaboration %s has a step without name or agent", collab.Metadata.Name)
+		}
+		if names[step.Name] {
+			return nil, fmt.Errorf("collaboration %s has duplicate step %s", collab.Metadata.Name, step.Name)
+		}
+		names[step.Name] = true
+	}
+
+	for i := range steps {
+		for _, dep := range steps[i].DependsOn {
+			if !names[dep] {
+				return nil, fmt.Errorf("step %s depends on unknown step %s", steps[i].Name, dep)
+			}
+		}
+		if collab.Spec.Type == "sequential" && len(steps[i].DependsOn) == 0 && i > 0 {
+			steps[i].DependsOn = []string{steps[i-1].Name}
+		}
+	}
+
+	return steps, nil
+}
+
+// schedulable splits unfinished steps into those ready to run (all
+// dependencies completed) and those to skip (failed/cancelled dependency or
+// false condition)
+func schedulable(steps []crd.CollaborationStep, collab *crd.Collaboration, outcomes map[string]stepOutcome) (ready, skipped []crd.CollaborationStep) {
+	for _, step := range steps {
+		if _, finished := outcomes[step.Name]; finished {
+			continue
+		}
+
+		blocked := false
+		failed := false
+		for _, dep := range step.DependsOn {
+			outcome, ok := outcomes[dep]
+			if !ok {
+				blocked = true
+				break
+			}
+			if outcome.status != models.TaskStatusCompleted {
+				failed = true
+			}
+		}
+
+		switch {
+		case blocked:
+		case failed:
+			skipped = append(skipped, step)
+		case !evalStepCondition(step.Condition, collab, outcomes):
+			skipped = append(skipped, step)
+		default:
+			ready = append(ready, step)
+		}
+	}
+	return ready, skipped
+}
+
+// evalStepCondition evaluates a step condition. A bare name looks up a
+// boolean in the collaboration's conditions map; "step.completed" and
+// "step.failed" test an earlier step's outcome; a leading "!" negates. Empty
+// or unknown conditions default to true.
+func evalStepCondition(condition string, collab *crd.Collaboration, outcomes map[string]stepOutcome) bool {
+	condition = strings.TrimSpace(condition)
+	if condition == "" {
+		return true
+	}
+
+	if negated := strings.HasPrefix(condition, "!"); negated {
+		return !evalStepCondition(condition[1:], collab, outcomes)
+	}
+
+	if name, verb, found := strings.Cut(condition, "."); found {
+		outcome, ok := outcomes[name]
+		if !ok {
+			return true
+		}
+		switch verb {
+		case "completed", "success":
+			return outcome.status == models.TaskStatusCompleted
+		case "failed":
+			return outcome.status == models.TaskStatusFailed
+		}
+		return true
+	}
+
+	if value, ok := collab.Spec.Conditions[condition]; ok {
+		if b, isBool := value.(bool); isBool {
+			return b
+		}
+	}
+	return true
+}
+
+// stepPrompt builds a step's prompt from the task prompt plus the outputs of
+// the steps it depends on
+func stepPrompt(basePrompt string, step crd.CollaborationStep, outcomes map[string]stepOutcome) string {
+	var b strings.Builder
+	b.WriteString(basePrompt)
+
+	for _, dep := range step.DependsOn {
+		if outcome, ok := outcomes[dep]; ok && outcome.result != "" {
+			b.WriteString(fmt.Sprintf("\n\n## Output from step %q\n%s", dep, outcome.result))
+		}
+	}
+	return b.String()
+}
+
+// finalResult joins the outputs of terminal steps (those no other step
+// depends on), preserving declared order
+func finalResult(steps []crd.CollaborationStep, outcomes map[string]stepOutcome) string {
+	dependedOn := make(map[string]bool)
+	for _, step := range steps {
+		for _, dep := range step.DependsOn {
+			dependedOn[dep] = true
+		}
+	}
+
+	var parts []string
+	for _, step := range steps {
+		if dependedOn[step.Name] {
+			continue
+		}
+		if outcome, ok := outcomes[step.Name]; ok && outcome.result != "" {
+			parts = append(parts, outcome.result)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,89 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"go.uber.org/zap"
+)
+
+const (
+	// compactThresholdTokens is how much un-summarized history a session
+	// may accumulate before compaction folds the older turns into the
+	// running summary
+	compactThresholdTokens = 4000
+
+	// compactKeepTurns is how many of the latest messages stay verbatim
+	// after a compaction pass
+	compactKeepTurns = 10
+
+	// compactTimeout bounds one background compaction pass
+	compactTimeout = 2 * time.Minute
+)
+
+// CompactSession folds a session's older un-summarized messages into its
+// running summary, leaving the latest turns verbatim. Sessions under the
+// threshold are left alone, so calling it after every turn is cheap.
+func (e *Executor) CompactSession(ctx context.Context, client llm.Client, sessionID string) error {
+	session, err := e.storage.Sessions().GetBySessionID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	messages, err := e.storage.Sessions().GetMessagesAfter(sessionID, session.SummarizedUpTo, 0)
+	if err != nil {
+		return err
+	}
+	if len(messages) <= compactKeepTurns {
+		return nil
+	}
+
+	total := 0
+	for _, message := range messages {
+		total += estimateTokens(message.Content)
+	}
+	if total <= compactThresholdTokens {
+		return nil
+	}
+
+	older := messages[:len(messages)-compactKeepTurns]
+
+	var transcript strings.Builder
+	if session.Summary != "" {
+		fmt.Fprintf(&transcript, "Running summary so far:\n%s\n\nNew conversation turns:\n", session.Summary)
+	}
+	for _, message := range older {
+		fmt.Fprintf(&transcript, "%s: %s\n", message.Role, message.Content)
+	}
+
+	resp, err := client.Chat(ctx, []llm.Message{
+		{Role: "system", Content: "Update the running summary of this conversation. Merge the new turns into the existing summary, keeping decisions, facts and open questions; drop pleasantries. Reply with the updated summary only."},
+		{Role: "user", Content: transcript.String()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to summarize session %s: %w", sessionID, err)
+	}
+
+	session.Summary = resp.Content
+	session.SummarizedUpTo = older[len(older)-1].ID
+	return e.storage.Sessions().Update(session)
+}
+
+// CompactSessionAsync runs CompactSession in the background with its own
+// timeout; a failed pass is logged and retried naturally on the next turn
+func (e *Executor) CompactSessionAsync(client llm.Client, sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), compactTimeout)
+		defer cancel()
+		if err := e.CompactSession(ctx, client, sessionID); err != nil {
+			e.logger.Warn("Session compaction failed",
+				zap.String("session_id", sessionID), zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// resolveEnvironment resolves the execution environment for a task. A
+// task-level environment overrides the robot's, which overrides the
+// workspace's default; an empty name everywhere means the local host and
+// returns nil.
+func (e *AgnoExecutor) resolveEnvironment(task *models.Task, robotEnvName string) (*crd.EnvironmentSpec, error) {
+	envName := task.Environment
+	if envName == "" {
+		envName = robotEnvName
+	}
+	if envName == "" {
+		envName = workspaceDefaults(e.storage, task.WorkspaceID).DefaultEnvironment
+	}
+	if envName == "" {
+		return nil, nil
+	}
+
+	envResource, err := resolveResource(e.storage, task.WorkspaceID, envName, models.ResourceTypeEnvironment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load environment %s: %w", envName, err)
+	}
+
+	resource, err := parseResolved(envResource, resolveSpec(task, envResource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse environment spec: %w", err)
+	}
+
+	env, ok := resource.(*crd.Environment)
+	if !ok {
+		return nil, fmt.Errorf("invalid environment resource")
+	}
+
+	return &env.Spec, nil
+}
+
+// buildRunnerCommand builds the bridge process command for an execution
+// environment. The runner slice is the declared override (command and
+// arguments, speaking the JSON event protocol over stdio); empty means the
+// bundled Python runner. Docker environments run the command inside the
+// configured image with the scripts directory mounted. Remote environments
+// are dispatched to a runner agent, not spawned here.
+func buildRunnerCommand(ctx context.Context, scriptPath string, runner []string, env *crd.EnvironmentSpec) (*exec.Cmd, error) {
+	if env == nil || env.Type == crd.EnvironmentTypeLocal {
+		if len(runner) > 0 {
+			return exec.CommandContext(ctx, runner[0], runner[1:]...), nil
+		}
+		return exec.CommandContext(ctx, "python3", scriptPath), nil
+	}
+
+	switch env.Type {
+	case crd.EnvironmentTypeDocker:
+		args := []string{
+			"run", "--rm", "-i",
+			"-v", fmt.Sprintf("%s:/xgent/scripts:ro", filepath.Dir(scriptPath)),
+		}
+		if env.WorkingDir != "" {
+			args = append(args, "-w", env.WorkingDir)
+		}
+		for key, value := range env.Env {
+			args = append(args, "-e", fmt.Sprintf("%s=%s", key, value))
+		}
+		args = append(args, env.Image)
+		if len(runner) > 0 {
+			args = append(args, runner...)
+		} else {
+			args = append(args, "python3", "/xgent/scripts/"+filepath.Base(scriptPath))
+		}
+		return exec.CommandContext(ctx, "docker", args...), nil
+	case crd.EnvironmentTypeRemote:
+		return nil, fmt.Errorf("remote environment %s requires a runner agent", env.RunnerURL)
+	default:
+		return nil, fmt.Errorf("unknown environment type: %s", env.Type)
+	}
+}
@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+)
+
+// Progress bands the estimate moves through: setup milestones fill the
+// first, work signals fill the second, and the remainder is reserved for
+// result persistence after the run completes.
+const (
+	setupBand = 15
+	workBand  = 75 // setupBand+workBand = 90, reported at run completion
+)
+
+// expectedStreamTokens anchors the asymptotic curve used when neither a
+// plan nor a token budget bounds the run: streaming this many tokens reads
+// as half the work band.
+const expectedStreamTokens = 1500
+
+// progressEstimator derives a task's progress percentage from structured
+// milestones — setup events seen, plan steps finished against planned,
+// tool calls completed, tokens streamed against the task budget — instead
+// of a fixed number per event type.
+type progressEstimator struct {
+	mu sync.Mutex
+
+	setup         map[string]bool
+	plannedSteps  int
+	finishedSteps int
+	toolsFinished int
+	streamedChars int
+	maxTokens     int
+	done          bool
+}
+
+// newProgressEstimator creates an estimator for a task, bounding the
+// streamed-token signal by the task's token budget when one is set
+func newProgressEstimator(store *storage.Storage, taskID uint) *progressEstimator {
+	est := &progressEstimator{setup: make(map[string]bool)}
+	if store != nil {
+		if task, err := store.Tasks().GetByID(taskID); err == nil {
+			est.maxTokens = task.MaxTokens
+		}
+	}
+	return est
+}
+
+// observe folds one bridge event into the estimate and returns the
+// percentage to report for it
+func (p *progressEstimator) observe(eventType, content string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch eventType {
+	case "started", "mcp_connected", "git_downloaded", "git_workspace_ready", "session_reused":
+		p.setup[eventType] = true
+	case "content":
+		p.streamedChars += len(content)
+	case "plan":
+		// A re-planned run starts its step count over
+		p.plannedSteps = len(parsePlanSteps(content))
+		p.finishedSteps = 0
+	case "step_completed", "step_failed":
+		p.finishedSteps++
+	case "tool_call_completed", "member_tool_completed":
+		p.toolsFinished++
+	case "run_completed", "team_run_completed", "completed":
+		p.done = true
+	}
+
+	return p.estimate()
+}
+
+// estimate maps the milestones seen so far onto a percentage
+func (p *progressEstimator) estimate() int {
+	if p.done {
+		return setupBand + workBand
+	}
+
+	// Each distinct setup milestone fills part of the setup band
+	progress := len(p.setup) * 5
+	if progress > setupBand {
+		progress = setupBand
+	}
+
+	// The strongest available work signal fills the work band: a plan gives
+	// exact step counts; without one, streamed tokens are measured against
+	// the task budget or approach the band's end asymptotically, with
+	// completed tool calls as a second signal
+	var work float64
+	if p.plannedSteps > 0 {
+		work = float64(p.finishedSteps) / float64(p.plannedSteps)
+	} else {
+		streamed := float64(p.streamedChars) / 4 // ~4 chars per token
+		if p.maxTokens > 0 {
+			work = streamed / float64(p.maxTokens)
+		} else {
+			work = streamed / (streamed + expectedStreamTokens)
+		}
+		if tools := float64(p.toolsFinished); tools > 0 {
+			if toolWork := tools / (tools + 3); toolWork > work {
+				work = toolWork
+			}
+		}
+	}
+	if work > 1 {
+		work = 1
+	}
+
+	return progress + int(work*float64(workBand))
+}
@@ -2,6 +2,8 @@ package executor
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/xcode-ai/xgent-go/internal/llm"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/tools"
 	"go.uber.org/zap"
 )
 
@@ -17,7 +20,12 @@ import (
 type Executor struct {
 	storage    *storage.Storage
 	logger     *zap.Logger
-	llmClients map[string]llm.Client
+	llmClients *llmClientCache
+	llmCache   llm.CacheStore // shared by all cache-enabled minds
+
+	// embedder is set by ConfigureEmbeddings; nil disables semantic
+	// attachment retrieval
+	embedder llm.Embeddings
 }
 
 // New creates a new executor
@@ -25,7 +33,8 @@ func New(storage *storage.Storage, logger *zap.Logger) *Executor {
 	return &Executor{
 		storage:    storage,
 		logger:     logger,
-		llmClients: make(map[string]llm.Client),
+		llmClients: newLLMClientCache(llmClientCacheSize),
+		llmCache:   llm.NewMemoryCache(256),
 	}
 }
 
@@ -40,6 +49,16 @@ func (e *Executor) Execute(ctx context.Context, task *models.Task, callback Prog
 		zap.String("resource_name", task.ResourceName),
 	)
 
+	// Clamp reported progress so it never moves backwards
+	callback = monotonicCallback(callback)
+
+	// Scrub PII and credentials from everything this run persists when the
+	// workspace opted in
+	redact := redactionEnabled(e.storage, task.WorkspaceID)
+	if redact {
+		callback = redactingCallback(callback)
+	}
+
 	// Update task status to running
 	task.Status = models.TaskStatusRunning
 	now := time.Now()
@@ -71,13 +90,23 @@ func (e *Executor) Execute(ctx context.Context, task *models.Task, callback Prog
 	task.CompletedAt = &completed
 	task.Duration = completed.Sub(*task.StartedAt).Milliseconds()
 
+	if redact {
+		result = redactSensitive(result)
+	}
+
 	if err != nil {
 		task.Status = models.TaskStatusFailed
+		if errors.Is(err, errBudgetExceeded) {
+			task.Status = models.TaskStatusBudgetExceeded
+		}
 		task.Error = err.Error()
+		if redact {
+			task.Error = redactSensitive(task.Error)
+		}
 		e.storage.Tasks().Update(task)
 
 		if callback != nil {
-			callback(task.ID, 0, models.TaskStatusFailed, err.Error(), nil)
+			callback(task.ID, task.Progress, task.Status, task.Error, nil)
 		}
 		return err
 	}
@@ -85,6 +114,7 @@ func (e *Executor) Execute(ctx context.Context, task *models.Task, callback Prog
 	task.Status = models.TaskStatusCompleted
 	task.Result = result
 	task.Progress = 100
+	task.TokensUsed = estimateTokens(task.Prompt + result)
 	e.storage.Tasks().Update(task)
 
 	if callback != nil {
@@ -99,14 +129,13 @@ func (e *Executor) Execute(ctx context.Context, task *models.Task, callback Prog
 // executeBot executes a robot task
 func (e *Executor) executeBot(ctx context.Context, task *models.Task, callback ProgressCallback) (string, error) {
 	// Load robot resource
-	robotResource, err := e.storage.Resources().GetByName(task.WorkspaceID, task.ResourceName, models.ResourceTypeRobot)
+	robotResource, err := resolveResource(e.storage, task.WorkspaceID, task.ResourceName, models.ResourceTypeRobot)
 	if err != nil {
 		return "", fmt.Errorf("failed to load robot: %w", err)
 	}
 
 	// Parse robot spec
-	parser := crd.NewParser()
-	resource, err := parser.Parse([]byte(robotResource.Spec))
+	resource, err := parseResolved(robotResource, resolveSpec(task, robotResource))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse robot spec: %w", err)
 	}
@@ -117,82 +146,146 @@ func (e *Executor) executeBot(ctx context.Context, task *models.Task, callback P
 	}
 
 	// Load soul for system prompt
-	soulResource, err := e.storage.Resources().GetByName(task.WorkspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
+	soulResource, err := resolveResource(e.storage, task.WorkspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
 	if err != nil {
 		return "", fmt.Errorf("failed to load soul: %w", err)
 	}
 
-	soulDef, err := parser.Parse([]byte(soulResource.Spec))
+	soulDef, err := parseResolved(soulResource, resolveSpec(task, soulResource))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse soul: %w", err)
 	}
 	soul := soulDef.(*crd.Soul)
 
 	// Load mind configuration
-	mindResource, err := e.storage.Resources().GetByName(task.WorkspaceID, robot.Spec.Mind, models.ResourceTypeMind)
+	mindResource, err := resolveResource(e.storage, task.WorkspaceID,
+		defaultedMind(e.storage, task.WorkspaceID, robot.Spec.Mind), models.ResourceTypeMind)
 	if err != nil {
 		return "", fmt.Errorf("failed to load mind: %w", err)
 	}
 
-	mindDef, err := parser.Parse([]byte(mindResource.Spec))
+	mindDef, err := parseResolved(mindResource, resolveSpec(task, mindResource))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse mind: %w", err)
 	}
 	mind := mindDef.(*crd.Mind)
 
-	// Get or create LLM client
-	client, err := e.getLLMClient(mind)
+	// Get or create LLM client, with the mind's fallback chain if declared
+	client, chain, err := e.mindClient(task, mind, callback)
 	if err != nil {
 		return "", fmt.Errorf("failed to create LLM client: %w", err)
 	}
 
-	// Build messages
-	messages := []llm.Message{
-		{
-			Role:    "system",
-			Content: soul.Spec.Personality,
-		},
-		{
-			Role:    "user",
-			Content: task.Prompt,
-		},
+	// Craft instructions extend the system prompt; the craft environment is
+	// exported to tools run in this process, and builtin tool configs become
+	// callable tools for this execution
+	systemPrompt := soul.Spec.Personality
+	var registry *tools.Registry
+	if robot.Spec.Craft != "" {
+		if craft := e.loadCraft(task, robot.Spec.Craft); craft != nil {
+			if craft.Spec.Instructions != "" {
+				systemPrompt += "\n\n# Tool instructions\n" + craft.Spec.Instructions
+			}
+			for key, value := range craft.Spec.Environment {
+				os.Setenv(key, value)
+			}
+			registry, err = tools.FromCraft(craft.Spec.Tools, task.WorkDir)
+			if err != nil {
+				return "", fmt.Errorf("failed to build tools: %w", err)
+			}
+		}
 	}
 
+	// Build messages; robots with a session_id recall their recent turns
+	// between the system prompt and the task prompt
+	prompt := RenderPrompt(task.Prompt, task, e.storage)
+	history := e.sessionHistory(robot)
+
+	messages := make([]llm.Message, 0, len(history)+2)
+	messages = append(messages, llm.Message{
+		Role:    "system",
+		Content: systemPrompt,
+	})
+	messages = append(messages, history...)
+	messages = append(messages, llm.Message{
+		Role: "user",
+		Content: prompt +
+			attachmentContext(e.storage, e.embedder, task, callback) +
+			knowledgeContext(e.storage, task, robot.Spec.Knowledge, e.logger),
+	})
+
 	// Report progress
 	if callback != nil {
 		callback(task.ID, 30, models.TaskStatusRunning, "Calling LLM...", nil)
 	}
 
-	// Call LLM with streaming
+	// Call LLM with streaming; with builtin tools enabled the conversation
+	// runs through the tool loop instead
 	var fullResponse string
-	err = client.Stream(ctx, messages, func(chunk string) error {
-		fullResponse += chunk
-		if callback != nil {
-			callback(task.ID, 60, models.TaskStatusRunning, chunk, map[string]interface{}{
-				"type": "content",
-			})
+	if registry != nil && registry.Len() > 0 {
+		fullResponse, err = e.runToolLoop(ctx, client, messages, registry, task, callback)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		err = client.Stream(ctx, messages, func(chunk string) error {
+			fullResponse += chunk
+			if callback != nil {
+				callback(task.ID, 60, models.TaskStatusRunning, chunk, map[string]interface{}{
+					"type": "content",
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("LLM call failed: %w", err)
 		}
+	}
+
+	e.reportServedModel(task, chain, callback)
+
+	// The finished turn becomes part of the robot's session memory, and a
+	// background pass compacts the session once it outgrows its budget
+	e.recordSessionTurn(task, robot, prompt, fullResponse)
+	e.CompactSessionAsync(client, robot.Spec.SessionID)
+
+	return fullResponse, nil
+}
+
+// loadCraft loads and parses a Craft resource, returning nil (with a
+// warning) when it is missing or invalid
+func (e *Executor) loadCraft(task *models.Task, craftName string) *crd.Craft {
+	craftResource, err := resolveResource(e.storage, task.WorkspaceID, craftName, models.ResourceTypeCraft)
+	if err != nil {
+		e.logger.Warn("Failed to load craft resource", zap.String("craft", craftName), zap.Error(err))
 		return nil
-	})
+	}
 
+	resource, err := parseResolved(craftResource, resolveSpec(task, craftResource))
 	if err != nil {
-		return "", fmt.Errorf("LLM call failed: %w", err)
+		e.logger.Warn("Failed to parse craft spec", zap.Error(err))
+		return nil
 	}
 
-	return fullResponse, nil
+	craft, ok := resource.(*crd.Craft)
+	if !ok {
+		e.logger.Warn("Invalid craft resource")
+		return nil
+	}
+
+	return craft
 }
 
 // executeTeam executes a team task
 func (e *Executor) executeTeam(ctx context.Context, task *models.Task, callback ProgressCallback) (string, error) {
 	// Load team resource
-	teamResource, err := e.storage.Resources().GetByName(task.WorkspaceID, task.ResourceName, models.ResourceTypeTeam)
+	teamResource, err := resolveResource(e.storage, task.WorkspaceID, task.ResourceName, models.ResourceTypeTeam)
 	if err != nil {
 		return "", fmt.Errorf("failed to load team: %w", err)
 	}
 
 	// Parse team spec
-	parser := crd.NewParser()
-	resource, err := parser.Parse([]byte(teamResource.Spec))
+	resource, err := parseResolved(teamResource, resolveSpec(task, teamResource))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse team spec: %w", err)
 	}
@@ -202,103 +295,137 @@ func (e *Executor) executeTeam(ctx context.Context, task *models.Task, callback
 		return "", fmt.Errorf("invalid team resource")
 	}
 
-	// Simple implementation: execute leader robot with member context
-	// In a full implementation, this would coordinate between multiple agents
+	members := e.loadTeamAgents(task, team.Spec.Members, callback)
+	if len(members) == 0 {
+		return "", fmt.Errorf("team has no valid members")
+	}
 
-	var leaderResult string
+	// The leader routes, synthesizes or coordinates depending on the mode;
+	// without one the first member takes that seat
+	lead := members[0]
 	if team.Spec.Leader != "" {
-		// Load leader robot
-		leaderRobotResource, err := e.storage.Resources().GetByName(task.WorkspaceID, team.Spec.Leader, models.ResourceTypeRobot)
+		lead, err = e.loadTeamAgent(task, team.Spec.Leader, callback)
 		if err != nil {
-			return "", fmt.Errorf("failed to load leader robot: %w", err)
+			return "", err
 		}
+	}
 
-		leaderRobotDef, err := parser.Parse([]byte(leaderRobotResource.Spec))
-		if err != nil {
-			return "", fmt.Errorf("failed to parse leader robot: %w", err)
-		}
-		leaderRobot := leaderRobotDef.(*crd.Robot)
+	prompt := RenderPrompt(task.Prompt, task, e.storage) + attachmentContext(e.storage, e.embedder, task, callback)
 
-		// Load leader's soul and mind
-		soulResource, err := e.storage.Resources().GetByName(task.WorkspaceID, leaderRobot.Spec.Soul, models.ResourceTypeSoul)
-		if err != nil {
-			return "", fmt.Errorf("failed to load soul: %w", err)
-		}
-		soulDef, err := parser.Parse([]byte(soulResource.Spec))
-		if err != nil {
-			return "", fmt.Errorf("failed to parse soul: %w", err)
-		}
-		soul := soulDef.(*crd.Soul)
+	if callback != nil {
+		callback(task.ID, 20, models.TaskStatusRunning,
+			fmt.Sprintf("Running team in %s mode with %d members", team.Spec.Mode, len(members)), nil)
+	}
 
-		mindResource, err := e.storage.Resources().GetByName(task.WorkspaceID, leaderRobot.Spec.Mind, models.ResourceTypeMind)
-		if err != nil {
-			return "", fmt.Errorf("failed to load mind: %w", err)
-		}
-		mindDef, err := parser.Parse([]byte(mindResource.Spec))
-		if err != nil {
-			return "", fmt.Errorf("failed to parse mind: %w", err)
-		}
-		mind := mindDef.(*crd.Mind)
+	switch team.Spec.Mode {
+	case crd.ModeRoute:
+		return e.executeTeamRoute(ctx, task, lead, members, prompt, callback)
+	case crd.ModeCollaborate:
+		return e.executeTeamCollaborate(ctx, task, lead, members, prompt, callback)
+	default: // coordinate
+		return e.executeTeamCoordinate(ctx, task, lead, members, prompt, callback)
+	}
+}
 
-		// Get LLM client
-		client, err := e.getLLMClient(mind)
-		if err != nil {
-			return "", fmt.Errorf("failed to create LLM client: %w", err)
-		}
+// mindClient returns the client for a mind, wrapped in its fallback chain
+// when the spec declares one. Chains carry per-call state, so one is built
+// per task; the chain is nil when the mind has no fallbacks.
+func (e *Executor) mindClient(task *models.Task, mind *crd.Mind, callback ProgressCallback) (llm.Client, *llm.FallbackChain, error) {
+	primary, err := e.getLLMClient(task.WorkspaceID, mind)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(mind.Spec.Fallbacks) == 0 {
+		return primary, nil, nil
+	}
 
-		// Build team context
-		teamContext := fmt.Sprintf("You are leading a team with %d members. Coordinate their work to accomplish the task.\n\nTeam members: %v\nCollaboration mode: %s",
-			len(team.Spec.Members),
-			getMemberNames(team),
-			team.Spec.Mode,
-		)
-
-		messages := []llm.Message{
-			{
-				Role:    "system",
-				Content: soul.Spec.Personality + "\n\n" + teamContext,
-			},
-			{
-				Role:    "user",
-				Content: task.Prompt,
+	clients := []llm.Client{primary}
+	for _, fallback := range mind.Spec.Fallbacks {
+		fallbackMind := &crd.Mind{
+			Metadata: mind.Metadata,
+			Spec: crd.MindSpec{
+				Provider:    fallback.Provider,
+				ModelID:     fallback.ModelID,
+				APIKey:      fallback.APIKey,
+				APIKeyFrom:  fallback.APIKeyFrom,
+				BaseURL:     fallback.BaseURL,
+				Deployment:  fallback.Deployment,
+				Temperature: mind.Spec.Temperature,
+				MaxTokens:   mind.Spec.MaxTokens,
 			},
 		}
-
-		// Report progress
-		if callback != nil {
-			callback(task.ID, 30, models.TaskStatusRunning, "Team leader coordinating...", nil)
+		client, err := e.getLLMClient(task.WorkspaceID, fallbackMind)
+		if err != nil {
+			e.logger.Warn("Skipping unusable fallback model",
+				zap.String("provider", fallback.Provider),
+				zap.String("model", fallback.ModelID),
+				zap.Error(err))
+			continue
 		}
+		clients = append(clients, client)
+	}
 
-		// Call LLM with streaming
-		err = client.Stream(ctx, messages, func(chunk string) error {
-			leaderResult += chunk
-			if callback != nil {
-				callback(task.ID, 70, models.TaskStatusRunning, chunk, map[string]interface{}{
-					"type":  "content",
-					"agent": "leader",
+	chain := llm.NewFallbackChain(clients, func(from, to string, err error) {
+		e.logger.Warn("Model failed, falling back",
+			zap.Uint("task_id", task.ID),
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Error(err))
+		if callback != nil {
+			callback(task.ID, 30, models.TaskStatusRunning,
+				fmt.Sprintf("Model %s failed, falling back to %s", from, to), map[string]interface{}{
+					"type": "warning",
 				})
-			}
-			return nil
-		})
-
-		if err != nil {
-			return "", fmt.Errorf("leader execution failed: %w", err)
 		}
+	})
+	return chain, chain, nil
+}
+
+// reportServedModel records which model actually served the task once a
+// fallback chain resolved
+func (e *Executor) reportServedModel(task *models.Task, chain *llm.FallbackChain, callback ProgressCallback) {
+	if chain == nil || chain.ServedBy() == "" {
+		return
 	}
+	e.logger.Info("Task served by model",
+		zap.Uint("task_id", task.ID),
+		zap.String("model", chain.ServedBy()))
+	if callback != nil {
+		callback(task.ID, 90, models.TaskStatusRunning,
+			fmt.Sprintf("Served by %s", chain.ServedBy()), map[string]interface{}{
+				"type": "model_served",
+				"details": map[string]interface{}{
+					"model": chain.ServedBy(),
+				},
+			})
+	}
+}
 
-	return leaderResult, nil
+// LLMClient returns the cached (or newly created) client for a mind
+func (e *Executor) LLMClient(workspaceID uint, mind *crd.Mind) (llm.Client, error) {
+	return e.getLLMClient(workspaceID, mind)
 }
 
 // getLLMClient gets or creates an LLM client for a mind
-func (e *Executor) getLLMClient(mind *crd.Mind) (llm.Client, error) {
-	cacheKey := fmt.Sprintf("%s:%s:%s", mind.Spec.Provider, mind.Spec.ModelID, mind.Spec.BaseURL)
+func (e *Executor) getLLMClient(workspaceID uint, mind *crd.Mind) (llm.Client, error) {
+	apiKey, err := resolveMindAPIKey(e.storage, workspaceID, &mind.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key includes the tuning options and a digest of the credential,
+	// so Minds sharing a model but tuned differently get separate clients
+	// and an edited Mind or rotated key stops hitting the stale client
+	keyDigest := sha256.Sum256([]byte(apiKey))
+	cacheKey := fmt.Sprintf("%s:%s:%s:%s:%g:%d:%v:%v:%d:%x", mind.Spec.Provider, mind.Spec.ModelID, mind.Spec.BaseURL,
+		mind.Spec.Deployment, mind.Spec.Temperature, mind.Spec.MaxTokens, mind.Spec.Headers,
+		mind.Spec.Cache, mind.Spec.CacheTTLSeconds, keyDigest[:8])
 
-	if client, exists := e.llmClients[cacheKey]; exists {
+	if client, ok := e.llmClients.get(cacheKey); ok {
 		return client, nil
 	}
 
 	var client llm.Client
-	apiKey := mind.Spec.APIKey
 
 	switch mind.Spec.Provider {
 	case "ollama":
@@ -322,6 +449,23 @@ func (e *Executor) getLLMClient(mind *crd.Mind) (llm.Client, error) {
 			client = llm.NewOpenAIClient(mind.Spec.ModelID, apiKey)
 		}
 
+	case "azure-openai", "azure":
+		// Azure routes to a named deployment on the resource endpoint
+		if apiKey == "" {
+			apiKey = os.Getenv("AZURE_OPENAI_API_KEY")
+		}
+		if apiKey == "" {
+			return nil, fmt.Errorf("API key not configured for provider: %s", mind.Spec.Provider)
+		}
+		if mind.Spec.BaseURL == "" {
+			return nil, fmt.Errorf("azure-openai requires base_url (the resource endpoint)")
+		}
+		deployment := mind.Spec.Deployment
+		if deployment == "" {
+			deployment = mind.Spec.ModelID
+		}
+		client = llm.NewAzureOpenAIClient(deployment, apiKey, mind.Spec.BaseURL, mind.Spec.APIVersion)
+
 	case "groq":
 		// Groq is OpenAI-compatible with free tier
 		if apiKey == "" {
@@ -384,11 +528,54 @@ func (e *Executor) getLLMClient(mind *crd.Mind) (llm.Client, error) {
 		}
 	}
 
-	e.llmClients[cacheKey] = client
+	// Apply the Mind's model tuning (temperature, max tokens, extra headers)
+	if tunable, ok := client.(llm.Tunable); ok {
+		tunable.SetOptions(llm.Options{
+			Temperature: mind.Spec.Temperature,
+			MaxTokens:   mind.Spec.MaxTokens,
+			Headers:     mind.Spec.Headers,
+		})
+	}
+
+	// Every provider call shows up as a client span in the task trace
+	client = llm.WithTracing(client, mind.Spec.ModelID)
+
+	// Retry transient provider failures with backoff; the circuit breaker
+	// fails fast when a provider keeps erroring
+	resilience := llm.DefaultResilienceConfig()
+	provider := mind.Spec.Provider
+	resilience.OnRetry = func(attempt int, delay time.Duration, err error) {
+		e.logger.Warn("Retrying LLM call",
+			zap.String("provider", provider),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+	}
+	client = llm.WithResilience(client, resilience)
+
+	// Serve repeated identical prompts from the response cache when the
+	// mind opted in
+	if mind.Spec.Cache {
+		ttl := time.Duration(mind.Spec.CacheTTLSeconds) * time.Second
+		client = llm.WithCache(client, e.llmCache, ttl, func(hit bool, key string) {
+			e.logger.Debug("LLM response cache lookup",
+				zap.String("provider", provider),
+				zap.Bool("hit", hit),
+				zap.String("key", key[:12]))
+		})
+	}
+
+	e.llmClients.put(cacheKey, client)
 	return client, nil
 }
 
-// getMemberNames extracts member names from team
-func getMemberNames(team *crd.Team) []string {
-	return team.Spec.Members
+// errBudgetExceeded marks an execution aborted for crossing the task's
+// token budget
+var errBudgetExceeded = errors.New("token budget exceeded")
+
+// estimateTokens approximates the LLM tokens a piece of text costs, used for
+// budget accounting when the provider does not report exact usage. The usual
+// ~4 characters per token heuristic errs on the generous side.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
 }
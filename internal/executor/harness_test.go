@@ -0,0 +1,280 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// bridgeEvent is one scripted line of fake bridge output
+type bridgeEvent struct {
+	Type    string                 `json:"type"`
+	Content string                 `json:"content,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// scriptBridge renders scripted events as the line protocol the Python
+// bridge speaks, for feeding consumeBridgeEvents deterministically
+func scriptBridge(t *testing.T, events ...bridgeEvent) io.Reader {
+	t.Helper()
+	var b strings.Builder
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf("marshal event: %v", err)
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return strings.NewReader(b.String())
+}
+
+// callbackRecord captures one progress callback invocation
+type callbackRecord struct {
+	Progress int
+	Status   models.TaskStatus
+	Message  string
+	Type     string
+}
+
+// recordingCallback collects every callback invocation for assertions
+func recordingCallback(records *[]callbackRecord) ProgressCallback {
+	return func(taskID uint, progress int, status models.TaskStatus, message string, metadata map[string]interface{}) {
+		record := callbackRecord{Progress: progress, Status: status, Message: message}
+		if eventType, ok := metadata["type"].(string); ok {
+			record.Type = eventType
+		}
+		*records = append(*records, record)
+	}
+}
+
+// newTestExecutor builds an executor without storage; scripted events must
+// avoid types that persist state (plan, step_*)
+func newTestExecutor() *AgnoExecutor {
+	return &AgnoExecutor{logger: zap.NewNop()}
+}
+
+// fakeLLM is a scripted llm.Client: each call shifts the next response (or
+// error) off the queue, so provider behavior is deterministic in tests
+type fakeLLM struct {
+	responses []*llm.Response
+	errs      []error
+	calls     int
+}
+
+func (f *fakeLLM) next() (*llm.Response, error) {
+	i := f.calls
+	f.calls++
+	if i < len(f.errs) && f.errs[i] != nil {
+		return nil, f.errs[i]
+	}
+	if i < len(f.responses) {
+		return f.responses[i], nil
+	}
+	return nil, fmt.Errorf("fakeLLM: no scripted response for call %d", i)
+}
+
+func (f *fakeLLM) Chat(ctx context.Context, messages []llm.Message) (*llm.Response, error) {
+	return f.next()
+}
+
+func (f *fakeLLM) Stream(ctx context.Context, messages []llm.Message, callback func(string) error) error {
+	resp, err := f.next()
+	if err != nil {
+		return err
+	}
+	return callback(resp.Content)
+}
+
+func (f *fakeLLM) ChatWithTools(ctx context.Context, messages []llm.Message, tools []llm.Tool) (*llm.Response, error) {
+	return f.next()
+}
+
+func (f *fakeLLM) StreamWithTools(ctx context.Context, messages []llm.Message, tools []llm.Tool, callback llm.StreamCallback) (*llm.Response, error) {
+	return f.next()
+}
+
+func (f *fakeLLM) Name() string { return "fake" }
+
+func TestConsumeBridgeEventsAccumulatesContent(t *testing.T) {
+	e := newTestExecutor()
+	var records []callbackRecord
+
+	output := scriptBridge(t,
+		bridgeEvent{Type: "started", Content: "agent booted"},
+		bridgeEvent{Type: "content", Content: "hello "},
+		bridgeEvent{Type: "content", Content: "world"},
+		bridgeEvent{Type: "completed"},
+	)
+
+	content, logs, lastError, cancelled := e.consumeBridgeEvents(output, 1, recordingCallback(&records))
+	if content != "hello world" {
+		t.Errorf("expected assembled content, got %q", content)
+	}
+	if lastError != "" || cancelled {
+		t.Errorf("unexpected error %q / cancelled %v", lastError, cancelled)
+	}
+	if lines := strings.Count(logs, "\n") + 1; lines != 4 {
+		t.Errorf("expected 4 event log lines, got %d", lines)
+	}
+	if len(records) != 3 { // started + two content events; completed emits none
+		t.Fatalf("expected 3 callback invocations, got %d", len(records))
+	}
+	if records[0].Type != "started" || records[1].Type != "content" {
+		t.Errorf("unexpected callback order: %+v", records)
+	}
+}
+
+func TestConsumeBridgeEventsSkipsNonJSONLines(t *testing.T) {
+	e := newTestExecutor()
+
+	output := strings.NewReader("not json\n" +
+		`{"type":"content","content":"ok"}` + "\n" +
+		"also not json\n")
+
+	content, logs, _, _ := e.consumeBridgeEvents(output, 1, nil)
+	if content != "ok" {
+		t.Errorf("expected raw lines ignored, got content %q", content)
+	}
+	if strings.Contains(logs, "not json") {
+		t.Errorf("raw lines must not land in the event log: %q", logs)
+	}
+}
+
+func TestConsumeBridgeEventsReportsError(t *testing.T) {
+	e := newTestExecutor()
+
+	output := scriptBridge(t,
+		bridgeEvent{Type: "content", Content: "partial"},
+		bridgeEvent{Type: "error", Content: "provider exploded"},
+	)
+
+	content, _, lastError, cancelled := e.consumeBridgeEvents(output, 1, nil)
+	if lastError != "provider exploded" {
+		t.Errorf("expected last error recorded, got %q", lastError)
+	}
+	if cancelled {
+		t.Error("error must not report as cancellation")
+	}
+	if content != "partial" {
+		t.Errorf("content before the error should be kept, got %q", content)
+	}
+}
+
+func TestConsumeBridgeEventsCancellation(t *testing.T) {
+	e := newTestExecutor()
+	var records []callbackRecord
+
+	output := scriptBridge(t,
+		bridgeEvent{Type: "content", Content: "before"},
+		bridgeEvent{Type: "cancelled", Content: "stopped by user"},
+		bridgeEvent{Type: "content", Content: "after"},
+	)
+
+	content, _, _, cancelled := e.consumeBridgeEvents(output, 1, recordingCallback(&records))
+	if !cancelled {
+		t.Fatal("expected cancellation to be reported")
+	}
+	if content != "before" {
+		t.Errorf("events after cancellation must not be consumed, got %q", content)
+	}
+}
+
+func TestConsumeBridgeEventsArtifactWithoutStore(t *testing.T) {
+	e := newTestExecutor()
+	var records []callbackRecord
+
+	// Artifact events without configured artifact storage are dropped with
+	// a warning rather than crashing the run
+	output := scriptBridge(t,
+		bridgeEvent{Type: "artifact", Details: map[string]interface{}{"path": "/tmp/out.txt"}},
+		bridgeEvent{Type: "content", Content: "done"},
+	)
+
+	content, _, _, _ := e.consumeBridgeEvents(output, 1, recordingCallback(&records))
+	if content != "done" {
+		t.Errorf("expected execution to continue past artifact event, got %q", content)
+	}
+	for _, record := range records {
+		if record.Type == "artifact_registered" {
+			t.Error("no artifact must be reported without a configured store")
+		}
+	}
+}
+
+func TestMonotonicCallbackClampsProgress(t *testing.T) {
+	var records []callbackRecord
+	callback := monotonicCallback(recordingCallback(&records))
+
+	for _, progress := range []int{10, 50, 30, 60, 20} {
+		callback(1, progress, models.TaskStatusRunning, "", nil)
+	}
+
+	expected := []int{10, 50, 50, 60, 60}
+	for i, record := range records {
+		if record.Progress != expected[i] {
+			t.Errorf("call %d: expected progress %d, got %d", i, expected[i], record.Progress)
+		}
+	}
+}
+
+func TestProgressEstimatorMilestones(t *testing.T) {
+	est := newProgressEstimator(nil, 1)
+
+	if p := est.observe("started", ""); p <= 0 || p > setupBand {
+		t.Errorf("setup milestone should land in the setup band, got %d", p)
+	}
+	if p := est.observe("mcp_connected", ""); p <= 5 || p > setupBand {
+		t.Errorf("second setup milestone should advance within the setup band, got %d", p)
+	}
+
+	// A three-step plan makes progress track finished steps exactly
+	est.observe("plan", "1. first\n2. second\n3. third")
+	afterOne := est.observe("step_completed", "")
+	afterTwo := est.observe("step_completed", "")
+	if afterOne <= setupBand || afterTwo <= afterOne {
+		t.Errorf("plan steps must advance progress: %d then %d", afterOne, afterTwo)
+	}
+
+	if p := est.observe("run_completed", ""); p != setupBand+workBand {
+		t.Errorf("completed run should report %d, got %d", setupBand+workBand, p)
+	}
+}
+
+func TestProgressEstimatorStreamedTokens(t *testing.T) {
+	est := newProgressEstimator(nil, 1)
+
+	previous := est.observe("started", "")
+	for i := 0; i < 5; i++ {
+		p := est.observe("content", strings.Repeat("x", 4000))
+		if p < previous {
+			t.Errorf("streamed content must never move progress backwards: %d after %d", p, previous)
+		}
+		previous = p
+	}
+	if previous <= setupBand || previous >= setupBand+workBand {
+		t.Errorf("unbounded streaming should approach but not reach the work band end, got %d", previous)
+	}
+}
+
+func TestFakeLLMScriptedFailures(t *testing.T) {
+	client := &fakeLLM{
+		responses: []*llm.Response{nil, {Content: "recovered"}},
+		errs:      []error{fmt.Errorf("transient"), nil},
+	}
+
+	if _, err := client.Chat(context.Background(), nil); err == nil {
+		t.Fatal("expected scripted failure on first call")
+	}
+	resp, err := client.Chat(context.Background(), nil)
+	if err != nil || resp.Content != "recovered" {
+		t.Fatalf("expected scripted success on second call, got %v / %v", resp, err)
+	}
+}
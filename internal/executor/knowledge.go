@@ -0,0 +1,201 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// knowledgeChunkSize is the default chunk length in characters
+	knowledgeChunkSize = 1200
+
+	// knowledgeTopK is the default number of chunks injected per execution
+	knowledgeTopK = 5
+
+	// knowledgeFetchTimeout bounds URL source fetches
+	knowledgeFetchTimeout = 10 * time.Second
+
+	// knowledgeFetchLimit caps how much is read from a URL source
+	knowledgeFetchLimit = 256 * 1024
+)
+
+// knowledgeChunk is one scored retrieval candidate
+type knowledgeChunk struct {
+	source string
+	text   string
+	score  int
+}
+
+// knowledgeContext loads the robot's Knowledge resources, retrieves the
+// chunks most relevant to the prompt and renders them as a context section
+// to append to the prompt
+func knowledgeContext(store *storage.Storage, task *models.Task, knowledgeNames []string, logger *zap.Logger) string {
+	if len(knowledgeNames) == 0 {
+		return ""
+	}
+
+	chunkSize := knowledgeChunkSize
+	topK := knowledgeTopK
+
+	var chunks []knowledgeChunk
+	for _, name := range knowledgeNames {
+		knowledgeResource, err := resolveResource(store, task.WorkspaceID, name, models.ResourceTypeKnowledge)
+		if err != nil {
+			logger.Warn("Failed to load knowledge resource", zap.String("knowledge", name), zap.Error(err))
+			continue
+		}
+
+		resource, err := parseResolved(knowledgeResource, resolveSpec(task, knowledgeResource))
+		if err != nil {
+			logger.Warn("Failed to parse knowledge spec", zap.String("knowledge", name), zap.Error(err))
+			continue
+		}
+		knowledge, ok := resource.(*crd.Knowledge)
+		if !ok {
+			continue
+		}
+
+		if knowledge.Spec.ChunkSize > 0 {
+			chunkSize = knowledge.Spec.ChunkSize
+		}
+		if knowledge.Spec.TopK > 0 {
+			topK = knowledge.Spec.TopK
+		}
+
+		for _, doc := range knowledge.Spec.Documents {
+			for _, text := range chunkText(doc.Content, chunkSize) {
+				chunks = append(chunks, knowledgeChunk{source: doc.Name, text: text})
+			}
+		}
+
+		for _, url := range knowledge.Spec.URLs {
+			content, err := fetchKnowledgeURL(url)
+			if err != nil {
+				logger.Warn("Failed to fetch knowledge URL", zap.String("url", url), zap.Error(err))
+				continue
+			}
+			for _, text := range chunkText(content, chunkSize) {
+				chunks = append(chunks, knowledgeChunk{source: url, text: text})
+			}
+		}
+
+		for _, attachmentID := range knowledge.Spec.AttachmentIDs {
+			att, err := store.Attachments().GetByID(attachmentID)
+			if err != nil || att.TextContent == "" {
+				continue
+			}
+			for _, text := range chunkText(att.TextContent, chunkSize) {
+				chunks = append(chunks, knowledgeChunk{source: att.Filename, text: text})
+			}
+		}
+	}
+
+	selected := rankChunks(chunks, task.Prompt, topK)
+	if len(selected) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n# Knowledge")
+	for _, chunk := range selected {
+		b.WriteString(fmt.Sprintf("\n\n## From %s\n%s", chunk.source, chunk.text))
+	}
+	return b.String()
+}
+
+// chunkText splits text into chunks of roughly the given size, breaking at
+// paragraph boundaries where possible
+func chunkText(text string, size int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if current.Len() > 0 && current.Len()+len(paragraph) > size {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		// Paragraphs larger than a chunk are split hard
+		for len(paragraph) > size {
+			chunks = append(chunks, paragraph[:size])
+			paragraph = paragraph[size:]
+		}
+		if paragraph == "" {
+			continue
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(paragraph)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// rankChunks scores chunks by distinct term overlap with the prompt and
+// returns the topK best matches in score order. Chunks sharing no terms with
+// the prompt are dropped.
+func rankChunks(chunks []knowledgeChunk, prompt string, topK int) []knowledgeChunk {
+	terms := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(prompt)) {
+		word = strings.Trim(word, ".,;:!?\"'()[]{}")
+		if len(word) > 3 {
+			terms[word] = true
+		}
+	}
+
+	scored := make([]knowledgeChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		lower := strings.ToLower(chunk.text)
+		for term := range terms {
+			if strings.Contains(lower, term) {
+				chunk.score++
+			}
+		}
+		if chunk.score > 0 {
+			scored = append(scored, chunk)
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	if len(scored) > topK {
+		scored = scored[:topK]
+	}
+	return scored
+}
+
+// fetchKnowledgeURL retrieves a URL source's body with a timeout and size cap
+func fetchKnowledgeURL(url string) (string, error) {
+	client := &http.Client{Timeout: knowledgeFetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, knowledgeFetchLimit))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+)
+
+// llmClientCacheSize caps how many constructed clients are kept; one entry
+// per distinct mind configuration in active use
+const llmClientCacheSize = 64
+
+// llmClientCache is a small LRU of constructed LLM clients, safe for
+// concurrent use by parallel workers. Keys cover the mind's full tuning
+// and credential material, so an edited Mind (or a rotated API key) simply
+// misses and its stale client ages out of the LRU.
+type llmClientCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently used
+}
+
+type llmClientEntry struct {
+	key    string
+	client llm.Client
+}
+
+func newLLMClientCache(capacity int) *llmClientCache {
+	return &llmClientCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the cached client for a key and marks it most recently used
+func (c *llmClientCache) get(key string) (llm.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*llmClientEntry).client, true
+}
+
+// put stores a client, evicting the least recently used entry when the
+// cache is full
+func (c *llmClientCache) put(key string, client llm.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*llmClientEntry).client = client
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&llmClientEntry{key: key, client: client})
+	for len(c.entries) > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*llmClientEntry).key)
+	}
+}
@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// memberTracker keeps the per-member SubTask rows of a team task in step
+// with the events the bridge emits, so clients watching the subtask list
+// see members start, work and finish as it happens
+type memberTracker struct {
+	store  *storage.Storage
+	taskID uint
+
+	mu      sync.Mutex
+	loaded  bool
+	members []*models.SubTask
+}
+
+// newMemberTracker creates a member tracker for a task
+func newMemberTracker(store *storage.Storage, taskID uint) *memberTracker {
+	return &memberTracker{store: store, taskID: taskID}
+}
+
+// load lazily fetches the task's member subtasks (rows with an agent ID);
+// bot runs have none and every update becomes a no-op
+func (t *memberTracker) load() {
+	if t.loaded {
+		return
+	}
+	t.loaded = true
+	if t.store == nil {
+		return
+	}
+	subtasks, err := t.store.Tasks().ListSubTasks(t.taskID)
+	if err != nil {
+		return
+	}
+	for _, subtask := range subtasks {
+		if subtask.AgentID != "" {
+			t.members = append(t.members, subtask)
+		}
+	}
+}
+
+// find resolves the member an event refers to from its details; events
+// without a recognizable member name are ignored rather than guessed at
+func (t *memberTracker) find(details map[string]interface{}) *models.SubTask {
+	if details == nil {
+		return nil
+	}
+	for _, key := range []string{"member", "member_name", "agent"} {
+		name, ok := details[key].(string)
+		if !ok || name == "" {
+			continue
+		}
+		for _, member := range t.members {
+			if strings.EqualFold(member.AgentID, name) {
+				return member
+			}
+		}
+	}
+	return nil
+}
+
+// Start marks the member named in the event as running
+func (t *memberTracker) Start(details map[string]interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.load()
+
+	member := t.find(details)
+	if member == nil || member.Status == models.TaskStatusCompleted {
+		return
+	}
+	member.Status = models.TaskStatusRunning
+	t.store.Tasks().UpdateSubTask(member)
+}
+
+// Touch bumps a running member's progress when one of its tool calls
+// starts or finishes
+func (t *memberTracker) Touch(details map[string]interface{}, progress int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.load()
+
+	member := t.find(details)
+	if member == nil || member.Status == models.TaskStatusCompleted {
+		return
+	}
+	member.Status = models.TaskStatusRunning
+	if progress > member.Progress {
+		member.Progress = progress
+	}
+	t.store.Tasks().UpdateSubTask(member)
+}
+
+// Complete marks the member named in the event as done
+func (t *memberTracker) Complete(details map[string]interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.load()
+
+	member := t.find(details)
+	if member == nil {
+		return
+	}
+	member.Status = models.TaskStatusCompleted
+	member.Progress = 100
+	t.store.Tasks().UpdateSubTask(member)
+}
+
+// CompleteAll finishes every member still open once the team run itself
+// completes; the bridge does not always attribute per-member completions
+func (t *memberTracker) CompleteAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.load()
+
+	for _, member := range t.members {
+		if member.Status == models.TaskStatusCompleted {
+			continue
+		}
+		member.Status = models.TaskStatusCompleted
+		member.Progress = 100
+		t.store.Tasks().UpdateSubTask(member)
+	}
+}
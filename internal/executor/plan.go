@@ -0,0 +1,133 @@
+package executor
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// planStepPattern matches one plan step per line: numbered ("1." / "2)"),
+// bulleted ("-" / "*") and markdown checklist ("- [ ]") forms
+var planStepPattern = regexp.MustCompile(`(?m)^\s*(?:\d+[.)]|[-*])\s*(?:\[[ xX]\]\s*)?(.+?)\s*$`)
+
+// planTracker turns plan events emitted by the agent into ordered SubTask
+// rows and keeps them updated as steps complete, giving users a checklist
+// view of long executions
+type planTracker struct {
+	store  *storage.Storage
+	taskID uint
+
+	mu    sync.Mutex
+	steps []*models.SubTask
+}
+
+// newPlanTracker creates a plan tracker for a task
+func newPlanTracker(store *storage.Storage, taskID uint) *planTracker {
+	return &planTracker{store: store, taskID: taskID}
+}
+
+// Record parses a plan into SubTask rows. Steps are ordered as listed and
+// each step depends on the previous one; recording a second plan replaces
+// the unfinished remainder of the first.
+func (t *planTracker) Record(content string) []string {
+	titles := parsePlanSteps(content)
+	if len(titles) == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Drop unfinished steps from a previously recorded plan
+	for _, step := range t.steps {
+		if step.Status == models.TaskStatusPending {
+			t.store.DB().Delete(step)
+		}
+	}
+	t.steps = nil
+
+	var prev string
+	for i, title := range titles {
+		var dependsOn string
+		if prev != "" {
+			deps, _ := json.Marshal([]string{prev})
+			dependsOn = string(deps)
+		}
+
+		subtask := &models.SubTask{
+			TaskID:    t.taskID,
+			Status:    models.TaskStatusPending,
+			Title:     title,
+			StepOrder: i + 1,
+			DependsOn: dependsOn,
+		}
+		if err := t.store.Tasks().CreateSubTask(subtask); err != nil {
+			continue
+		}
+		t.steps = append(t.steps, subtask)
+		prev = title
+	}
+
+	return titles
+}
+
+// Update marks a plan step as running, completed or failed. The step is
+// located by "step" (1-based index or title) in the event details, falling
+// back to the first non-finished step.
+func (t *planTracker) Update(details map[string]interface{}, status models.TaskStatus, result string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	step := t.findStep(details)
+	if step == nil {
+		return
+	}
+
+	step.Status = status
+	if status == models.TaskStatusCompleted {
+		step.Progress = 100
+		step.Result = result
+	}
+	t.store.Tasks().UpdateSubTask(step)
+}
+
+// findStep resolves the step an update refers to
+func (t *planTracker) findStep(details map[string]interface{}) *models.SubTask {
+	if details != nil {
+		switch ref := details["step"].(type) {
+		case float64:
+			if index := int(ref) - 1; index >= 0 && index < len(t.steps) {
+				return t.steps[index]
+			}
+		case string:
+			for _, step := range t.steps {
+				if strings.EqualFold(step.Title, ref) {
+					return step
+				}
+			}
+		}
+	}
+
+	for _, step := range t.steps {
+		if step.Status == models.TaskStatusPending || step.Status == models.TaskStatusRunning {
+			return step
+		}
+	}
+	return nil
+}
+
+// parsePlanSteps extracts step titles from plan text
+func parsePlanSteps(content string) []string {
+	var titles []string
+	for _, match := range planStepPattern.FindAllStringSubmatch(content, -1) {
+		title := strings.TrimSpace(match[1])
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
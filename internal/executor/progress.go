@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// progressTracker keeps the highest progress value reported so far for a
+// task. Event callbacks report fixed numbers per event type, which made
+// progress jump backwards when events interleaved (60 → 50 → 70); the
+// tracker clamps every report to be monotonic.
+type progressTracker struct {
+	mu      sync.Mutex
+	current int
+}
+
+// advance raises progress to at least percent and returns the value to report
+func (p *progressTracker) advance(percent int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent > p.current {
+		p.current = percent
+	}
+	return p.current
+}
+
+// rollupProgress maps subtask completion onto the 10-90 progress band.
+// Every subtask weighs equally and contributes its own progress; finished
+// ones (whatever the outcome) count as done.
+func rollupProgress(subtasks []*models.SubTask) int {
+	if len(subtasks) == 0 {
+		return 10
+	}
+	total := 0
+	for _, subtask := range subtasks {
+		switch subtask.Status {
+		case models.TaskStatusCompleted, models.TaskStatusFailed, models.TaskStatusCancelled:
+			total += 100
+		default:
+			total += subtask.Progress
+		}
+	}
+	return 10 + total*80/(100*len(subtasks))
+}
+
+// taskRollup rolls a task's stored subtasks up into one progress value,
+// returning fallback when the task has none to roll up
+func taskRollup(store *storage.Storage, taskID uint, fallback int) int {
+	if store == nil {
+		return fallback
+	}
+	subtasks, err := store.Tasks().ListSubTasks(taskID)
+	if err != nil || len(subtasks) == 0 {
+		return fallback
+	}
+	return rollupProgress(subtasks)
+}
+
+// monotonicCallback wraps a progress callback so the progress it reports for
+// a task never decreases, regardless of event ordering
+func monotonicCallback(callback ProgressCallback) ProgressCallback {
+	if callback == nil {
+		return nil
+	}
+	tracker := &progressTracker{}
+	return func(taskID uint, progress int, status models.TaskStatus, message string, metadata map[string]interface{}) {
+		callback(taskID, tracker.advance(progress), status, message, metadata)
+	}
+}
@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"regexp"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// redactPatterns match PII and credentials that must not reach persisted
+// results or logs when a workspace opts into redaction. Patterns are
+// deliberately conservative: false positives hide useful output, while the
+// provider key prefixes below are unambiguous.
+var redactPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// Email addresses
+	{regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), "[REDACTED:email]"},
+	// OpenAI / Anthropic style keys
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9_\-]{16,}`), "[REDACTED:key]"},
+	// GitHub tokens
+	{regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36,}`), "[REDACTED:key]"},
+	{regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}`), "[REDACTED:key]"},
+	// Slack tokens
+	{regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9\-]{10,}`), "[REDACTED:key]"},
+	// AWS access key IDs
+	{regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`), "[REDACTED:key]"},
+	// Bearer tokens in headers or curl snippets
+	{regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._\-]{16,}`), "Bearer [REDACTED:token]"},
+	// JWTs
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]{10,}\.[A-Za-z0-9_\-]{10,}\.[A-Za-z0-9_\-]{10,}\b`), "[REDACTED:token]"},
+}
+
+// redactSensitive scrubs PII and credentials from a piece of text
+func redactSensitive(text string) string {
+	for _, entry := range redactPatterns {
+		text = entry.pattern.ReplaceAllString(text, entry.replacement)
+	}
+	return text
+}
+
+// redactionEnabled reports whether the task's workspace opted into output
+// redaction; lookup failures leave redaction off
+func redactionEnabled(store *storage.Storage, workspaceID uint) bool {
+	workspace, err := store.Workspaces().GetByID(workspaceID)
+	if err != nil {
+		return false
+	}
+	return workspace.RedactPII
+}
+
+// redactingCallback wraps a progress callback so every log message and any
+// result payload is scrubbed before it is broadcast or persisted
+func redactingCallback(callback ProgressCallback) ProgressCallback {
+	if callback == nil {
+		return nil
+	}
+	return func(taskID uint, progress int, status models.TaskStatus, message string, metadata map[string]interface{}) {
+		message = redactSensitive(message)
+		if metadata != nil {
+			if result, ok := metadata["result"].(string); ok {
+				metadata["result"] = redactSensitive(result)
+			}
+		}
+		callback(taskID, progress, status, message, metadata)
+	}
+}
@@ -0,0 +1,55 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/xcode-ai/xgent-go/internal/runner"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// runRemote dispatches a bridge config to a connected runner agent and
+// consumes the event lines it streams back, so remote executions report
+// progress exactly like local ones
+func (e *AgnoExecutor) runRemote(ctx context.Context, config AgnoConfig, taskID uint, callback ProgressCallback) (string, string, error) {
+	name := config.Environment.RunnerURL
+	conn, ok := runner.GetRegistry().Get(name)
+	if !ok {
+		return "", "", fmt.Errorf("runner %s is not connected", name)
+	}
+
+	e.logger.Info("Dispatching task to remote runner",
+		zap.Uint("task_id", taskID),
+		zap.String("runner", name))
+
+	if callback != nil {
+		callback(taskID, 10, models.TaskStatusRunning,
+			fmt.Sprintf("Dispatched to runner %s", name), map[string]interface{}{
+				"type":    "runner_dispatched",
+				"details": map[string]interface{}{"runner": name},
+			})
+	}
+
+	events, err := conn.Execute(ctx, taskID, config)
+	if err != nil {
+		return "", "", err
+	}
+	defer events.Close()
+
+	result, eventLogs, lastError, cancelled := e.consumeBridgeEvents(events, taskID, callback)
+	if cancelled {
+		return result, eventLogs, nil
+	}
+
+	// A failed execution surfaces as the pipe's close error
+	if _, err := io.Copy(io.Discard, events); err != nil {
+		if lastError != "" {
+			return "", "", fmt.Errorf("runner error: %s", lastError)
+		}
+		return "", "", fmt.Errorf("runner execution failed: %w", err)
+	}
+
+	return result, eventLogs, nil
+}
@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// resolveSpec returns the spec bytes a task should execute for a resource.
+// Specs are recorded in the task's snapshot as they are resolved, so the run
+// can later be replayed against the same revisions even after the resources
+// have been edited. Replay tasks read recorded specs back instead of
+// resolving the live resource.
+func resolveSpec(task *models.Task, resource *models.Resource) []byte {
+	key := fmt.Sprintf("%s/%s", resource.Type, resource.Name)
+
+	snapshot := map[string]string{}
+	if task.SpecSnapshot != "" {
+		json.Unmarshal([]byte(task.SpecSnapshot), &snapshot)
+	}
+
+	if task.ReplayOfID != nil {
+		if spec, ok := snapshot[key]; ok {
+			return []byte(spec)
+		}
+	}
+
+	spec := specForExecution(resource)
+	snapshot[key] = string(spec)
+	if data, err := json.Marshal(snapshot); err == nil {
+		task.SpecSnapshot = string(data)
+	}
+	return spec
+}
+
+// logReplayOutcome compares a finished replay's result with the original task
+// and records whether the results diverged, so regressions after model or
+// spec changes are easy to spot in the task log
+func logReplayOutcome(store *storage.Storage, logger *zap.Logger, task *models.Task) {
+	if task.ReplayOfID == nil {
+		return
+	}
+
+	original, err := store.Tasks().GetByID(*task.ReplayOfID)
+	if err != nil {
+		logger.Warn("Replay original task not found",
+			zap.Uint("task_id", task.ID), zap.Uint("original_id", *task.ReplayOfID))
+		return
+	}
+
+	message := fmt.Sprintf("replay result matches original task %d", original.ID)
+	if task.Result != original.Result {
+		message = fmt.Sprintf("replay result DIVERGES from original task %d", original.ID)
+	}
+
+	if err := store.Tasks().AddLog(&models.TaskLog{
+		TaskID:    task.ID,
+		Level:     "info",
+		Message:   message,
+		EventType: "replay",
+	}); err != nil {
+		logger.Warn("Failed to write replay log", zap.Uint("task_id", task.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,110 @@
+package executor
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// resolverTTL bounds how stale a cached resource can get for writes this
+// process never sees (the server and worker cache independently). Writes
+// through this process's API invalidate immediately.
+const resolverTTL = 30 * time.Second
+
+type resolverKey struct {
+	workspaceID  uint
+	name         string
+	resourceType models.ResourceType
+}
+
+// resolverEntry is one cached resolution: the stored row and the parse of
+// its current spec. The parse is tied to the row's UpdatedAt, so an apply
+// naturally invalidates it even when the row itself is re-fetched.
+type resolverEntry struct {
+	resource  *models.Resource
+	parsed    crd.Resource
+	fetchedAt time.Time
+}
+
+// resolverCache is shared by every executor in the process — the handlers'
+// native executors and the orchestrator's agno executor resolve the same
+// Souls, Minds and Crafts, so a per-instance cache would fragment
+var resolverCache = struct {
+	sync.Mutex
+	entries map[resolverKey]*resolverEntry
+}{entries: make(map[resolverKey]*resolverEntry)}
+
+// resolveResource returns a resource by name, served from the in-process
+// cache when a fresh entry exists. Executions look up the same unchanged
+// resources three to six times per run; the cache collapses those to one
+// storage round-trip per revision.
+func resolveResource(store *storage.Storage, workspaceID uint, name string, resourceType models.ResourceType) (*models.Resource, error) {
+	key := resolverKey{workspaceID, name, resourceType}
+
+	resolverCache.Lock()
+	entry, ok := resolverCache.entries[key]
+	resolverCache.Unlock()
+	if ok && time.Since(entry.fetchedAt) < resolverTTL {
+		return entry.resource, nil
+	}
+
+	resource, err := store.Resources().GetByName(workspaceID, name, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	fresh := &resolverEntry{resource: resource, fetchedAt: time.Now()}
+	resolverCache.Lock()
+	// Keep the parse when the revision did not change across the re-fetch
+	if ok && entry.parsed != nil && entry.resource.UpdatedAt.Equal(resource.UpdatedAt) {
+		fresh.parsed = entry.parsed
+	}
+	resolverCache.entries[key] = fresh
+	resolverCache.Unlock()
+	return resource, nil
+}
+
+// parseResolved parses spec bytes for a resource, reusing the cached parse
+// when the bytes are the resource's current spec. Variant specs — canary
+// rollbacks and replay snapshots — parse uncached, since they are not the
+// stored revision.
+func parseResolved(resource *models.Resource, data []byte) (crd.Resource, error) {
+	key := resolverKey{resource.WorkspaceID, resource.Name, resource.Type}
+	current := bytes.Equal(data, []byte(resource.Spec))
+
+	if current {
+		resolverCache.Lock()
+		entry, ok := resolverCache.entries[key]
+		resolverCache.Unlock()
+		if ok && entry.parsed != nil && entry.resource.UpdatedAt.Equal(resource.UpdatedAt) {
+			return entry.parsed, nil
+		}
+	}
+
+	parsed, err := crd.NewParser().Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if current {
+		resolverCache.Lock()
+		if entry, ok := resolverCache.entries[key]; ok && entry.resource.UpdatedAt.Equal(resource.UpdatedAt) {
+			entry.parsed = parsed
+		}
+		resolverCache.Unlock()
+	}
+	return parsed, nil
+}
+
+// InvalidateResource drops a cached resolution after a write, so the next
+// execution in this process sees the new revision immediately instead of
+// after the TTL
+func InvalidateResource(workspaceID uint, name string, resourceType models.ResourceType) {
+	resolverCache.Lock()
+	delete(resolverCache.entries, resolverKey{workspaceID, name, resourceType})
+	resolverCache.Unlock()
+}
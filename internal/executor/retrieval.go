@@ -0,0 +1,99 @@
+package executor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// retrievalTopK caps the passages injected per oversized attachment
+	retrievalTopK = 4
+
+	// retrievalTimeout bounds the query embedding call
+	retrievalTimeout = 15 * time.Second
+)
+
+// ConfigureEmbeddings enables semantic retrieval over indexed attachment
+// chunks using the given embeddings provider; an empty provider leaves
+// attachments on the inline truncation path
+func (e *AgnoExecutor) ConfigureEmbeddings(cfg llm.Config) {
+	e.embedder = newEmbedder(cfg, e.logger)
+}
+
+// ConfigureEmbeddings is the native executor's counterpart of the agno
+// executor's method
+func (e *Executor) ConfigureEmbeddings(cfg llm.Config) {
+	e.embedder = newEmbedder(cfg, e.logger)
+}
+
+// newEmbedder builds the embeddings client behind both ConfigureEmbeddings
+// variants, logging rather than failing when the provider is unusable
+func newEmbedder(cfg llm.Config, logger *zap.Logger) llm.Embeddings {
+	if cfg.Provider == "" {
+		return nil
+	}
+	embedder, err := llm.NewEmbeddings(&cfg)
+	if err != nil {
+		logger.Warn("Semantic attachment retrieval disabled", zap.Error(err))
+		return nil
+	}
+	return embedder
+}
+
+// retrieveAttachmentPassages finds the indexed chunks most relevant to the
+// task prompt for attachments too large to inline. A nil result (no embedder,
+// nothing indexed, or any failure) sends the caller down the truncation path.
+func retrieveAttachmentPassages(store *storage.Storage, embedder llm.Embeddings, task *models.Task, attachments []*models.Attachment) map[uint][]*models.VectorChunk {
+	if embedder == nil {
+		return nil
+	}
+
+	bySource := make(map[string]uint)
+	var sources []string
+	for _, att := range attachments {
+		if att.Status != models.AttachmentStatusCompleted || len(att.TextContent) <= maxAttachmentChars {
+			continue
+		}
+		source := attachment.VectorSource(att.ID)
+		bySource[source] = att.ID
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), retrievalTimeout)
+	defer cancel()
+
+	embeddings, err := embedder.Embed(ctx, []string{task.Prompt})
+	if err != nil || len(embeddings) != 1 {
+		return nil
+	}
+
+	chunks, err := store.Vectors().SearchSources(task.WorkspaceID, sources, embeddings[0], retrievalTopK*len(sources))
+	if err != nil || len(chunks) == 0 {
+		return nil
+	}
+
+	passages := make(map[uint][]*models.VectorChunk)
+	for _, chunk := range chunks {
+		id, ok := bySource[chunk.Source]
+		if !ok || len(passages[id]) >= retrievalTopK {
+			continue
+		}
+		passages[id] = append(passages[id], chunk)
+	}
+
+	// Passages read better in document order than in rank order
+	for _, list := range passages {
+		sort.Slice(list, func(i, j int) bool { return list[i].ChunkIndex < list[j].ChunkIndex })
+	}
+	return passages
+}
@@ -0,0 +1,46 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// resolveSecretKeyRef reads one key from a Secret resource's data. Values
+// are stored encrypted and decrypted here, at the point of use.
+func resolveSecretKeyRef(store *storage.Storage, workspaceID uint, ref *crd.SecretKeyRef) (string, error) {
+	if ref.SecretRef == "" || ref.Key == "" {
+		return "", fmt.Errorf("secret reference needs both secretRef and key")
+	}
+
+	resource, err := resolveResource(store, workspaceID, ref.SecretRef, models.ResourceTypeSecret)
+	if err != nil {
+		return "", fmt.Errorf("secret %q not found: %w", ref.SecretRef, err)
+	}
+
+	parsed, err := parseResolved(resource, []byte(resource.Spec))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse secret %q: %w", ref.SecretRef, err)
+	}
+	secret, ok := parsed.(*crd.Secret)
+	if !ok {
+		return "", fmt.Errorf("resource %q is not a Secret", ref.SecretRef)
+	}
+
+	value, ok := secret.Spec.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", ref.SecretRef, ref.Key)
+	}
+	return models.DecryptSensitive(value), nil
+}
+
+// resolveMindAPIKey returns a mind's API key, following an api_key_from
+// reference when one is set; otherwise the inline api_key is used
+func resolveMindAPIKey(store *storage.Storage, workspaceID uint, spec *crd.MindSpec) (string, error) {
+	if spec.APIKeyFrom != nil {
+		return resolveSecretKeyRef(store, workspaceID, spec.APIKeyFrom)
+	}
+	return models.DecryptSensitive(spec.APIKey), nil
+}
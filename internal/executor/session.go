@@ -0,0 +1,86 @@
+package executor
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// defaultMaxHistory bounds recalled turns when the robot spec does not set
+// max_history
+const defaultMaxHistory = 20
+
+// sessionHistory loads a robot's persistent session as chat messages,
+// ready to slot between the system prompt and the task prompt: the running
+// summary (when the compactor has built one) followed by the turns it does
+// not cover yet. Robots without a session_id keep no memory and get nil.
+func (e *Executor) sessionHistory(robot *crd.Robot) []llm.Message {
+	sessionID := robot.Spec.SessionID
+	if sessionID == "" {
+		return nil
+	}
+
+	session, err := e.storage.Sessions().GetBySessionID(sessionID)
+	if err != nil {
+		return nil
+	}
+
+	maxHistory := robot.Spec.MaxHistory
+	if maxHistory <= 0 {
+		maxHistory = defaultMaxHistory
+	}
+
+	stored, err := e.storage.Sessions().GetRecentMessages(sessionID, maxHistory)
+	if err != nil {
+		return nil
+	}
+
+	var messages []llm.Message
+	if session.Summary != "" {
+		messages = append(messages, llm.Message{
+			Role:    "system",
+			Content: "Summary of the earlier conversation:\n" + session.Summary,
+		})
+	}
+	for _, message := range stored {
+		// Turns the summary already absorbed are not replayed verbatim
+		if message.ID <= session.SummarizedUpTo {
+			continue
+		}
+		messages = append(messages, llm.Message{Role: message.Role, Content: message.Content})
+	}
+
+	return messages
+}
+
+// recordSessionTurn persists a task's prompt and response on the robot's
+// session so later tasks recall them
+func (e *Executor) recordSessionTurn(task *models.Task, robot *crd.Robot, prompt, response string) {
+	sessionID := robot.Spec.SessionID
+	if sessionID == "" {
+		return
+	}
+
+	if _, err := e.storage.Sessions().GetBySessionID(sessionID); err != nil {
+		if err := e.storage.Sessions().Create(&models.Session{
+			SessionID: sessionID,
+			UserID:    task.UserID,
+			AgentID:   task.ResourceName,
+		}); err != nil {
+			e.logger.Warn("Failed to create robot session",
+				zap.String("session_id", sessionID), zap.Error(err))
+			return
+		}
+	}
+
+	for _, message := range []*models.Message{
+		{SessionID: sessionID, Role: "user", Content: prompt},
+		{SessionID: sessionID, Role: "assistant", Content: response},
+	} {
+		if err := e.storage.Sessions().AddMessage(message); err != nil {
+			e.logger.Warn("Failed to record session message",
+				zap.String("session_id", sessionID), zap.Error(err))
+		}
+	}
+}
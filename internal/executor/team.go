@@ -0,0 +1,301 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// maxCoordinateRounds bounds the leader-member loop in coordinate mode so a
+// leader that is never satisfied cannot spin forever
+const maxCoordinateRounds = 3
+
+// teamAgent is one robot resolved for native team execution: its persona
+// and a ready LLM client
+type teamAgent struct {
+	name   string
+	soul   *crd.Soul
+	client llm.Client
+	chain  *llm.FallbackChain
+}
+
+// loadTeamAgent resolves a robot with its soul and mind into a runnable
+// team agent
+func (e *Executor) loadTeamAgent(task *models.Task, robotName string, callback ProgressCallback) (*teamAgent, error) {
+	robotResource, err := resolveResource(e.storage, task.WorkspaceID, robotName, models.ResourceTypeRobot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load robot %s: %w", robotName, err)
+	}
+	robotDef, err := parseResolved(robotResource, resolveSpec(task, robotResource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse robot %s: %w", robotName, err)
+	}
+	robot, ok := robotDef.(*crd.Robot)
+	if !ok {
+		return nil, fmt.Errorf("invalid robot resource: %s", robotName)
+	}
+
+	soulResource, err := resolveResource(e.storage, task.WorkspaceID, robot.Spec.Soul, models.ResourceTypeSoul)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load soul for %s: %w", robotName, err)
+	}
+	soulDef, err := parseResolved(soulResource, resolveSpec(task, soulResource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse soul for %s: %w", robotName, err)
+	}
+	soul := soulDef.(*crd.Soul)
+
+	mindResource, err := resolveResource(e.storage, task.WorkspaceID, robot.Spec.Mind, models.ResourceTypeMind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mind for %s: %w", robotName, err)
+	}
+	mindDef, err := parseResolved(mindResource, resolveSpec(task, mindResource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mind for %s: %w", robotName, err)
+	}
+	mind := mindDef.(*crd.Mind)
+
+	client, chain, err := e.mindClient(task, mind, callback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LLM client for %s: %w", robotName, err)
+	}
+
+	return &teamAgent{name: robotName, soul: soul, client: client, chain: chain}, nil
+}
+
+// loadTeamAgents resolves the team's members, skipping (with a warning) the
+// ones that fail to load so one broken member does not block the team
+func (e *Executor) loadTeamAgents(task *models.Task, names []string, callback ProgressCallback) []*teamAgent {
+	agents := make([]*teamAgent, 0, len(names))
+	for _, name := range names {
+		agent, err := e.loadTeamAgent(task, name, callback)
+		if err != nil {
+			e.logger.Warn("Failed to load team member, skipping",
+				zap.String("member", name), zap.Error(err))
+			continue
+		}
+		agents = append(agents, agent)
+	}
+	return agents
+}
+
+// runTeamAgent streams one agent turn, recording it as a SubTask and
+// tagging every emitted chunk with the agent's name
+func (e *Executor) runTeamAgent(ctx context.Context, task *models.Task, agent *teamAgent, title, prompt string, stepOrder int, callback ProgressCallback) (string, error) {
+	subtask := &models.SubTask{
+		TaskID:    task.ID,
+		Status:    models.TaskStatusRunning,
+		Title:     title,
+		AgentID:   agent.name,
+		StepOrder: stepOrder,
+	}
+	if err := e.storage.Tasks().CreateSubTask(subtask); err != nil {
+		e.logger.Warn("Failed to create team subtask", zap.Error(err))
+		subtask = nil
+	}
+
+	// Progress rolls up from the subtasks recorded so far rather than
+	// fixed per-phase numbers
+	progress := taskRollup(e.storage, task.ID, 20)
+
+	if callback != nil {
+		callback(task.ID, progress, models.TaskStatusRunning, fmt.Sprintf("%s: %s", agent.name, title), map[string]interface{}{
+			"type":  "member_start",
+			"agent": agent.name,
+		})
+	}
+
+	messages := []llm.Message{
+		{Role: "system", Content: agent.soul.Spec.Personality},
+		{Role: "user", Content: prompt},
+	}
+
+	var response string
+	err := agent.client.Stream(ctx, messages, func(chunk string) error {
+		response += chunk
+		if callback != nil {
+			callback(task.ID, progress, models.TaskStatusRunning, chunk, map[string]interface{}{
+				"type":  "content",
+				"agent": agent.name,
+			})
+		}
+		return nil
+	})
+
+	if subtask != nil {
+		if err != nil {
+			subtask.Status = models.TaskStatusFailed
+			subtask.Error = err.Error()
+		} else {
+			subtask.Status = models.TaskStatusCompleted
+			subtask.Progress = 100
+			subtask.Result = response
+		}
+		if updateErr := e.storage.Tasks().UpdateSubTask(subtask); updateErr != nil {
+			e.logger.Warn("Failed to update team subtask", zap.Error(updateErr))
+		}
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("member %s failed: %w", agent.name, err)
+	}
+	return response, nil
+}
+
+// memberRoster describes the team to a routing or coordinating agent, one
+// line per member with a persona excerpt
+func memberRoster(agents []*teamAgent) string {
+	var roster strings.Builder
+	for _, agent := range agents {
+		persona := agent.soul.Spec.Personality
+		if idx := strings.IndexAny(persona, ".\n"); idx > 0 {
+			persona = persona[:idx]
+		}
+		fmt.Fprintf(&roster, "- %s: %s\n", agent.name, strings.TrimSpace(persona))
+	}
+	return roster.String()
+}
+
+// executeTeamRoute picks the single best-suited member via a routing prompt
+// and hands the task to that member alone
+func (e *Executor) executeTeamRoute(ctx context.Context, task *models.Task, router *teamAgent, members []*teamAgent, prompt string, callback ProgressCallback) (string, error) {
+	routingPrompt := fmt.Sprintf(
+		"You are routing a task to the best-suited member of your team.\n\nTeam members:\n%s\nTask:\n%s\n\nReply with only the name of the single best-suited member.",
+		memberRoster(members), prompt)
+
+	decision, err := e.runTeamAgent(ctx, task, router, "route task", routingPrompt, 0, callback)
+	if err != nil {
+		return "", err
+	}
+
+	// Match the reply against member names; an unparseable decision falls
+	// back to the first member rather than failing the task
+	chosen := members[0]
+	for _, member := range members {
+		if strings.Contains(strings.ToLower(decision), strings.ToLower(member.name)) {
+			chosen = member
+			break
+		}
+	}
+
+	if callback != nil {
+		callback(task.ID, taskRollup(e.storage, task.ID, 50), models.TaskStatusRunning, fmt.Sprintf("Routed to %s", chosen.name), map[string]interface{}{
+			"type":  "routed",
+			"agent": chosen.name,
+		})
+	}
+
+	result, err := e.runTeamAgent(ctx, task, chosen, "execute routed task", prompt, 1, callback)
+	if err != nil {
+		return "", err
+	}
+	e.reportServedModel(task, chosen.chain, callback)
+	return result, nil
+}
+
+// executeTeamCollaborate runs every member on the task in parallel, then
+// has the synthesizer merge their contributions into one answer
+func (e *Executor) executeTeamCollaborate(ctx context.Context, task *models.Task, synthesizer *teamAgent, members []*teamAgent, prompt string, callback ProgressCallback) (string, error) {
+	results := make([]string, len(members))
+	errs := make([]error, len(members))
+
+	var wg sync.WaitGroup
+	for i, member := range members {
+		wg.Add(1)
+		go func(i int, member *teamAgent) {
+			defer wg.Done()
+			results[i], errs[i] = e.runTeamAgent(ctx, task, member, "contribute", prompt, i, callback)
+		}(i, member)
+	}
+	wg.Wait()
+
+	var contributions strings.Builder
+	succeeded := 0
+	for i, member := range members {
+		if errs[i] != nil {
+			e.logger.Warn("Team member failed, synthesizing without it",
+				zap.String("member", member.name), zap.Error(errs[i]))
+			continue
+		}
+		fmt.Fprintf(&contributions, "## %s\n%s\n\n", member.name, results[i])
+		succeeded++
+	}
+	if succeeded == 0 {
+		return "", fmt.Errorf("all %d team members failed: %w", len(members), errs[0])
+	}
+
+	synthesisPrompt := fmt.Sprintf(
+		"Your team members each worked on the task below. Synthesize their contributions into a single coherent answer.\n\nTask:\n%s\n\nContributions:\n%s",
+		prompt, contributions.String())
+
+	result, err := e.runTeamAgent(ctx, task, synthesizer, "synthesize contributions", synthesisPrompt, len(members), callback)
+	if err != nil {
+		return "", err
+	}
+	e.reportServedModel(task, synthesizer.chain, callback)
+	return result, nil
+}
+
+// executeTeamCoordinate loops the leader against the members: the leader
+// assigns instructions, members execute, and the leader reviews until it
+// declares the answer final or the round budget runs out
+func (e *Executor) executeTeamCoordinate(ctx context.Context, task *models.Task, leader *teamAgent, members []*teamAgent, prompt string, callback ProgressCallback) (string, error) {
+	instructions := ""
+	review := ""
+	step := 0
+
+	for round := 1; round <= maxCoordinateRounds; round++ {
+		assignPrompt := fmt.Sprintf(
+			"You are coordinating your team on the task below. Give each member one clear instruction for this round, addressed by name.\n\nTeam members:\n%s\nTask:\n%s",
+			memberRoster(members), prompt)
+		if review != "" {
+			assignPrompt += fmt.Sprintf("\n\nYour review of the previous round:\n%s", review)
+		}
+
+		var err error
+		instructions, err = e.runTeamAgent(ctx, task, leader, fmt.Sprintf("assign round %d", round), assignPrompt, step, callback)
+		if err != nil {
+			return "", err
+		}
+		step++
+
+		var outputs strings.Builder
+		for _, member := range members {
+			memberPrompt := fmt.Sprintf(
+				"Your team leader is coordinating the task below. Carry out the instructions addressed to you (%s).\n\nTask:\n%s\n\nLeader instructions:\n%s",
+				member.name, prompt, instructions)
+			output, err := e.runTeamAgent(ctx, task, member, fmt.Sprintf("execute round %d", round), memberPrompt, step, callback)
+			if err != nil {
+				e.logger.Warn("Team member failed, coordinating without it",
+					zap.String("member", member.name), zap.Error(err))
+				continue
+			}
+			fmt.Fprintf(&outputs, "## %s\n%s\n\n", member.name, output)
+			step++
+		}
+
+		reviewPrompt := fmt.Sprintf(
+			"Review your team's work on the task below. If the task is complete, reply with FINAL: followed by the finished answer. Otherwise describe what must change next round.\n\nTask:\n%s\n\nMember outputs:\n%s",
+			prompt, outputs.String())
+		review, err = e.runTeamAgent(ctx, task, leader, fmt.Sprintf("review round %d", round), reviewPrompt, step, callback)
+		if err != nil {
+			return "", err
+		}
+		step++
+
+		if final, done := strings.CutPrefix(strings.TrimSpace(review), "FINAL:"); done {
+			e.reportServedModel(task, leader.chain, callback)
+			return strings.TrimSpace(final), nil
+		}
+	}
+
+	// The round budget ran out; the last review is the best answer we have
+	e.reportServedModel(task, leader.chain, callback)
+	return review, nil
+}
@@ -0,0 +1,147 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// templateVarPattern matches {{variable}} placeholders in task prompts
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_.-]+)\s*\}\}`)
+
+// ResolveTemplateVars expands {{variable}} placeholders in a task prompt.
+// Variables are resolved in order of precedence: task parameters, git
+// context (branch, repo name, url), then workspace defaults. Unknown
+// variables are left untouched so literal braces survive.
+func ResolveTemplateVars(prompt string, task *models.Task, store *storage.Storage) string {
+	if !strings.Contains(prompt, "{{") {
+		return prompt
+	}
+
+	vars := promptVars(task, store)
+
+	return templateVarPattern.ReplaceAllStringFunc(prompt, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// promptVars assembles the variables visible to a prompt: built-ins
+// (workspace, date, git context, attachment summary) overridden by the
+// task's own parameters
+func promptVars(task *models.Task, store *storage.Storage) map[string]string {
+	vars := map[string]string{
+		"date": time.Now().Format("2006-01-02"),
+		"time": time.Now().Format("15:04"),
+	}
+
+	// Workspace defaults (lowest precedence)
+	if store != nil {
+		if workspace, err := store.Workspaces().GetByID(task.WorkspaceID); err == nil {
+			vars["workspace"] = workspace.Name
+			vars["workspace_name"] = workspace.Name
+		}
+		if attachments, err := store.Attachments().ListByTask(task.ID); err == nil && len(attachments) > 0 {
+			names := make([]string, len(attachments))
+			for i, attachment := range attachments {
+				names[i] = attachment.Filename
+			}
+			vars["attachments"] = strings.Join(names, ", ")
+		}
+	}
+
+	// Git context
+	if task.GitURL != "" {
+		vars["git_url"] = task.GitURL
+		vars["repo_name"] = repoNameFromURL(task.GitURL)
+	}
+	if task.BranchName != "" {
+		vars["branch"] = task.BranchName
+		vars["git_branch"] = task.BranchName
+	}
+
+	// Task parameters (highest precedence)
+	if task.Parameters != "" {
+		var params map[string]string
+		if err := json.Unmarshal([]byte(task.Parameters), &params); err == nil {
+			for k, v := range params {
+				vars[k] = v
+			}
+		}
+	}
+
+	return vars
+}
+
+// goTemplateMarkers distinguish Go template actions from plain {{variable}}
+// placeholders
+var goTemplateMarkers = []string{
+	"{{.", "{{ .", "{{-",
+	"{{if", "{{ if", "{{range", "{{ range", "{{with", "{{ with",
+}
+
+// usesGoTemplate reports whether a prompt relies on Go template actions
+func usesGoTemplate(prompt string) bool {
+	for _, marker := range goTemplateMarkers {
+		if strings.Contains(prompt, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePromptTemplate checks a Go-templated prompt at submission time so
+// syntax errors fail the request instead of the task hours later. Plain
+// {{variable}} prompts always pass: unknown placeholders survive rendering
+// untouched.
+func ValidatePromptTemplate(prompt string) error {
+	if !usesGoTemplate(prompt) {
+		return nil
+	}
+	if _, err := template.New("prompt").Parse(prompt); err != nil {
+		return fmt.Errorf("invalid prompt template: %w", err)
+	}
+	return nil
+}
+
+// RenderPrompt expands a task prompt. Prompts using Go template actions
+// ({{.variable}}, conditionals, loops) render with text/template against
+// the same variables as the legacy syntax; everything else goes through
+// ResolveTemplateVars. Render failures fall back to the raw prompt so a
+// task never dies on a template the API already accepted.
+func RenderPrompt(prompt string, task *models.Task, store *storage.Storage) string {
+	if !usesGoTemplate(prompt) {
+		return ResolveTemplateVars(prompt, task, store)
+	}
+
+	tmpl, err := template.New("prompt").Parse(prompt)
+	if err != nil {
+		return prompt
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, promptVars(task, store)); err != nil {
+		return prompt
+	}
+	return buf.String()
+}
+
+// repoNameFromURL extracts the repository name from a git URL
+func repoNameFromURL(gitURL string) string {
+	name := strings.TrimSuffix(gitURL, ".git")
+	name = strings.TrimSuffix(name, "/")
+	if idx := strings.LastIndexAny(name, "/:"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
@@ -0,0 +1,104 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/tools"
+	"go.uber.org/zap"
+)
+
+// maxToolIterations bounds the call-execute-feed-back loop so a confused
+// model cannot spin forever
+const maxToolIterations = 8
+
+// runToolLoop drives a tool-calling conversation: the model streams content
+// and may request tool calls, which are executed through the registry and
+// fed back as role "tool" messages until the model answers without calls.
+func (e *Executor) runToolLoop(ctx context.Context, client llm.Client, messages []llm.Message, registry *tools.Registry, task *models.Task, callback ProgressCallback) (string, error) {
+	defs := registry.Definitions()
+	var fullResponse string
+
+	for iteration := 0; iteration < maxToolIterations; iteration++ {
+		// Abort once the running usage estimate crosses the task's budget
+		if task.MaxTokens > 0 {
+			used := estimateTokens(task.Prompt + fullResponse)
+			if used > task.MaxTokens {
+				return "", fmt.Errorf("%w: task used ~%d of %d budgeted tokens",
+					errBudgetExceeded, used, task.MaxTokens)
+			}
+		}
+
+		resp, err := client.StreamWithTools(ctx, messages, defs, func(event llm.StreamEvent) error {
+			if event.Content != "" {
+				fullResponse += event.Content
+				if callback != nil {
+					callback(task.ID, 60, models.TaskStatusRunning, event.Content, map[string]interface{}{
+						"type": "content",
+					})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("LLM call failed: %w", err)
+		}
+
+		if len(resp.ToolCalls) == 0 {
+			return fullResponse, nil
+		}
+
+		messages = append(messages, llm.Message{
+			Role:      "assistant",
+			Content:   resp.Content,
+			ToolCalls: resp.ToolCalls,
+		})
+		for _, call := range resp.ToolCalls {
+			messages = append(messages, llm.Message{
+				Role:       "tool",
+				Content:    e.executeToolCall(ctx, registry, call, task, callback),
+				ToolCallID: call.ID,
+				Name:       call.Name,
+			})
+		}
+	}
+
+	return "", fmt.Errorf("tool loop did not converge after %d iterations", maxToolIterations)
+}
+
+// executeToolCall runs one requested tool call. Failures are returned as
+// text so the model can see what went wrong and recover.
+func (e *Executor) executeToolCall(ctx context.Context, registry *tools.Registry, call llm.ToolCall, task *models.Task, callback ProgressCallback) string {
+	if callback != nil {
+		callback(task.ID, 60, models.TaskStatusRunning,
+			fmt.Sprintf("Calling tool %s", call.Name), map[string]interface{}{
+				"type": "tool_call",
+				"tool": call.Name,
+			})
+	}
+
+	tool, ok := registry.Get(call.Name)
+	if !ok {
+		return fmt.Sprintf("error: unknown tool %s", call.Name)
+	}
+
+	args := make(map[string]interface{})
+	if call.Arguments != "" {
+		if err := json.Unmarshal([]byte(call.Arguments), &args); err != nil {
+			return fmt.Sprintf("error: invalid arguments: %v", err)
+		}
+	}
+
+	output, err := tool.Execute(ctx, args)
+	if err != nil {
+		e.logger.Warn("Tool call failed",
+			zap.Uint("task_id", task.ID),
+			zap.String("tool", call.Name),
+			zap.Error(err))
+		return fmt.Sprintf("error: %v", err)
+	}
+	return output
+}
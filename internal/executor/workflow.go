@@ -0,0 +1,399 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+const (
+	// workflowHTTPTimeout bounds a single http step
+	workflowHTTPTimeout = 60 * time.Second
+
+	// workflowOutputLimit caps what a shell or http step may contribute as
+	// output, keeping bindings and results bounded
+	workflowOutputLimit = 1 << 20
+
+	// approvalPollInterval is how often a waiting approval step re-reads its
+	// subtask; approvalDefaultTimeout applies when the step sets none
+	approvalPollInterval   = 5 * time.Second
+	approvalDefaultTimeout = time.Hour
+)
+
+// stepOutputPattern matches {{steps.<name>.output}} bindings in step fields
+var stepOutputPattern = templateVarPattern
+
+// executeWorkflow runs a Workflow resource: a DAG of typed steps (robot
+// runs, shell commands, HTTP calls, approvals) materialized as SubTasks.
+// Independent steps run concurrently; each step's fields may bind earlier
+// outputs via {{steps.<name>.output}}. Steps whose dependencies failed are
+// cancelled, mirroring executeCollaboration.
+func (e *AgnoExecutor) executeWorkflow(ctx context.Context, task *models.Task, callback ProgressCallback) (string, string, error) {
+	workflowResource, err := resolveResource(e.storage, task.WorkspaceID, task.ResourceName, models.ResourceTypeWorkflow)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	resource, err := parseResolved(workflowResource, resolveSpec(task, workflowResource))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse workflow spec: %w", err)
+	}
+
+	workflow, ok := resource.(*crd.Workflow)
+	if !ok {
+		return "", "", fmt.Errorf("invalid workflow resource")
+	}
+
+	steps := normalizeWorkflowSteps(workflow)
+
+	// Create one subtask per step up front so clients see the full plan
+	subtasks := make(map[string]*models.SubTask, len(steps))
+	for i, step := range steps {
+		subtask := &models.SubTask{
+			TaskID:    task.ID,
+			Status:    models.TaskStatusPending,
+			Title:     step.Name,
+			AgentID:   step.Robot,
+			StepOrder: i,
+		}
+		if err := e.storage.Tasks().CreateSubTask(subtask); err != nil {
+			return "", "", fmt.Errorf("failed to create subtask for step %s: %w", step.Name, err)
+		}
+		subtasks[step.Name] = subtask
+	}
+
+	var (
+		mu       sync.Mutex
+		outcomes = make(map[string]stepOutcome, len(steps))
+		logs     []string
+	)
+
+	basePrompt := RenderPrompt(task.Prompt, task, e.storage) + attachmentContext(e.storage, e.embedder, task, callback)
+
+	for len(outcomes) < len(steps) {
+		ready, skipped := schedulableWorkflowSteps(steps, outcomes)
+
+		for _, step := range skipped {
+			outcomes[step.Name] = stepOutcome{status: models.TaskStatusCancelled}
+			subtask := subtasks[step.Name]
+			subtask.Status = models.TaskStatusCancelled
+			e.storage.Tasks().UpdateSubTask(subtask)
+		}
+
+		if len(ready) == 0 {
+			if len(skipped) == 0 {
+				return "", "", fmt.Errorf("workflow %s has a dependency cycle", workflow.Metadata.Name)
+			}
+			continue
+		}
+
+		var wg sync.WaitGroup
+		for _, step := range ready {
+			wg.Add(1)
+			go func(step crd.WorkflowStep) {
+				defer wg.Done()
+
+				subtask := subtasks[step.Name]
+				subtask.Status = models.TaskStatusRunning
+				e.storage.Tasks().UpdateSubTask(subtask)
+
+				if callback != nil {
+					callback(task.ID, taskRollup(e.storage, task.ID, 10), models.TaskStatusRunning,
+						fmt.Sprintf("Running step %s (%s)", step.Name, step.Type), map[string]interface{}{
+							"type":       "step_started",
+							"step_type":  string(step.Type),
+							"subtask_id": subtask.ID,
+						})
+				}
+
+				mu.Lock()
+				bound := bindWorkflowStep(step, outcomes)
+				mu.Unlock()
+
+				result, eventLog, stepErr := e.runWorkflowStep(ctx, task, bound, basePrompt, subtask, callback)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if eventLog != "" {
+					logs = append(logs, eventLog)
+				}
+
+				if stepErr != nil {
+					outcomes[step.Name] = stepOutcome{status: models.TaskStatusFailed}
+					subtask.Status = models.TaskStatusFailed
+					subtask.Error = stepErr.Error()
+				} else {
+					outcomes[step.Name] = stepOutcome{status: models.TaskStatusCompleted, result: result}
+					subtask.Status = models.TaskStatusCompleted
+					subtask.Result = result
+					subtask.Progress = 100
+				}
+				e.storage.Tasks().UpdateSubTask(subtask)
+			}(step)
+		}
+		wg.Wait()
+	}
+
+	var failed []string
+	for name, outcome := range outcomes {
+		if outcome.status == models.TaskStatusFailed {
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return "", strings.Join(logs, "\n"), fmt.Errorf("workflow steps failed: %s", strings.Join(failed, ", "))
+	}
+
+	return workflowResult(steps, outcomes), strings.Join(logs, "\n"), nil
+}
+
+// runWorkflowStep dispatches a step to its typed executor
+func (e *AgnoExecutor) runWorkflowStep(ctx context.Context, task *models.Task, step crd.WorkflowStep, basePrompt string, subtask *models.SubTask, callback ProgressCallback) (string, string, error) {
+	switch step.Type {
+	case crd.StepTypeRobot:
+		prompt := step.Prompt
+		if prompt == "" {
+			prompt = basePrompt
+		}
+		config, err := e.buildRobotConfig(task, step.Robot, prompt)
+		if err != nil {
+			return "", "", err
+		}
+		// Give each step its own session so agents don't share history
+		config.SessionID = fmt.Sprintf("task-%d-step-%s", task.ID, step.Name)
+		return e.runAgnoScript(ctx, *config, task.ID, callback)
+
+	case crd.StepTypeShell:
+		return e.runShellStep(ctx, task, step)
+
+	case crd.StepTypeHTTP:
+		return runHTTPStep(ctx, step)
+
+	case crd.StepTypeApproval:
+		return e.waitForApproval(ctx, task, step, subtask, callback)
+
+	default:
+		return "", "", fmt.Errorf("unsupported step type: %s", step.Type)
+	}
+}
+
+// runShellStep executes a shell command in the task's prepared workspace
+// (or the server's working directory when none was prepared)
+func (e *AgnoExecutor) runShellStep(ctx context.Context, task *models.Task, step crd.WorkflowStep) (string, string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", step.Command)
+	if task.WorkDir != "" {
+		cmd.Dir = task.WorkDir
+	}
+
+	output, err := cmd.CombinedOutput()
+	if len(output) > workflowOutputLimit {
+		output = output[:workflowOutputLimit]
+	}
+	if err != nil {
+		return "", string(output), fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	return strings.TrimSpace(string(output)), "", nil
+}
+
+// runHTTPStep performs an HTTP call and returns the response body
+func runHTTPStep(ctx context.Context, step crd.WorkflowStep) (string, string, error) {
+	method := strings.ToUpper(step.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if step.Body != "" {
+		body = strings.NewReader(step.Body)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, workflowHTTPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, step.URL, body)
+	if err != nil {
+		return "", "", fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	for key, value := range step.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, workflowOutputLimit))
+	if resp.StatusCode >= 400 {
+		return "", "", fmt.Errorf("step %s: %s returned %d", step.Name, step.URL, resp.StatusCode)
+	}
+	return strings.TrimSpace(string(data)), "", nil
+}
+
+// waitForApproval parks the step until an operator resolves its subtask
+// through PATCH /subtasks/:id/status: completed approves, cancelled or
+// failed rejects. Unresolved approvals fail after the step's timeout.
+func (e *AgnoExecutor) waitForApproval(ctx context.Context, task *models.Task, step crd.WorkflowStep, subtask *models.SubTask, callback ProgressCallback) (string, string, error) {
+	message := step.Message
+	if message == "" {
+		message = fmt.Sprintf("Step %s awaits approval", step.Name)
+	}
+
+	if callback != nil {
+		callback(task.ID, task.Progress, models.TaskStatusRunning, message, map[string]interface{}{
+			"type":       "approval_required",
+			"subtask_id": subtask.ID,
+		})
+	}
+
+	timeout := approvalDefaultTimeout
+	if step.TimeoutSeconds > 0 {
+		timeout = time.Duration(step.TimeoutSeconds) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		case <-time.After(approvalPollInterval):
+		}
+
+		current, err := e.storage.Tasks().GetSubTask(subtask.ID)
+		if err != nil {
+			return "", "", fmt.Errorf("step %s: %w", step.Name, err)
+		}
+		switch current.Status {
+		case models.TaskStatusCompleted:
+			return "approved", "", nil
+		case models.TaskStatusCancelled, models.TaskStatusFailed:
+			return "", "", fmt.Errorf("step %s was rejected", step.Name)
+		}
+
+		if time.Now().After(deadline) {
+			return "", "", fmt.Errorf("step %s: approval timed out after %s", step.Name, timeout)
+		}
+	}
+}
+
+// normalizeWorkflowSteps chains steps without explicit dependencies onto
+// the previous step, so plain listings run sequentially
+func normalizeWorkflowSteps(workflow *crd.Workflow) []crd.WorkflowStep {
+	steps := make([]crd.WorkflowStep, len(workflow.Spec.Steps))
+	copy(steps, workflow.Spec.Steps)
+
+	for i := range steps {
+		if len(steps[i].DependsOn) == 0 && i > 0 {
+			steps[i].DependsOn = []string{steps[i-1].Name}
+		}
+	}
+	return steps
+}
+
+// schedulableWorkflowSteps splits unfinished steps into those ready to run
+// and those to skip because a dependency did not complete
+func schedulableWorkflowSteps(steps []crd.WorkflowStep, outcomes map[string]stepOutcome) (ready, skipped []crd.WorkflowStep) {
+	for _, step := range steps {
+		if _, finished := outcomes[step.Name]; finished {
+			continue
+		}
+
+		blocked := false
+		failed := false
+		for _, dep := range step.DependsOn {
+			outcome, ok := outcomes[dep]
+			if !ok {
+				blocked = true
+				break
+			}
+			if outcome.status != models.TaskStatusCompleted {
+				failed = true
+			}
+		}
+
+		switch {
+		case blocked:
+		case failed:
+			skipped = append(skipped, step)
+		default:
+			ready = append(ready, step)
+		}
+	}
+	return ready, skipped
+}
+
+// bindWorkflowStep expands output bindings in a step's string fields:
+// {{steps.<name>.output}} anywhere, plus {{inputs.<key>}} for the step's
+// with entries (whose values are themselves expanded first)
+func bindWorkflowStep(step crd.WorkflowStep, outcomes map[string]stepOutcome) crd.WorkflowStep {
+	inputs := make(map[string]string, len(step.With))
+	for key, value := range step.With {
+		inputs[key] = expandBindings(value, outcomes, nil)
+	}
+
+	step.Prompt = expandBindings(step.Prompt, outcomes, inputs)
+	step.Command = expandBindings(step.Command, outcomes, inputs)
+	step.URL = expandBindings(step.URL, outcomes, inputs)
+	step.Body = expandBindings(step.Body, outcomes, inputs)
+	step.Message = expandBindings(step.Message, outcomes, inputs)
+	return step
+}
+
+// expandBindings substitutes {{steps.<name>.output}} and {{inputs.<key>}}
+// placeholders; unknown references are left untouched
+func expandBindings(value string, outcomes map[string]stepOutcome, inputs map[string]string) string {
+	if !strings.Contains(value, "{{") {
+		return value
+	}
+
+	return stepOutputPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := stepOutputPattern.FindStringSubmatch(match)[1]
+
+		if step, ok := strings.CutPrefix(name, "steps."); ok {
+			if step, ok = strings.CutSuffix(step, ".output"); ok {
+				if outcome, found := outcomes[step]; found {
+					return outcome.result
+				}
+			}
+			return match
+		}
+		if key, ok := strings.CutPrefix(name, "inputs."); ok {
+			if input, found := inputs[key]; found {
+				return input
+			}
+		}
+		return match
+	})
+}
+
+// workflowResult joins the outputs of terminal steps (those no other step
+// depends on), preserving declared order
+func workflowResult(steps []crd.WorkflowStep, outcomes map[string]stepOutcome) string {
+	dependedOn := make(map[string]bool)
+	for _, step := range steps {
+		for _, dep := range step.DependsOn {
+			dependedOn[dep] = true
+		}
+	}
+
+	var parts []string
+	for _, step := range steps {
+		if dependedOn[step.Name] {
+			continue
+		}
+		if outcome, ok := outcomes[step.Name]; ok && outcome.result != "" {
+			parts = append(parts, outcome.result)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,29 @@
+package executor
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// workspaceDefaults loads a workspace's execution defaults. A missing
+// workspace or empty settings blob yields zero values, so callers fall
+// through to their own behavior.
+func workspaceDefaults(store *storage.Storage, workspaceID uint) models.WorkspaceSettings {
+	if store == nil {
+		return models.WorkspaceSettings{}
+	}
+	workspace, err := store.Workspaces().GetByID(workspaceID)
+	if err != nil {
+		return models.WorkspaceSettings{}
+	}
+	return workspace.SettingsValues()
+}
+
+// defaultedMind returns the mind name a robot executes with: its own spec
+// value, or the workspace's default mind when the spec declares none
+func defaultedMind(store *storage.Storage, workspaceID uint, specMind string) string {
+	if specMind != "" {
+		return specMind
+	}
+	return workspaceDefaults(store, workspaceID).DefaultMind
+}
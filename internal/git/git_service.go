@@ -219,6 +219,17 @@ func (s *Service) CheckoutBranch(repoPath, branchName string) error {
 	return nil
 }
 
+// CurrentBranch returns the checked-out branch name
+func (s *Service) CurrentBranch(repoPath string) (string, error) {
+	args := []string{"-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD"}
+	cmd := exec.Command("git", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w, output: %s", err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // ListBranches lists all branches in a repository
 func (s *Service) ListBranches(repoPath string) ([]string, error) {
 	args := []string{"-C", repoPath, "branch", "--list"}
@@ -0,0 +1,42 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultAzureAPIVersion is used when the Mind does not pin one
+const defaultAzureAPIVersion = "2024-02-01"
+
+// AzureOpenAIClient implements the Azure-hosted OpenAI API. Azure routes
+// requests to a named deployment instead of a model parameter and
+// authenticates with an api-key header, but speaks the same chat
+// completions wire format otherwise.
+type AzureOpenAIClient struct {
+	*OpenAIClient
+	deployment string
+}
+
+// NewAzureOpenAIClient creates a client for an Azure OpenAI deployment.
+// endpoint is the resource URL (https://<resource>.openai.azure.com);
+// apiVersion defaults when empty.
+func NewAzureOpenAIClient(deployment, apiKey, endpoint, apiVersion string) *AzureOpenAIClient {
+	if apiVersion == "" {
+		apiVersion = defaultAzureAPIVersion
+	}
+
+	return &AzureOpenAIClient{
+		OpenAIClient: &OpenAIClient{
+			model:  deployment,
+			apiKey: apiKey,
+			chatURL: fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+				strings.TrimSuffix(endpoint, "/"), deployment, apiVersion),
+			apiKeyHeader: "api-key",
+		},
+		deployment: deployment,
+	}
+}
+
+func (c *AzureOpenAIClient) Name() string {
+	return "azure-openai:" + c.deployment
+}
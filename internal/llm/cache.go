@@ -0,0 +1,227 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultCacheTTL applies when a Mind enables caching without pinning a TTL
+const defaultCacheTTL = 15 * time.Minute
+
+// CacheStore holds cached responses keyed by request hash
+type CacheStore interface {
+	Get(key string) (*Response, bool)
+	Set(key string, resp *Response, ttl time.Duration)
+}
+
+// memoryCache is an in-process CacheStore with per-entry expiry and a soft
+// size cap: inserts past the cap evict expired entries first, then refuse
+type memoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]memoryCacheEntry
+	maxEntries int
+}
+
+type memoryCacheEntry struct {
+	resp      *Response
+	expiresAt time.Time
+}
+
+// NewMemoryCache creates an in-process response cache holding at most
+// maxEntries responses
+func NewMemoryCache(maxEntries int) CacheStore {
+	return &memoryCache{
+		entries:    make(map[string]memoryCacheEntry),
+		maxEntries: maxEntries,
+	}
+}
+
+func (m *memoryCache) Get(key string) (*Response, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return entry.resp, true
+}
+
+func (m *memoryCache) Set(key string, resp *Response, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.entries) >= m.maxEntries {
+		now := time.Now()
+		for k, entry := range m.entries {
+			if now.After(entry.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+		if len(m.entries) >= m.maxEntries {
+			return
+		}
+	}
+	m.entries[key] = memoryCacheEntry{resp: resp, expiresAt: time.Now().Add(ttl)}
+}
+
+// redisCache is a CacheStore backed by Redis, for sharing hits across
+// workers
+type redisCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisCache creates a Redis-backed response cache under the given key
+// prefix
+func NewRedisCache(client *redis.Client, prefix string) CacheStore {
+	return &redisCache{client: client, prefix: prefix}
+}
+
+func (r *redisCache) Get(key string) (*Response, bool) {
+	data, err := r.client.Get(context.Background(), r.prefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var resp Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+func (r *redisCache) Set(key string, resp *Response, ttl time.Duration) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	r.client.Set(context.Background(), r.prefix+key, data, ttl)
+}
+
+// cachedClient serves identical requests from the cache instead of the
+// provider. Responses with tool calls are never cached: tool results make
+// the follow-up conversation diverge anyway.
+type cachedClient struct {
+	inner   Client
+	store   CacheStore
+	ttl     time.Duration
+	onEvent func(hit bool, key string)
+}
+
+// WithCache decorates a client with response caching. onEvent (if non-nil)
+// is invoked with the hit/miss outcome of every lookup.
+func WithCache(client Client, store CacheStore, ttl time.Duration, onEvent func(hit bool, key string)) Client {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &cachedClient{inner: client, store: store, ttl: ttl, onEvent: onEvent}
+}
+
+// cacheKey hashes everything that determines the response
+func (c *cachedClient) cacheKey(messages []Message, tools []Tool) string {
+	payload, _ := json.Marshal(struct {
+		Client   string    `json:"client"`
+		Messages []Message `json:"messages"`
+		Tools    []Tool    `json:"tools,omitempty"`
+	}{c.inner.Name(), messages, tools})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// lookup consults the store and reports the outcome
+func (c *cachedClient) lookup(key string) (*Response, bool) {
+	resp, hit := c.store.Get(key)
+	if c.onEvent != nil {
+		c.onEvent(hit, key)
+	}
+	return resp, hit
+}
+
+// storeResponse caches a successful, tool-call-free response
+func (c *cachedClient) storeResponse(key string, resp *Response) {
+	if resp == nil || len(resp.ToolCalls) > 0 {
+		return
+	}
+	c.store.Set(key, resp, c.ttl)
+}
+
+func (c *cachedClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	key := c.cacheKey(messages, nil)
+	if resp, hit := c.lookup(key); hit {
+		return resp, nil
+	}
+
+	resp, err := c.inner.Chat(ctx, messages)
+	if err == nil {
+		c.storeResponse(key, resp)
+	}
+	return resp, err
+}
+
+func (c *cachedClient) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
+	key := c.cacheKey(messages, nil)
+	if resp, hit := c.lookup(key); hit {
+		// Replay the cached response as a single chunk
+		return callback(resp.Content)
+	}
+
+	var content string
+	err := c.inner.Stream(ctx, messages, func(chunk string) error {
+		content += chunk
+		return callback(chunk)
+	})
+	if err == nil {
+		c.storeResponse(key, &Response{Content: content})
+	}
+	return err
+}
+
+func (c *cachedClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
+	key := c.cacheKey(messages, tools)
+	if resp, hit := c.lookup(key); hit {
+		return resp, nil
+	}
+
+	resp, err := c.inner.ChatWithTools(ctx, messages, tools)
+	if err == nil {
+		c.storeResponse(key, resp)
+	}
+	return resp, err
+}
+
+func (c *cachedClient) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
+	key := c.cacheKey(messages, tools)
+	if resp, hit := c.lookup(key); hit {
+		if resp.Content != "" {
+			if err := callback(StreamEvent{Content: resp.Content}); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+
+	resp, err := c.inner.StreamWithTools(ctx, messages, tools, callback)
+	if err == nil {
+		c.storeResponse(key, resp)
+	}
+	return resp, err
+}
+
+func (c *cachedClient) Name() string {
+	return c.inner.Name()
+}
+
+// SetOptions forwards model tuning to the wrapped client
+func (c *cachedClient) SetOptions(opts Options) {
+	if tunable, ok := c.inner.(Tunable); ok {
+		tunable.SetOptions(opts)
+	}
+}
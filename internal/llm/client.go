@@ -2,17 +2,40 @@ package llm
 
 import (
 	"context"
+	"net/http"
 )
 
 // Message represents a chat message
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+
+	// ToolCalls carries tool invocations requested by the assistant
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID links a role "tool" result message to the originating call
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	// Name is the tool name for role "tool" messages
+	Name string `json:"name,omitempty"`
+}
+
+// Tool describes a function the model may call
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"` // JSON schema
+}
+
+// ToolCall represents a tool invocation requested by the model
+type ToolCall struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"` // JSON-encoded arguments
 }
 
 // Response represents an LLM response
 type Response struct {
 	Content      string
+	ToolCalls    []ToolCall
 	FinishReason string
 	Usage        Usage
 }
@@ -24,10 +47,30 @@ type Usage struct {
 	TotalTokens      int
 }
 
+// StreamEvent represents an incremental piece of a streaming response.
+// Content deltas arrive as they are generated; tool calls are delivered
+// once their argument deltas have been fully assembled.
+type StreamEvent struct {
+	Content  string
+	ToolCall *ToolCall
+}
+
+// StreamCallback receives streaming events
+type StreamCallback func(event StreamEvent) error
+
 // Client interface for LLM providers
 type Client interface {
 	Chat(ctx context.Context, messages []Message) (*Response, error)
 	Stream(ctx context.Context, messages []Message, callback func(string) error) error
+
+	// ChatWithTools sends a chat request with tool definitions; the response
+	// may contain ToolCalls the caller should execute and feed back as
+	// role "tool" messages.
+	ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error)
+	// StreamWithTools streams a response with tool definitions, returning
+	// the assembled final response including any tool calls.
+	StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error)
+
 	Name() string
 }
 
@@ -38,3 +81,23 @@ type Config struct {
 	APIKey   string
 	BaseURL  string
 }
+
+// Options carries per-Mind model tuning applied to every request. Zero
+// values leave the provider defaults in place.
+type Options struct {
+	Temperature float32
+	MaxTokens   int
+	Headers     map[string]string // extra HTTP headers sent with every request
+}
+
+// applyHeaders adds the configured extra headers to a request
+func (o Options) applyHeaders(req *http.Request) {
+	for key, value := range o.Headers {
+		req.Header.Set(key, value)
+	}
+}
+
+// Tunable is implemented by clients that support model tuning options
+type Tunable interface {
+	SetOptions(opts Options)
+}
@@ -0,0 +1,160 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Embeddings is implemented by providers that can turn text into vectors
+type Embeddings interface {
+	// Embed returns one embedding per input text, in input order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewEmbeddings creates an embeddings client for the given provider. Only
+// providers with an embeddings endpoint are supported.
+func NewEmbeddings(cfg *Config) (Embeddings, error) {
+	switch cfg.Provider {
+	case "openai":
+		model := cfg.Model
+		if model == "" {
+			model = "text-embedding-3-small"
+		}
+		return NewOpenAICompatibleClient(model, cfg.APIKey, cfg.BaseURL), nil
+
+	case "ollama":
+		return NewOllamaClient(cfg.Model, cfg.BaseURL), nil
+
+	default:
+		return nil, fmt.Errorf("provider %s does not support embeddings", cfg.Provider)
+	}
+}
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Embed implements the Embeddings interface via the /embeddings endpoint
+func (c *OpenAIClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(openAIEmbeddingRequest{
+		Model: c.model,
+		Input: texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.opts.applyHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result openAIEmbeddingResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("embeddings request failed: %s", result.Error.Message)
+	}
+	if len(result.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(result.Data))
+	}
+
+	// The API documents data in input order, but index is authoritative
+	embeddings := make([][]float32, len(texts))
+	for _, item := range result.Data {
+		if item.Index < 0 || item.Index >= len(embeddings) {
+			return nil, fmt.Errorf("embedding index %d out of range", item.Index)
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+	return embeddings, nil
+}
+
+type ollamaEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type ollamaEmbeddingResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// Embed implements the Embeddings interface via Ollama's /api/embed endpoint
+func (c *OllamaClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(ollamaEmbeddingRequest{
+		Model: c.model,
+		Input: texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/embed", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.opts.applyHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ollamaEmbeddingResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("embeddings request failed: %s", result.Error)
+	}
+	if len(result.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(result.Embeddings))
+	}
+	return result.Embeddings, nil
+}
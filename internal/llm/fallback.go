@@ -0,0 +1,102 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+// FallbackChain tries clients in order until one succeeds, remembering which
+// one served the last call. Chains carry per-call state (ServedBy), so build
+// one per task rather than caching them; the clients inside may be shared.
+type FallbackChain struct {
+	clients    []Client
+	onFallback func(from, to string, err error)
+	served     string
+}
+
+// NewFallbackChain builds a chain over the given clients. onFallback is
+// invoked (if non-nil) each time a client fails and the next one is tried.
+func NewFallbackChain(clients []Client, onFallback func(from, to string, err error)) *FallbackChain {
+	return &FallbackChain{clients: clients, onFallback: onFallback}
+}
+
+// ServedBy returns the Name() of the client that served the last successful
+// call, or empty before any call succeeded
+func (f *FallbackChain) ServedBy() string {
+	return f.served
+}
+
+// do walks the chain; started reports whether a client made observable
+// progress (streamed content), which rules out falling back mid-response
+func (f *FallbackChain) do(call func(client Client) (bool, error)) error {
+	var lastErr error
+	for i, client := range f.clients {
+		started, err := call(client)
+		if err == nil {
+			f.served = client.Name()
+			return nil
+		}
+		lastErr = err
+
+		if started || err == context.Canceled {
+			break
+		}
+		if f.onFallback != nil && i+1 < len(f.clients) {
+			f.onFallback(client.Name(), f.clients[i+1].Name(), err)
+		}
+	}
+	return lastErr
+}
+
+func (f *FallbackChain) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	var resp *Response
+	err := f.do(func(client Client) (bool, error) {
+		var err error
+		resp, err = client.Chat(ctx, messages)
+		return false, err
+	})
+	return resp, err
+}
+
+func (f *FallbackChain) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
+	return f.do(func(client Client) (bool, error) {
+		started := false
+		err := client.Stream(ctx, messages, func(chunk string) error {
+			started = true
+			return callback(chunk)
+		})
+		return started, err
+	})
+}
+
+func (f *FallbackChain) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
+	var resp *Response
+	err := f.do(func(client Client) (bool, error) {
+		var err error
+		resp, err = client.ChatWithTools(ctx, messages, tools)
+		return false, err
+	})
+	return resp, err
+}
+
+func (f *FallbackChain) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
+	var resp *Response
+	err := f.do(func(client Client) (bool, error) {
+		started := false
+		var err error
+		resp, err = client.StreamWithTools(ctx, messages, tools, func(event StreamEvent) error {
+			started = true
+			return callback(event)
+		})
+		return started, err
+	})
+	return resp, err
+}
+
+// Name identifies the chain by its primary client
+func (f *FallbackChain) Name() string {
+	if len(f.clients) == 0 {
+		return "fallback-chain"
+	}
+	return fmt.Sprintf("%s (+%d fallbacks)", f.clients[0].Name(), len(f.clients)-1)
+}
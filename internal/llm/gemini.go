@@ -16,6 +16,7 @@ type GeminiClient struct {
 	model   string
 	apiKey  string
 	baseURL string
+	opts    Options
 }
 
 // NewGeminiClient creates a new Gemini client
@@ -27,8 +28,26 @@ func NewGeminiClient(model, apiKey string) *GeminiClient {
 	}
 }
 
+// SetOptions applies per-Mind model tuning to subsequent requests
+func (c *GeminiClient) SetOptions(opts Options) {
+	c.opts = opts
+}
+
+// generationConfig renders the tuning options in Gemini's format, or nil
+// when everything is left at the provider default
+func (c *GeminiClient) generationConfig() *geminiGenerationConfig {
+	if c.opts.Temperature == 0 && c.opts.MaxTokens == 0 {
+		return nil
+	}
+	return &geminiGenerationConfig{
+		Temperature:     c.opts.Temperature,
+		MaxOutputTokens: c.opts.MaxTokens,
+	}
+}
+
 type geminiRequest struct {
 	Contents         []geminiContent         `json:"contents"`
+	Tools            []geminiTool            `json:"tools,omitempty"`
 	GenerationConfig *geminiGenerationConfig `json:"generationConfig,omitempty"`
 }
 
@@ -38,7 +57,29 @@ type geminiContent struct {
 }
 
 type geminiPart struct {
-	Text string `json:"text"`
+	Text             string                  `json:"text,omitempty"`
+	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
+}
+
+type geminiFunctionCall struct {
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+type geminiFunctionResponse struct {
+	Name     string                 `json:"name"`
+	Response map[string]interface{} `json:"response"`
+}
+
+type geminiTool struct {
+	FunctionDeclarations []geminiFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type geminiFunctionDeclaration struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
 }
 
 type geminiGenerationConfig struct {
@@ -49,9 +90,7 @@ type geminiGenerationConfig struct {
 type geminiResponse struct {
 	Candidates []struct {
 		Content struct {
-			Parts []struct {
-				Text string `json:"text"`
-			} `json:"parts"`
+			Parts []geminiPart `json:"parts"`
 		} `json:"content"`
 		FinishReason string `json:"finishReason"`
 	} `json:"candidates"`
@@ -59,10 +98,15 @@ type geminiResponse struct {
 
 // Chat sends a chat request to Gemini
 func (c *GeminiClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
-	contents := convertToGeminiContents(messages)
+	return c.ChatWithTools(ctx, messages, nil)
+}
 
+// ChatWithTools sends a chat request with tool definitions
+func (c *GeminiClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
 	reqBody := geminiRequest{
-		Contents: contents,
+		Contents:         convertToGeminiContents(messages),
+		Tools:            toGeminiTools(tools),
+		GenerationConfig: c.generationConfig(),
 	}
 
 	data, err := json.Marshal(reqBody)
@@ -77,6 +121,7 @@ func (c *GeminiClient) Chat(ctx context.Context, messages []Message) (*Response,
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-goog-api-key", c.apiKey)
+	c.opts.applyHeaders(req)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -94,50 +139,78 @@ func (c *GeminiClient) Chat(ctx context.Context, messages []Message) (*Response,
 		return nil, err
 	}
 
-	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
+	if len(result.Candidates) == 0 {
 		return nil, fmt.Errorf("no response from Gemini")
 	}
 
-	return &Response{
-		Content:      result.Candidates[0].Content.Parts[0].Text,
-		FinishReason: result.Candidates[0].FinishReason,
-	}, nil
+	candidate := result.Candidates[0]
+	response := &Response{FinishReason: candidate.FinishReason}
+	for _, part := range candidate.Content.Parts {
+		if part.Text != "" {
+			response.Content += part.Text
+		}
+		if part.FunctionCall != nil {
+			response.ToolCalls = append(response.ToolCalls, geminiCallToToolCall(part.FunctionCall))
+		}
+	}
+
+	if response.Content == "" && len(response.ToolCalls) == 0 {
+		return nil, fmt.Errorf("no response from Gemini")
+	}
+
+	return response, nil
 }
 
 // Stream streams chat responses from Gemini
 func (c *GeminiClient) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
-	contents := convertToGeminiContents(messages)
+	_, err := c.StreamWithTools(ctx, messages, nil, func(event StreamEvent) error {
+		if event.Content != "" {
+			return callback(event.Content)
+		}
+		return nil
+	})
+	return err
+}
 
+// StreamWithTools streams chat responses with tool definitions
+func (c *GeminiClient) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
 	reqBody := geminiRequest{
-		Contents: contents,
+		Contents:         convertToGeminiContents(messages),
+		Tools:            toGeminiTools(tools),
+		GenerationConfig: c.generationConfig(),
 	}
 
 	data, err := json.Marshal(reqBody)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/models/%s:streamGenerateContent?alt=sse", c.baseURL, c.model)
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-goog-api-key", c.apiKey)
+	c.opts.applyHeaders(req)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("Gemini API request failed: %w", err)
+		return nil, fmt.Errorf("Gemini API request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Gemini API error: %d, %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("Gemini API error: %d, %s", resp.StatusCode, string(body))
 	}
 
+	response := &Response{}
+	var content strings.Builder
+
 	// Parse SSE stream
 	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if !strings.HasPrefix(line, "data: ") {
@@ -154,17 +227,37 @@ func (c *GeminiClient) Stream(ctx context.Context, messages []Message, callback
 			continue
 		}
 
-		if len(chunk.Candidates) > 0 && len(chunk.Candidates[0].Content.Parts) > 0 {
-			text := chunk.Candidates[0].Content.Parts[0].Text
-			if text != "" {
-				if err := callback(text); err != nil {
-					return err
+		if len(chunk.Candidates) == 0 {
+			continue
+		}
+
+		candidate := chunk.Candidates[0]
+		if candidate.FinishReason != "" {
+			response.FinishReason = candidate.FinishReason
+		}
+
+		for _, part := range candidate.Content.Parts {
+			if part.Text != "" {
+				content.WriteString(part.Text)
+				if err := callback(StreamEvent{Content: part.Text}); err != nil {
+					return nil, err
+				}
+			}
+			if part.FunctionCall != nil {
+				call := geminiCallToToolCall(part.FunctionCall)
+				response.ToolCalls = append(response.ToolCalls, call)
+				if err := callback(StreamEvent{ToolCall: &call}); err != nil {
+					return nil, err
 				}
 			}
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
-	return scanner.Err()
+	response.Content = content.String()
+	return response, nil
 }
 
 // Name returns the client name
@@ -172,6 +265,31 @@ func (c *GeminiClient) Name() string {
 	return "gemini:" + c.model
 }
 
+// geminiCallToToolCall converts a Gemini function call to the common format
+func geminiCallToToolCall(call *geminiFunctionCall) ToolCall {
+	args, _ := json.Marshal(call.Args)
+	return ToolCall{
+		Name:      call.Name,
+		Arguments: string(args),
+	}
+}
+
+// toGeminiTools converts tool definitions to Gemini format
+func toGeminiTools(tools []Tool) []geminiTool {
+	if len(tools) == 0 {
+		return nil
+	}
+	declarations := make([]geminiFunctionDeclaration, len(tools))
+	for i, t := range tools {
+		declarations[i] = geminiFunctionDeclaration{
+			Name:        t.Name,
+			Description: t.Description,
+			Parameters:  t.Parameters,
+		}
+	}
+	return []geminiTool{{FunctionDeclarations: declarations}}
+}
+
 // convertToGeminiContents converts messages to Gemini format
 func convertToGeminiContents(messages []Message) []geminiContent {
 	var contents []geminiContent
@@ -183,21 +301,57 @@ func convertToGeminiContents(messages []Message) []geminiContent {
 			continue
 		}
 
+		// Tool results are sent back as functionResponse parts
+		if msg.Role == "tool" {
+			var result map[string]interface{}
+			if err := json.Unmarshal([]byte(msg.Content), &result); err != nil {
+				result = map[string]interface{}{"result": msg.Content}
+			}
+			contents = append(contents, geminiContent{
+				Role: "user",
+				Parts: []geminiPart{{
+					FunctionResponse: &geminiFunctionResponse{
+						Name:     msg.Name,
+						Response: result,
+					},
+				}},
+			})
+			continue
+		}
+
 		role := msg.Role
 		if role == "assistant" {
 			role = "model"
 		}
 
+		var parts []geminiPart
+
 		content := msg.Content
 		if systemPrompt != "" && role == "user" {
 			// Prepend system prompt to first user message
 			content = systemPrompt + "\n\n" + content
 			systemPrompt = ""
 		}
+		if content != "" {
+			parts = append(parts, geminiPart{Text: content})
+		}
+
+		// Replay assistant tool calls as functionCall parts
+		for _, tc := range msg.ToolCalls {
+			var args map[string]interface{}
+			json.Unmarshal([]byte(tc.Arguments), &args)
+			parts = append(parts, geminiPart{
+				FunctionCall: &geminiFunctionCall{Name: tc.Name, Args: args},
+			})
+		}
+
+		if len(parts) == 0 {
+			continue
+		}
 
 		contents = append(contents, geminiContent{
 			Role:  role,
-			Parts: []geminiPart{{Text: content}},
+			Parts: parts,
 		})
 	}
 
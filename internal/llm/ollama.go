@@ -15,6 +15,7 @@ import (
 type OllamaClient struct {
 	model   string
 	baseURL string
+	opts    Options
 }
 
 // NewOllamaClient creates a new Ollama client
@@ -28,36 +29,100 @@ func NewOllamaClient(model string, baseURL string) *OllamaClient {
 	}
 }
 
+// SetOptions applies per-Mind model tuning to subsequent requests
+func (c *OllamaClient) SetOptions(opts Options) {
+	c.opts = opts
+}
+
+// modelOptions renders the tuning options in Ollama's format, or nil when
+// everything is left at the model default
+func (c *OllamaClient) modelOptions() *ollamaOptions {
+	if c.opts.Temperature == 0 && c.opts.MaxTokens == 0 {
+		return nil
+	}
+	return &ollamaOptions{
+		Temperature: c.opts.Temperature,
+		NumPredict:  c.opts.MaxTokens,
+	}
+}
+
 type ollamaRequest struct {
 	Model    string          `json:"model"`
 	Messages []ollamaMessage `json:"messages"`
+	Tools    []openAITool    `json:"tools,omitempty"` // Ollama uses the OpenAI tool schema
 	Stream   bool            `json:"stream"`
+	Options  *ollamaOptions  `json:"options,omitempty"`
+}
+
+type ollamaOptions struct {
+	Temperature float32 `json:"temperature,omitempty"`
+	NumPredict  int     `json:"num_predict,omitempty"`
 }
 
 type ollamaMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function ollamaFunctionCall `json:"function"`
+}
+
+type ollamaFunctionCall struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
 }
 
 type ollamaResponse struct {
 	Message struct {
-		Role    string `json:"role"`
-		Content string `json:"content"`
+		Role      string           `json:"role"`
+		Content   string           `json:"content"`
+		ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
 	} `json:"message"`
 	Done bool `json:"done"`
 }
 
-// Chat sends a chat request to Ollama
-func (c *OllamaClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
-	ollamaMessages := make([]ollamaMessage, len(messages))
+// toOllamaMessages converts messages to Ollama wire format. Ollama expects
+// tool call arguments as objects rather than JSON strings.
+func toOllamaMessages(messages []Message) []ollamaMessage {
+	result := make([]ollamaMessage, len(messages))
 	for i, m := range messages {
-		ollamaMessages[i] = ollamaMessage{Role: m.Role, Content: m.Content}
+		msg := ollamaMessage{Role: m.Role, Content: m.Content}
+		for _, tc := range m.ToolCalls {
+			var args map[string]interface{}
+			json.Unmarshal([]byte(tc.Arguments), &args)
+			msg.ToolCalls = append(msg.ToolCalls, ollamaToolCall{
+				Function: ollamaFunctionCall{Name: tc.Name, Arguments: args},
+			})
+		}
+		result[i] = msg
 	}
+	return result
+}
+
+// ollamaCallToToolCall converts an Ollama tool call to the common format
+func ollamaCallToToolCall(call ollamaToolCall) ToolCall {
+	args, _ := json.Marshal(call.Function.Arguments)
+	return ToolCall{
+		Name:      call.Function.Name,
+		Arguments: string(args),
+	}
+}
+
+// Chat sends a chat request to Ollama
+func (c *OllamaClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	return c.ChatWithTools(ctx, messages, nil)
+}
 
+// ChatWithTools sends a chat request with tool definitions
+func (c *OllamaClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
 	reqBody := ollamaRequest{
 		Model:    c.model,
-		Messages: ollamaMessages,
+		Messages: toOllamaMessages(messages),
+		Tools:    toOpenAITools(tools),
 		Stream:   false,
+		Options:  c.modelOptions(),
 	}
 
 	data, err := json.Marshal(reqBody)
@@ -70,6 +135,7 @@ func (c *OllamaClient) Chat(ctx context.Context, messages []Message) (*Response,
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	c.opts.applyHeaders(req)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -87,49 +153,67 @@ func (c *OllamaClient) Chat(ctx context.Context, messages []Message) (*Response,
 		return nil, err
 	}
 
-	return &Response{
+	response := &Response{
 		Content:      result.Message.Content,
 		FinishReason: "stop",
-	}, nil
+	}
+	for _, tc := range result.Message.ToolCalls {
+		response.ToolCalls = append(response.ToolCalls, ollamaCallToToolCall(tc))
+	}
+
+	return response, nil
 }
 
 // Stream streams chat responses from Ollama
 func (c *OllamaClient) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
-	ollamaMessages := make([]ollamaMessage, len(messages))
-	for i, m := range messages {
-		ollamaMessages[i] = ollamaMessage{Role: m.Role, Content: m.Content}
-	}
+	_, err := c.StreamWithTools(ctx, messages, nil, func(event StreamEvent) error {
+		if event.Content != "" {
+			return callback(event.Content)
+		}
+		return nil
+	})
+	return err
+}
 
+// StreamWithTools streams chat responses with tool definitions
+func (c *OllamaClient) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
 	reqBody := ollamaRequest{
 		Model:    c.model,
-		Messages: ollamaMessages,
+		Messages: toOllamaMessages(messages),
+		Tools:    toOpenAITools(tools),
 		Stream:   true,
+		Options:  c.modelOptions(),
 	}
 
 	data, err := json.Marshal(reqBody)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/chat", bytes.NewBuffer(data))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	c.opts.applyHeaders(req)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("Ollama connection failed (is Ollama running?): %w", err)
+		return nil, fmt.Errorf("Ollama connection failed (is Ollama running?): %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Ollama API error: %d, %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("Ollama API error: %d, %s", resp.StatusCode, string(body))
 	}
 
+	response := &Response{FinishReason: "stop"}
+	var content strings.Builder
+
 	// Parse NDJSON stream
 	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
@@ -142,8 +226,17 @@ func (c *OllamaClient) Stream(ctx context.Context, messages []Message, callback
 		}
 
 		if chunk.Message.Content != "" {
-			if err := callback(chunk.Message.Content); err != nil {
-				return err
+			content.WriteString(chunk.Message.Content)
+			if err := callback(StreamEvent{Content: chunk.Message.Content}); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, tc := range chunk.Message.ToolCalls {
+			call := ollamaCallToToolCall(tc)
+			response.ToolCalls = append(response.ToolCalls, call)
+			if err := callback(StreamEvent{ToolCall: &call}); err != nil {
+				return nil, err
 			}
 		}
 
@@ -151,8 +244,12 @@ func (c *OllamaClient) Stream(ctx context.Context, messages []Message, callback
 			break
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
-	return scanner.Err()
+	response.Content = content.String()
+	return response, nil
 }
 
 // Name returns the client name
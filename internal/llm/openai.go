@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // OpenAIClient implements OpenAI API
@@ -14,6 +15,14 @@ type OpenAIClient struct {
 	model   string
 	apiKey  string
 	baseURL string
+	opts    Options
+
+	// chatURL overrides baseURL+"/chat/completions" for providers with a
+	// different endpoint shape (Azure); empty uses the default
+	chatURL string
+	// apiKeyHeader sends the key in a named header instead of a Bearer
+	// Authorization header (Azure uses "api-key")
+	apiKeyHeader string
 }
 
 // NewOpenAIClient creates a new OpenAI client
@@ -25,6 +34,11 @@ func NewOpenAIClient(model, apiKey string) *OpenAIClient {
 	}
 }
 
+// SetOptions applies per-Mind model tuning to subsequent requests
+func (c *OpenAIClient) SetOptions(opts Options) {
+	c.opts = opts
+}
+
 // NewOpenAICompatibleClient creates a client for any OpenAI-compatible API
 func NewOpenAICompatibleClient(model, apiKey, baseURL string) *OpenAIClient {
 	if baseURL == "" {
@@ -38,15 +52,56 @@ func NewOpenAICompatibleClient(model, apiKey, baseURL string) *OpenAIClient {
 }
 
 type openAIRequest struct {
-	Model    string    `json:"model"`
-	Messages []Message `json:"messages"`
-	Stream   bool      `json:"stream"`
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	Tools       []openAITool    `json:"tools,omitempty"`
+	Stream      bool            `json:"stream"`
+	Temperature float32         `json:"temperature,omitempty"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+
+	// StreamOptions asks for a final usage chunk when streaming
+	StreamOptions *openAIStreamOptions `json:"stream_options,omitempty"`
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+	Name       string           `json:"name,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function openAIFunctionCall `json:"function"`
+}
+
+type openAIFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+type openAITool struct {
+	Type     string         `json:"type"`
+	Function openAIToolSpec `json:"function"`
+}
+
+type openAIToolSpec struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
 }
 
 type openAIResponse struct {
 	Choices []struct {
 		Message struct {
-			Content string `json:"content"`
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls,omitempty"`
 		} `json:"message"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
@@ -57,12 +112,87 @@ type openAIResponse struct {
 	} `json:"usage"`
 }
 
+// newChatRequest builds a chat completions request with the provider's URL
+// and auth scheme
+func (c *OpenAIClient) newChatRequest(ctx context.Context, data []byte) (*http.Request, error) {
+	url := c.chatURL
+	if url == "" {
+		url = c.baseURL + "/chat/completions"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKeyHeader != "" {
+		req.Header.Set(c.apiKeyHeader, c.apiKey)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	c.opts.applyHeaders(req)
+	return req, nil
+}
+
+// toOpenAIMessages converts messages to OpenAI wire format
+func toOpenAIMessages(messages []Message) []openAIMessage {
+	result := make([]openAIMessage, len(messages))
+	for i, m := range messages {
+		msg := openAIMessage{
+			Role:       m.Role,
+			Content:    m.Content,
+			ToolCallID: m.ToolCallID,
+			Name:       m.Name,
+		}
+		for _, tc := range m.ToolCalls {
+			msg.ToolCalls = append(msg.ToolCalls, openAIToolCall{
+				ID:   tc.ID,
+				Type: "function",
+				Function: openAIFunctionCall{
+					Name:      tc.Name,
+					Arguments: tc.Arguments,
+				},
+			})
+		}
+		result[i] = msg
+	}
+	return result
+}
+
+// toOpenAITools converts tool definitions to OpenAI wire format
+func toOpenAITools(tools []Tool) []openAITool {
+	if len(tools) == 0 {
+		return nil
+	}
+	result := make([]openAITool, len(tools))
+	for i, t := range tools {
+		result[i] = openAITool{
+			Type: "function",
+			Function: openAIToolSpec{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+			},
+		}
+	}
+	return result
+}
+
 // Chat sends a chat request
 func (c *OpenAIClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	return c.ChatWithTools(ctx, messages, nil)
+}
+
+// ChatWithTools sends a chat request with tool definitions
+func (c *OpenAIClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
 	reqBody := openAIRequest{
-		Model:    c.model,
-		Messages: messages,
-		Stream:   false,
+		Model:       c.model,
+		Messages:    toOpenAIMessages(messages),
+		Tools:       toOpenAITools(tools),
+		Stream:      false,
+		Temperature: c.opts.Temperature,
+		MaxTokens:   c.opts.MaxTokens,
 	}
 
 	data, err := json.Marshal(reqBody)
@@ -70,14 +200,11 @@ func (c *OpenAIClient) Chat(ctx context.Context, messages []Message) (*Response,
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewBuffer(data))
+	req, err := c.newChatRequest(ctx, data)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -98,75 +225,194 @@ func (c *OpenAIClient) Chat(ctx context.Context, messages []Message) (*Response,
 		return nil, fmt.Errorf("no response from OpenAI")
 	}
 
-	return &Response{
-		Content:      result.Choices[0].Message.Content,
-		FinishReason: result.Choices[0].FinishReason,
+	choice := result.Choices[0]
+	response := &Response{
+		Content:      choice.Message.Content,
+		FinishReason: choice.FinishReason,
 		Usage: Usage{
 			PromptTokens:     result.Usage.PromptTokens,
 			CompletionTokens: result.Usage.CompletionTokens,
 			TotalTokens:      result.Usage.TotalTokens,
 		},
-	}, nil
+	}
+	for _, tc := range choice.Message.ToolCalls {
+		response.ToolCalls = append(response.ToolCalls, ToolCall{
+			ID:        tc.ID,
+			Name:      tc.Function.Name,
+			Arguments: tc.Function.Arguments,
+		})
+	}
+
+	return response, nil
 }
 
 // Stream streams chat responses
 func (c *OpenAIClient) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
+	_, err := c.StreamWithTools(ctx, messages, nil, func(event StreamEvent) error {
+		if event.Content != "" {
+			return callback(event.Content)
+		}
+		return nil
+	})
+	return err
+}
+
+// openAIStreamChunk represents a single streamed chat completion chunk
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				Index    int                `json:"index"`
+				ID       string             `json:"id,omitempty"`
+				Function openAIFunctionCall `json:"function"`
+			} `json:"tool_calls,omitempty"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage,omitempty"`
+}
+
+// StreamWithTools streams chat responses with tool definitions, assembling
+// tool_call argument deltas into complete calls before emitting them.
+func (c *OpenAIClient) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
 	reqBody := openAIRequest{
-		Model:    c.model,
-		Messages: messages,
-		Stream:   true,
+		Model:       c.model,
+		Messages:    toOpenAIMessages(messages),
+		Tools:       toOpenAITools(tools),
+		Stream:      true,
+		Temperature: c.opts.Temperature,
+		MaxTokens:   c.opts.MaxTokens,
+
+		// Ask for token usage in the final chunk; providers that don't
+		// support stream_options simply omit it
+		StreamOptions: &openAIStreamOptions{IncludeUsage: true},
 	}
 
 	data, err := json.Marshal(reqBody)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewBuffer(data))
+	req, err := c.newChatRequest(ctx, data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("OpenAI API error: %d, %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("OpenAI API error: %d, %s", resp.StatusCode, string(body))
 	}
 
-	// Parse SSE stream
-	decoder := json.NewDecoder(resp.Body)
+	response := &Response{}
+	var content strings.Builder
+
+	// Accumulate tool call deltas by index until the stream finishes
+	type partialCall struct {
+		id   string
+		name string
+		args strings.Builder
+	}
+	partials := make(map[int]*partialCall)
+	maxIndex := -1
+
+	reader := newSSEReader(resp.Body)
 	for {
-		var chunk struct {
-			Choices []struct {
-				Delta struct {
-					Content string `json:"content"`
-				} `json:"delta"`
-			} `json:"choices"`
+		event, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		if err := decoder.Decode(&chunk); err != nil {
-			if err == io.EOF {
-				break
+		payload := strings.TrimSpace(event.Data)
+		if payload == "" {
+			continue
+		}
+		if payload == "[DONE]" {
+			break
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			continue
+		}
+
+		// Providers emit usage on a trailing chunk with no choices
+		if chunk.Usage != nil {
+			response.Usage = Usage{
+				PromptTokens:     chunk.Usage.PromptTokens,
+				CompletionTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:      chunk.Usage.TotalTokens,
 			}
-			return err
 		}
 
-		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
-			if err := callback(chunk.Choices[0].Delta.Content); err != nil {
-				return err
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		choice := chunk.Choices[0]
+		if choice.FinishReason != "" {
+			response.FinishReason = choice.FinishReason
+		}
+
+		if choice.Delta.Content != "" {
+			content.WriteString(choice.Delta.Content)
+			if err := callback(StreamEvent{Content: choice.Delta.Content}); err != nil {
+				return nil, err
 			}
 		}
+
+		for _, tc := range choice.Delta.ToolCalls {
+			partial, ok := partials[tc.Index]
+			if !ok {
+				partial = &partialCall{}
+				partials[tc.Index] = partial
+			}
+			if tc.ID != "" {
+				partial.id = tc.ID
+			}
+			if tc.Function.Name != "" {
+				partial.name = tc.Function.Name
+			}
+			partial.args.WriteString(tc.Function.Arguments)
+			if tc.Index > maxIndex {
+				maxIndex = tc.Index
+			}
+		}
+	}
+
+	response.Content = content.String()
+
+	// Emit assembled tool calls in index order
+	for i := 0; i <= maxIndex; i++ {
+		partial, ok := partials[i]
+		if !ok {
+			continue
+		}
+		call := ToolCall{
+			ID:        partial.id,
+			Name:      partial.name,
+			Arguments: partial.args.String(),
+		}
+		response.ToolCalls = append(response.ToolCalls, call)
+		if err := callback(StreamEvent{ToolCall: &call}); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	return response, nil
 }
 
 // Name returns the client name
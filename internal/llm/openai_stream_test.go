@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fixtureServer serves a recorded SSE stream for the chat completions
+// endpoint
+func fixtureServer(t *testing.T, fixture string) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", fixture, err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write(body)
+	}))
+}
+
+func TestStreamContentAndUsage(t *testing.T) {
+	server := fixtureServer(t, "openai_stream.sse")
+	defer server.Close()
+
+	client := NewOpenAICompatibleClient("test-model", "test-key", server.URL)
+
+	var deltas []string
+	response, err := client.StreamWithTools(context.Background(), []Message{{Role: "user", Content: "hi"}}, nil,
+		func(event StreamEvent) error {
+			if event.Content != "" {
+				deltas = append(deltas, event.Content)
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("StreamWithTools returned error: %v", err)
+	}
+
+	if response.Content != "Hello world" {
+		t.Errorf("expected content %q, got %q", "Hello world", response.Content)
+	}
+	if len(deltas) != 3 {
+		t.Errorf("expected 3 content deltas, got %d: %v", len(deltas), deltas)
+	}
+	if response.FinishReason != "stop" {
+		t.Errorf("expected finish reason %q, got %q", "stop", response.FinishReason)
+	}
+
+	// Usage arrives in a trailing chunk with no choices
+	if response.Usage.PromptTokens != 12 || response.Usage.CompletionTokens != 4 || response.Usage.TotalTokens != 16 {
+		t.Errorf("unexpected usage: %+v", response.Usage)
+	}
+}
+
+func TestStreamAssemblesToolCalls(t *testing.T) {
+	server := fixtureServer(t, "openai_tool_call.sse")
+	defer server.Close()
+
+	client := NewOpenAICompatibleClient("test-model", "test-key", server.URL)
+
+	var streamed []ToolCall
+	response, err := client.StreamWithTools(context.Background(), []Message{{Role: "user", Content: "weather?"}}, nil,
+		func(event StreamEvent) error {
+			if event.ToolCall != nil {
+				streamed = append(streamed, *event.ToolCall)
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("StreamWithTools returned error: %v", err)
+	}
+
+	if len(response.ToolCalls) != 1 {
+		t.Fatalf("expected 1 assembled tool call, got %d", len(response.ToolCalls))
+	}
+	call := response.ToolCalls[0]
+	if call.ID != "call_1" || call.Name != "get_weather" {
+		t.Errorf("unexpected tool call identity: %+v", call)
+	}
+	if call.Arguments != `{"city":"SF"}` {
+		t.Errorf("expected argument deltas assembled into %q, got %q", `{"city":"SF"}`, call.Arguments)
+	}
+	if len(streamed) != 1 || streamed[0].Arguments != call.Arguments {
+		t.Errorf("expected the assembled call delivered via callback, got %+v", streamed)
+	}
+	if response.FinishReason != "tool_calls" {
+		t.Errorf("expected finish reason %q, got %q", "tool_calls", response.FinishReason)
+	}
+}
@@ -0,0 +1,234 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResilienceConfig tunes the retry/timeout/circuit breaker decorator. Zero
+// values fall back to the defaults below.
+type ResilienceConfig struct {
+	MaxRetries     int           // retries after the first attempt (default 3)
+	BaseDelay      time.Duration // first backoff step (default 1s)
+	MaxDelay       time.Duration // backoff ceiling (default 30s)
+	RequestTimeout time.Duration // per-attempt deadline; 0 disables
+
+	// BreakerThreshold consecutive failures open the circuit for
+	// BreakerCooldown, failing calls fast instead of hammering a
+	// struggling provider
+	BreakerThreshold int           // default 5
+	BreakerCooldown  time.Duration // default 30s
+
+	// OnRetry is invoked before each retry sleep, for surfacing retry
+	// events in task logs
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// DefaultResilienceConfig returns the standard retry posture
+func DefaultResilienceConfig() ResilienceConfig {
+	return ResilienceConfig{
+		MaxRetries:       3,
+		BaseDelay:        time.Second,
+		MaxDelay:         30 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// resilientClient wraps a Client with retries, per-attempt timeouts and a
+// circuit breaker
+type resilientClient struct {
+	inner Client
+	cfg   ResilienceConfig
+
+	mu        sync.Mutex
+	failures  int       // consecutive failures feeding the breaker
+	openUntil time.Time // circuit open until this instant
+}
+
+// WithResilience decorates a client with retry, timeout and circuit breaker
+// behavior. The breaker state is per wrapped client, which maps one-to-one
+// onto a provider/model pair in the executor's client cache.
+func WithResilience(client Client, cfg ResilienceConfig) Client {
+	defaults := DefaultResilienceConfig()
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaults.MaxRetries
+	}
+	if cfg.BaseDelay == 0 {
+		cfg.BaseDelay = defaults.BaseDelay
+	}
+	if cfg.MaxDelay == 0 {
+		cfg.MaxDelay = defaults.MaxDelay
+	}
+	if cfg.BreakerThreshold == 0 {
+		cfg.BreakerThreshold = defaults.BreakerThreshold
+	}
+	if cfg.BreakerCooldown == 0 {
+		cfg.BreakerCooldown = defaults.BreakerCooldown
+	}
+	return &resilientClient{inner: client, cfg: cfg}
+}
+
+// isRetryableError reports whether an attempt is worth repeating: rate
+// limits, server errors and transport flakes. Provider clients surface HTTP
+// failures as "... API error: <status>, <body>" messages.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == context.Canceled {
+		return false
+	}
+
+	msg := err.Error()
+	for _, marker := range []string{
+		"error: 429", "error: 500", "error: 502", "error: 503", "error: 504",
+		"timeout", "deadline exceeded",
+		"connection refused", "connection reset", "unexpected EOF",
+	} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBreaker fails fast while the circuit is open
+func (r *resilientClient) checkBreaker() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Now().Before(r.openUntil) {
+		return fmt.Errorf("llm circuit breaker open for %s until %s",
+			r.inner.Name(), r.openUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// recordResult feeds the breaker: successes reset it, consecutive failures
+// past the threshold open it
+func (r *resilientClient) recordResult(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		r.failures = 0
+		return
+	}
+	r.failures++
+	if r.failures >= r.cfg.BreakerThreshold {
+		r.openUntil = time.Now().Add(r.cfg.BreakerCooldown)
+		r.failures = 0
+	}
+}
+
+// backoffDelay is the exponential backoff with full jitter for one attempt
+func (r *resilientClient) backoffDelay(attempt int) time.Duration {
+	delay := r.cfg.BaseDelay << uint(attempt)
+	if delay > r.cfg.MaxDelay {
+		delay = r.cfg.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + int64(delay)/2)
+}
+
+// do runs one call with retries; started reports whether the attempt made
+// observable progress (streamed content), in which case it is not retried
+func (r *resilientClient) do(ctx context.Context, call func(ctx context.Context) (bool, error)) error {
+	if err := r.checkBreaker(); err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= r.cfg.MaxRetries; attempt++ {
+		attemptCtx := ctx
+		cancel := func() {}
+		if r.cfg.RequestTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, r.cfg.RequestTimeout)
+		}
+
+		started, err := call(attemptCtx)
+		cancel()
+
+		r.recordResult(err)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		// A stream that already emitted content cannot be transparently
+		// replayed; surface the error instead
+		if started || !isRetryableError(err) || attempt == r.cfg.MaxRetries {
+			break
+		}
+
+		delay := r.backoffDelay(attempt)
+		if r.cfg.OnRetry != nil {
+			r.cfg.OnRetry(attempt+1, delay, err)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return lastErr
+}
+
+func (r *resilientClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	var resp *Response
+	err := r.do(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		resp, err = r.inner.Chat(ctx, messages)
+		return false, err
+	})
+	return resp, err
+}
+
+func (r *resilientClient) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
+	return r.do(ctx, func(ctx context.Context) (bool, error) {
+		started := false
+		err := r.inner.Stream(ctx, messages, func(chunk string) error {
+			started = true
+			return callback(chunk)
+		})
+		return started, err
+	})
+}
+
+func (r *resilientClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
+	var resp *Response
+	err := r.do(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		resp, err = r.inner.ChatWithTools(ctx, messages, tools)
+		return false, err
+	})
+	return resp, err
+}
+
+func (r *resilientClient) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
+	var resp *Response
+	err := r.do(ctx, func(ctx context.Context) (bool, error) {
+		started := false
+		var err error
+		resp, err = r.inner.StreamWithTools(ctx, messages, tools, func(event StreamEvent) error {
+			started = true
+			return callback(event)
+		})
+		return started, err
+	})
+	return resp, err
+}
+
+func (r *resilientClient) Name() string {
+	return r.inner.Name()
+}
+
+// SetOptions forwards model tuning to the wrapped client
+func (r *resilientClient) SetOptions(opts Options) {
+	if tunable, ok := r.inner.(Tunable); ok {
+		tunable.SetOptions(opts)
+	}
+}
@@ -0,0 +1,71 @@
+package llm
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// sseEvent is a single server-sent event
+type sseEvent struct {
+	Event string
+	Data  string
+}
+
+// sseReader decodes a text/event-stream body into events. It implements the
+// subset of the WHATWG spec that OpenAI-compatible providers use: "data:"
+// field lines accumulate (joined with newlines) until an empty line
+// dispatches the event, ":" comment lines and unknown fields are ignored,
+// and CRLF line endings are accepted.
+type sseReader struct {
+	scanner *bufio.Scanner
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &sseReader{scanner: scanner}
+}
+
+// Next returns the next event, or io.EOF when the stream is exhausted. A
+// final event not terminated by a blank line is still delivered.
+func (r *sseReader) Next() (*sseEvent, error) {
+	var event sseEvent
+	var data []string
+
+	for r.scanner.Scan() {
+		line := strings.TrimSuffix(r.scanner.Text(), "\r")
+
+		// A blank line dispatches the accumulated event
+		if line == "" {
+			if len(data) > 0 || event.Event != "" {
+				event.Data = strings.Join(data, "\n")
+				return &event, nil
+			}
+			continue
+		}
+
+		// Lines starting with a colon are comments (keep-alives)
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "event":
+			event.Event = value
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 || event.Event != "" {
+		event.Data = strings.Join(data, "\n")
+		return &event, nil
+	}
+	return nil, io.EOF
+}
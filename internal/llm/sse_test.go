@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func readAllEvents(t *testing.T, input string) []sseEvent {
+	t.Helper()
+	reader := newSSEReader(strings.NewReader(input))
+	var events []sseEvent
+	for {
+		event, err := reader.Next()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("Next() returned error: %v", err)
+		}
+		events = append(events, *event)
+	}
+}
+
+func TestSSEReaderSimpleEvents(t *testing.T) {
+	input := "data: one\n\ndata: two\n\n"
+	events := readAllEvents(t, input)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Data != "one" || events[1].Data != "two" {
+		t.Errorf("unexpected payloads: %q, %q", events[0].Data, events[1].Data)
+	}
+}
+
+func TestSSEReaderMultilineData(t *testing.T) {
+	input := "data: first\ndata: second\n\n"
+	events := readAllEvents(t, input)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Data != "first\nsecond" {
+		t.Errorf("expected data lines joined with newline, got %q", events[0].Data)
+	}
+}
+
+func TestSSEReaderIgnoresCommentsAndUnknownFields(t *testing.T) {
+	input := ": keep-alive\nid: 42\nretry: 1000\ndata: payload\n\n"
+	events := readAllEvents(t, input)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Data != "payload" {
+		t.Errorf("unexpected payload: %q", events[0].Data)
+	}
+}
+
+func TestSSEReaderCRLF(t *testing.T) {
+	input := "data: windows\r\n\r\ndata: line\r\n\r\n"
+	events := readAllEvents(t, input)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Data != "windows" || events[1].Data != "line" {
+		t.Errorf("unexpected payloads: %q, %q", events[0].Data, events[1].Data)
+	}
+}
+
+func TestSSEReaderEventField(t *testing.T) {
+	input := "event: done\ndata: {}\n\n"
+	events := readAllEvents(t, input)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Event != "done" {
+		t.Errorf("expected event name %q, got %q", "done", events[0].Event)
+	}
+}
+
+func TestSSEReaderUnterminatedFinalEvent(t *testing.T) {
+	// A stream cut off without a trailing blank line still yields the event
+	input := "data: first\n\ndata: trailing"
+	events := readAllEvents(t, input)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[1].Data != "trailing" {
+		t.Errorf("unexpected final payload: %q", events[1].Data)
+	}
+}
+
+func TestSSEReaderNoSpaceAfterColon(t *testing.T) {
+	input := "data:compact\n\n"
+	events := readAllEvents(t, input)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].Data != "compact" {
+		t.Errorf("unexpected payload: %q", events[0].Data)
+	}
+}
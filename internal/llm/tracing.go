@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xcode-ai/xgent-go/internal/tracing"
+)
+
+// tracedClient wraps a Client so every provider call produces a client span
+// carrying the provider name, model latency and token usage
+type tracedClient struct {
+	inner Client
+	model string
+}
+
+// WithTracing decorates a client with per-call tracing spans. The model is
+// recorded on each span; it is passed in because Name() only identifies the
+// provider.
+func WithTracing(client Client, model string) Client {
+	return &tracedClient{inner: client, model: model}
+}
+
+// startCall opens a span for one provider call
+func (t *tracedClient) startCall(ctx context.Context, operation string) (context.Context, *tracing.Span) {
+	ctx, span := tracing.StartSpan(ctx, "llm."+operation, tracing.KindClient)
+	span.SetAttribute("llm.provider", t.inner.Name())
+	span.SetAttribute("llm.model", t.model)
+	return ctx, span
+}
+
+// recordUsage attaches token counts to the span when the provider reports
+// them
+func recordUsage(span *tracing.Span, resp *Response) {
+	if resp == nil || resp.Usage.TotalTokens == 0 {
+		return
+	}
+	span.SetAttribute("llm.tokens.prompt", fmt.Sprintf("%d", resp.Usage.PromptTokens))
+	span.SetAttribute("llm.tokens.completion", fmt.Sprintf("%d", resp.Usage.CompletionTokens))
+}
+
+func (t *tracedClient) Chat(ctx context.Context, messages []Message) (*Response, error) {
+	ctx, span := t.startCall(ctx, "chat")
+	defer span.End()
+
+	resp, err := t.inner.Chat(ctx, messages)
+	span.RecordError(err)
+	recordUsage(span, resp)
+	return resp, err
+}
+
+func (t *tracedClient) Stream(ctx context.Context, messages []Message, callback func(string) error) error {
+	ctx, span := t.startCall(ctx, "stream")
+	defer span.End()
+
+	err := t.inner.Stream(ctx, messages, callback)
+	span.RecordError(err)
+	return err
+}
+
+func (t *tracedClient) ChatWithTools(ctx context.Context, messages []Message, tools []Tool) (*Response, error) {
+	ctx, span := t.startCall(ctx, "chat_with_tools")
+	defer span.End()
+
+	resp, err := t.inner.ChatWithTools(ctx, messages, tools)
+	span.RecordError(err)
+	recordUsage(span, resp)
+	return resp, err
+}
+
+func (t *tracedClient) StreamWithTools(ctx context.Context, messages []Message, tools []Tool, callback StreamCallback) (*Response, error) {
+	ctx, span := t.startCall(ctx, "stream_with_tools")
+	defer span.End()
+
+	resp, err := t.inner.StreamWithTools(ctx, messages, tools, callback)
+	span.RecordError(err)
+	recordUsage(span, resp)
+	return resp, err
+}
+
+func (t *tracedClient) Name() string {
+	return t.inner.Name()
+}
+
+// SetOptions forwards model tuning to the wrapped client
+func (t *tracedClient) SetOptions(opts Options) {
+	if tunable, ok := t.inner.(Tunable); ok {
+		tunable.SetOptions(opts)
+	}
+}
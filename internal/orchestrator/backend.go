@@ -0,0 +1,25 @@
+package orchestrator
+
+import "context"
+
+// Delivery represents a dequeued task pending acknowledgement
+type Delivery struct {
+	TaskID uint
+	// MessageID is the backend-specific identifier used for acknowledgement
+	MessageID string
+}
+
+// Queue is a pluggable task queue backend shared between server and worker
+// processes. Implementations must provide at-least-once delivery: a
+// dequeued task that is not acknowledged before its visibility timeout
+// elapses is redelivered to another worker.
+type Queue interface {
+	// Enqueue adds a task to the queue
+	Enqueue(ctx context.Context, taskID uint) error
+	// Dequeue blocks until a task is available or the context is cancelled
+	Dequeue(ctx context.Context) (*Delivery, error)
+	// Ack acknowledges a delivery so it is not redelivered
+	Ack(ctx context.Context, delivery *Delivery) error
+	// Close releases backend resources
+	Close() error
+}
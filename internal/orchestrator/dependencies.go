@@ -0,0 +1,150 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// dependencyInterval is how often blocked tasks are checked for
+	// completed parents
+	dependencyInterval = 10 * time.Second
+
+	// dependencyBatchLimit caps how many blocked tasks one pass examines
+	dependencyBatchLimit = 100
+)
+
+// startDependencyWatcher begins the loop that releases blocked tasks once
+// every task they depend on has completed. Parent results are injected
+// into the child's template parameters as task_<id>_result before
+// submission; a failed parent fails the child instead.
+func (o *Orchestrator) startDependencyWatcher() {
+	o.wg.Add(1)
+	go o.dependencyLoop()
+}
+
+func (o *Orchestrator) dependencyLoop() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(dependencyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+		case <-ticker.C:
+			o.releaseBlockedTasks()
+		}
+	}
+}
+
+// releaseBlockedTasks submits every blocked task whose parents all
+// completed and fails those whose parents cannot complete anymore
+func (o *Orchestrator) releaseBlockedTasks() {
+	tasks, err := o.storage.Tasks().ListBlocked(dependencyBatchLimit)
+	if err != nil {
+		o.logger.Warn("Failed to scan for blocked tasks", zap.Error(err))
+		return
+	}
+
+	for _, task := range tasks {
+		var parentIDs []uint
+		if err := json.Unmarshal([]byte(task.DependsOn), &parentIDs); err != nil {
+			o.failBlockedTask(task, fmt.Sprintf("invalid depends_on: %v", err))
+			continue
+		}
+
+		ready := true
+		results := make(map[uint]string, len(parentIDs))
+		for _, parentID := range parentIDs {
+			parent, err := o.storage.Tasks().GetByID(parentID)
+			if err != nil {
+				o.failBlockedTask(task, fmt.Sprintf("dependency task %d not found", parentID))
+				ready = false
+				break
+			}
+			switch parent.Status {
+			case models.TaskStatusCompleted:
+				results[parent.ID] = parent.Result
+			case models.TaskStatusFailed, models.TaskStatusCancelled, models.TaskStatusDeadLetter:
+				o.failBlockedTask(task, fmt.Sprintf("dependency task %d ended %s", parent.ID, parent.Status))
+				ready = false
+			default:
+				ready = false // still running or queued
+			}
+			if !ready {
+				break
+			}
+		}
+		if !ready || task.Status != models.TaskStatusBlocked {
+			continue
+		}
+
+		claimed, err := o.storage.Tasks().ClaimBlocked(task.ID)
+		if err != nil {
+			o.logger.Error("Failed to claim blocked task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue // another process got there first
+		}
+
+		task.Status = models.TaskStatusPending
+		InjectParentResults(task, results)
+		if err := o.storage.Tasks().Update(task); err != nil {
+			o.logger.Error("Failed to update released task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+
+		o.logger.Info("Releasing blocked task",
+			zap.Uint("task_id", task.ID),
+			zap.Int("dependencies", len(parentIDs)))
+
+		if err := o.SubmitTask(task, nil); err != nil {
+			o.logger.Error("Failed to submit released task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+		o.addRecoveryLog(task.ID, "released after all dependencies completed")
+	}
+}
+
+// failBlockedTask fails a blocked task whose dependencies can never be
+// satisfied, reporting the transition like any execution failure
+func (o *Orchestrator) failBlockedTask(task *models.Task, reason string) {
+	task.Status = models.TaskStatusFailed
+	task.Error = reason
+	if err := o.storage.Tasks().Update(task); err != nil {
+		o.logger.Error("Failed to fail blocked task",
+			zap.Uint("task_id", task.ID), zap.Error(err))
+		return
+	}
+	o.addRecoveryLog(task.ID, "failed while blocked: "+reason)
+	o.reporter.Callback()(task.ID, task.Progress, models.TaskStatusFailed, reason, nil)
+}
+
+// InjectParentResults merges parent task results into a task's template
+// parameters as task_<id>_result, so child prompts can reference them with
+// {{task_<id>_result}} placeholders
+func InjectParentResults(task *models.Task, results map[uint]string) {
+	if len(results) == 0 {
+		return
+	}
+	parameters := map[string]string{}
+	if task.Parameters != "" {
+		json.Unmarshal([]byte(task.Parameters), &parameters)
+	}
+	for parentID, result := range results {
+		parameters[fmt.Sprintf("task_%d_result", parentID)] = result
+	}
+	if data, err := json.Marshal(parameters); err == nil {
+		task.Parameters = string(data)
+	}
+}
@@ -0,0 +1,332 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/git"
+	"github.com/xcode-ai/xgent-go/internal/secrets"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// gitFlowExecutor wraps the task executor with the opt-in git PR flow: the
+// repository is cloned into the task workspace before execution, and agent
+// changes are committed on an xgent/task-<id> branch, pushed and turned into
+// a pull request afterwards
+type gitFlowExecutor struct {
+	inner        TaskExecutor
+	storage      *storage.Storage
+	workspaceDir string
+	logger       *zap.Logger
+
+	// secrets resolves workspace git credentials from settings; nil when
+	// no encryption key is configured
+	secrets *secrets.Store
+}
+
+// Execute runs the task, bracketed by git workspace preparation and PR
+// creation for tasks that opted in
+func (g *gitFlowExecutor) Execute(ctx context.Context, task *models.Task, callback ProgressCallback) error {
+	repoDir, baseBranch, err := g.prepare(task, callback)
+	if err != nil {
+		return fmt.Errorf("failed to prepare git workspace: %w", err)
+	}
+
+	err = g.inner.Execute(ctx, task, callback)
+
+	if repoDir != "" {
+		if err == nil {
+			g.finalize(task, repoDir, baseBranch, callback)
+		}
+		os.RemoveAll(repoDir)
+	}
+
+	return err
+}
+
+// prepare clones the task's repository into the workspace directory and
+// checks out the task branch. Tasks without AutoPR (or without a git URL)
+// return an empty dir and run unchanged.
+func (g *gitFlowExecutor) prepare(task *models.Task, callback ProgressCallback) (repoDir, baseBranch string, err error) {
+	if !task.AutoPR || task.GitURL == "" {
+		return "", "", nil
+	}
+
+	gitSvc := git.NewService(g.workspaceDir, g.logger)
+	repoDir = filepath.Join(g.workspaceDir, fmt.Sprintf("task-%d", task.ID))
+	os.RemoveAll(repoDir) // a previous attempt may have left a clone behind
+
+	if err := gitSvc.Clone(git.CloneOptions{
+		URL:    task.GitURL,
+		Branch: task.BranchName,
+		Token:  g.gitToken(task),
+	}, repoDir); err != nil {
+		return "", "", err
+	}
+
+	// The cloned checkout is the PR base; agent changes go on their own
+	// branch on top of it
+	baseBranch, err = gitSvc.CurrentBranch(repoDir)
+	if err != nil {
+		os.RemoveAll(repoDir)
+		return "", "", err
+	}
+
+	taskBranch := fmt.Sprintf("xgent/task-%d", task.ID)
+	if err := gitSvc.CreateBranch(repoDir, taskBranch, true); err != nil {
+		os.RemoveAll(repoDir)
+		return "", "", err
+	}
+
+	task.WorkDir = repoDir
+	if err := g.storage.Tasks().Update(task); err != nil {
+		os.RemoveAll(repoDir)
+		return "", "", err
+	}
+
+	if callback != nil {
+		callback(task.ID, 15, models.TaskStatusRunning,
+			fmt.Sprintf("Prepared git workspace on branch %s", taskBranch), map[string]interface{}{
+				"type": "git_workspace_ready",
+				"details": map[string]interface{}{
+					"branch": taskBranch,
+					"base":   baseBranch,
+				},
+			})
+	}
+
+	return repoDir, baseBranch, nil
+}
+
+// finalize commits and pushes agent changes and opens a pull request. PR flow
+// failures are reported to the stream but do not fail the task: the agent's
+// result is already recorded.
+func (g *gitFlowExecutor) finalize(task *models.Task, repoDir, baseBranch string, callback ProgressCallback) {
+	gitSvc := git.NewService(g.workspaceDir, g.logger)
+	taskBranch := fmt.Sprintf("xgent/task-%d", task.ID)
+
+	status, err := gitSvc.GetStatus(repoDir)
+	if err != nil {
+		g.logger.Warn("Failed to read git status after execution",
+			zap.Uint("task_id", task.ID), zap.Error(err))
+		return
+	}
+	if strings.TrimSpace(status) == "" {
+		g.logger.Info("Task made no changes, skipping PR",
+			zap.Uint("task_id", task.ID))
+		return
+	}
+
+	if err := gitSvc.Commit(repoDir, git.CommitOptions{
+		Message:     fmt.Sprintf("Task %d: %s", task.ID, task.Title),
+		AuthorName:  "Xgent",
+		AuthorEmail: "xgent@localhost",
+	}); err != nil {
+		g.reportGitFlowFailure(task, "commit failed", err, callback)
+		return
+	}
+
+	if err := gitSvc.Push(repoDir, git.PushOptions{
+		Remote: "origin",
+		Branch: taskBranch,
+		Token:  g.gitToken(task),
+	}); err != nil {
+		g.reportGitFlowFailure(task, "push failed", err, callback)
+		return
+	}
+
+	prURL, err := g.createPullRequest(task, taskBranch, baseBranch)
+	if err != nil {
+		g.reportGitFlowFailure(task, "pull request creation failed", err, callback)
+		return
+	}
+
+	task.PRURL = prURL
+	if err := g.storage.Tasks().Update(task); err != nil {
+		g.logger.Error("Failed to record PR URL",
+			zap.Uint("task_id", task.ID), zap.Error(err))
+	}
+
+	g.logger.Info("Opened pull request for task",
+		zap.Uint("task_id", task.ID), zap.String("pr_url", prURL))
+
+	if callback != nil {
+		callback(task.ID, 100, models.TaskStatusCompleted,
+			fmt.Sprintf("Opened pull request %s", prURL), map[string]interface{}{
+				"type": "pr_created",
+				"details": map[string]interface{}{
+					"pr_url": prURL,
+					"branch": taskBranch,
+				},
+			})
+	}
+}
+
+// reportGitFlowFailure surfaces a PR flow failure in the task stream and log
+// without failing the task itself
+func (g *gitFlowExecutor) reportGitFlowFailure(task *models.Task, stage string, err error, callback ProgressCallback) {
+	g.logger.Error("Git PR flow failed",
+		zap.Uint("task_id", task.ID),
+		zap.String("stage", stage),
+		zap.Error(err))
+
+	if callback != nil {
+		callback(task.ID, task.Progress, task.Status,
+			fmt.Sprintf("Git PR flow: %s: %v", stage, err), map[string]interface{}{
+				"type": "warning",
+			})
+	}
+}
+
+// gitToken returns the git token for clone, push and PR calls: the task
+// owner's connected account, falling back to the workspace's shared
+// credentials named in its settings. Empty when neither is configured.
+func (g *gitFlowExecutor) gitToken(task *models.Task) string {
+	if user, err := g.storage.Users().GetByID(task.UserID); err == nil && user.GitToken != "" {
+		return user.GitToken
+	}
+
+	workspace, err := g.storage.Workspaces().GetByID(task.WorkspaceID)
+	if err != nil {
+		return ""
+	}
+	name := workspace.SettingsValues().GitTokenSecret
+	if name == "" || g.secrets == nil {
+		return ""
+	}
+	token, err := g.secrets.Get(task.WorkspaceID, name)
+	if err != nil {
+		g.logger.Warn("Failed to resolve workspace git credentials",
+			zap.Uint("workspace_id", task.WorkspaceID),
+			zap.String("secret", name),
+			zap.Error(err))
+		return ""
+	}
+	return token
+}
+
+// createPullRequest opens a PR (GitHub) or merge request (GitLab) for the
+// pushed branch and returns its URL
+func (g *gitFlowExecutor) createPullRequest(task *models.Task, head, base string) (string, error) {
+	host, repoPath, err := splitRepoURL(task.GitURL)
+	if err != nil {
+		return "", err
+	}
+
+	token := g.gitToken(task)
+	if token == "" {
+		return "", fmt.Errorf("no git token connected for user %d", task.UserID)
+	}
+
+	title := fmt.Sprintf("Task %d: %s", task.ID, task.Title)
+	body := fmt.Sprintf("Automated change from Xgent task %d.\n\n%s", task.ID, task.Description)
+
+	switch {
+	case host == "github.com":
+		return createGitHubPR(repoPath, token, title, body, head, base)
+	case strings.Contains(host, "gitlab"):
+		return createGitLabMR(host, repoPath, token, title, body, head, base)
+	default:
+		return "", fmt.Errorf("unsupported git host for PR creation: %s", host)
+	}
+}
+
+// splitRepoURL extracts the host and owner/repo path from an HTTPS clone URL
+func splitRepoURL(gitURL string) (host, repoPath string, err error) {
+	parsed, err := url.Parse(gitURL)
+	if err != nil || parsed.Host == "" {
+		return "", "", fmt.Errorf("cannot parse git URL %q", gitURL)
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	if repoPath == "" {
+		return "", "", fmt.Errorf("git URL %q has no repository path", gitURL)
+	}
+	return parsed.Host, repoPath, nil
+}
+
+// prHTTPClient is used for PR API calls
+var prHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// createGitHubPR opens a pull request via the GitHub REST API
+func createGitHubPR(repoPath, token, title, body, head, base string) (string, error) {
+	payload, _ := json.Marshal(map[string]string{
+		"title": title,
+		"body":  body,
+		"head":  head,
+		"base":  base,
+	})
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("https://api.github.com/repos/%s/pulls", repoPath),
+		bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	var result struct {
+		HTMLURL string `json:"html_url"`
+		Message string `json:"message"`
+	}
+	if err := doPRRequest(req, &result); err != nil {
+		return "", err
+	}
+	if result.HTMLURL == "" {
+		return "", fmt.Errorf("github: %s", result.Message)
+	}
+	return result.HTMLURL, nil
+}
+
+// createGitLabMR opens a merge request via the GitLab API
+func createGitLabMR(host, repoPath, token, title, body, head, base string) (string, error) {
+	payload, _ := json.Marshal(map[string]string{
+		"title":         title,
+		"description":   body,
+		"source_branch": head,
+		"target_branch": base,
+	})
+
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("https://%s/api/v4/projects/%s/merge_requests", host, url.PathEscape(repoPath)),
+		bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+
+	var result struct {
+		WebURL  string      `json:"web_url"`
+		Message interface{} `json:"message"`
+	}
+	if err := doPRRequest(req, &result); err != nil {
+		return "", err
+	}
+	if result.WebURL == "" {
+		return "", fmt.Errorf("gitlab: %v", result.Message)
+	}
+	return result.WebURL, nil
+}
+
+// doPRRequest executes a PR API call and decodes the JSON response
+func doPRRequest(req *http.Request, result interface{}) error {
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := prHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// ErrLimitExceeded marks a submission rejected because the target robot has
+// reached one of its configured usage limits
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// checkRobotLimits rejects the task if the target robot declares usage limits
+// and has already reached them. Windows are rolling: the last hour for the
+// task limit and the last 24 hours for the token budget.
+func (o *Orchestrator) checkRobotLimits(task *models.Task) error {
+	if task.ResourceType != "bot" && task.ResourceType != "robot" {
+		return nil
+	}
+
+	robotResource, err := o.storage.Resources().GetByName(task.WorkspaceID, task.ResourceName, models.ResourceTypeRobot)
+	if err != nil {
+		// Missing resources surface as execution errors, not here
+		return nil
+	}
+
+	resource, err := crd.NewParser().Parse([]byte(robotResource.Spec))
+	if err != nil {
+		return nil
+	}
+	robot, ok := resource.(*crd.Robot)
+	if !ok || robot.Spec.Limits == nil {
+		return nil
+	}
+
+	limits := robot.Spec.Limits
+	now := time.Now()
+
+	if limits.MaxTasksPerHour > 0 {
+		// The submitted task is already persisted, so it is part of the count
+		total, _, err := o.storage.Tasks().CountForResource(task.WorkspaceID, task.ResourceName, now.Add(-time.Hour), now)
+		if err != nil {
+			return fmt.Errorf("failed to check task limit: %w", err)
+		}
+		if total > int64(limits.MaxTasksPerHour) {
+			return fmt.Errorf("%w: robot %q already ran %d tasks in the last hour (limit %d)",
+				ErrLimitExceeded, task.ResourceName, total-1, limits.MaxTasksPerHour)
+		}
+	}
+
+	if limits.MaxTokensPerDay > 0 {
+		tokens, err := o.storage.Tasks().SumTokensForResource(task.WorkspaceID, task.ResourceName, now.Add(-24*time.Hour))
+		if err != nil {
+			return fmt.Errorf("failed to check token budget: %w", err)
+		}
+		if tokens >= int64(limits.MaxTokensPerDay) {
+			return fmt.Errorf("%w: robot %q used %d tokens in the last 24 hours (budget %d)",
+				ErrLimitExceeded, task.ResourceName, tokens, limits.MaxTokensPerDay)
+		}
+	}
+
+	return nil
+}
+
+// WorkspaceBudgetWindow is the rolling window workspace token budgets
+// apply over, shared with the API's remaining-budget report
+const WorkspaceBudgetWindow = 30 * 24 * time.Hour
+
+// checkWorkspaceBudget rejects the task when its workspace has spent its
+// rolling token budget
+func (o *Orchestrator) checkWorkspaceBudget(task *models.Task) error {
+	workspace, err := o.storage.Workspaces().GetByID(task.WorkspaceID)
+	if err != nil || workspace.TokenBudget <= 0 {
+		return nil
+	}
+
+	used, err := o.storage.Tasks().SumTokensForWorkspace(task.WorkspaceID, time.Now().Add(-WorkspaceBudgetWindow))
+	if err != nil {
+		return fmt.Errorf("failed to check workspace budget: %w", err)
+	}
+	if used >= int64(workspace.TokenBudget) {
+		return fmt.Errorf("%w: workspace used %d of %d budgeted tokens in the last 30 days",
+			ErrLimitExceeded, used, workspace.TokenBudget)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package orchestrator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// janitorInterval is how often the background archival pass runs
+	janitorInterval = time.Hour
+
+	// janitorBatchLimit bounds how many rows one pass archives per
+	// workspace; anything beyond it is picked up by the next pass
+	janitorBatchLimit = 10000
+)
+
+// LogJanitorStatus reports the outcome of the most recent archival run
+type LogJanitorStatus struct {
+	Running        bool      `json:"running"`
+	LastRun        time.Time `json:"last_run,omitempty"`
+	Workspaces     int       `json:"workspaces"`
+	LogsArchived   int       `json:"logs_archived"`
+	EventsArchived int       `json:"events_archived"`
+	LastError      string    `json:"last_error,omitempty"`
+}
+
+// LogJanitor periodically archives task logs and event rows older than
+// each workspace's retention window to compressed JSONL files in the blob
+// store, then deletes them from the database. Workspaces without a
+// retention window are left alone.
+type LogJanitor struct {
+	storage *storage.Storage
+	blobs   attachment.BlobStore
+	logger  *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	runMu  sync.Mutex // serializes archival passes
+	mu     sync.Mutex // guards status
+	status LogJanitorStatus
+}
+
+// NewLogJanitor creates a janitor archiving to the given blob storage
+// backend. When the backend cannot be initialized archival is disabled
+// rather than deleting logs without a copy.
+func NewLogJanitor(store *storage.Storage, cfg attachment.Config, logger *zap.Logger) *LogJanitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	blobs, err := attachment.NewBlobStore(cfg)
+	if err != nil {
+		logger.Warn("Failed to initialize archive storage, log archival disabled", zap.Error(err))
+		blobs = nil
+	}
+	return &LogJanitor{
+		storage: store,
+		blobs:   blobs,
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start launches the background archival loop
+func (j *LogJanitor) Start() {
+	j.wg.Add(1)
+	go j.run()
+}
+
+// Stop stops the background loop
+func (j *LogJanitor) Stop() {
+	j.cancel()
+	j.wg.Wait()
+}
+
+func (j *LogJanitor) run() {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.ctx.Done():
+			return
+		case <-ticker.C:
+			j.RunNow()
+		}
+	}
+}
+
+// Status returns the outcome of the most recent archival run
+func (j *LogJanitor) Status() LogJanitorStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// RunNow performs one archival pass over every workspace with a retention
+// window and returns the combined outcome. Concurrent calls are serialized
+// with the background loop.
+func (j *LogJanitor) RunNow() LogJanitorStatus {
+	j.runMu.Lock()
+	defer j.runMu.Unlock()
+
+	j.mu.Lock()
+	j.status.Running = true
+	j.mu.Unlock()
+
+	status := LogJanitorStatus{LastRun: time.Now()}
+
+	workspaces, err := j.storage.Workspaces().ListWithLogRetention()
+	if err != nil {
+		status.LastError = err.Error()
+	}
+
+	for _, workspace := range workspaces {
+		cutoff := time.Now().Add(-time.Duration(workspace.LogRetentionDays) * 24 * time.Hour)
+		logs, events, err := j.archiveWorkspace(workspace, cutoff)
+		if err != nil {
+			j.logger.Warn("Log archival failed",
+				zap.Uint("workspace_id", workspace.ID), zap.Error(err))
+			status.LastError = err.Error()
+			continue
+		}
+		if logs+events > 0 {
+			status.Workspaces++
+		}
+		status.LogsArchived += logs
+		status.EventsArchived += events
+	}
+
+	j.mu.Lock()
+	j.status = status
+	j.mu.Unlock()
+	return status
+}
+
+// archiveWorkspace writes one workspace's expired logs and events to a
+// compressed archive, records it, and deletes the archived rows
+func (j *LogJanitor) archiveWorkspace(workspace *models.Workspace, cutoff time.Time) (int, int, error) {
+	logs, err := j.storage.Tasks().ListLogsBefore(workspace.ID, cutoff, janitorBatchLimit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list expired logs: %w", err)
+	}
+	events, err := j.storage.Tasks().ListEventsBefore(workspace.ID, cutoff, janitorBatchLimit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list expired events: %w", err)
+	}
+	if len(logs) == 0 && len(events) == 0 {
+		return 0, 0, nil
+	}
+
+	if j.blobs == nil {
+		return 0, 0, fmt.Errorf("archive storage unavailable")
+	}
+
+	// One archive per pass: JSONL of logs then events, gzip compressed
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+	for _, log := range logs {
+		enc.Encode(map[string]interface{}{"kind": "log", "record": log})
+	}
+	for _, event := range events {
+		enc.Encode(map[string]interface{}{"kind": "event", "record": event})
+	}
+	if err := gz.Close(); err != nil {
+		return 0, 0, fmt.Errorf("failed to compress archive: %w", err)
+	}
+
+	key := fmt.Sprintf("archives/workspace-%d/task-logs-%s.jsonl.gz",
+		workspace.ID, time.Now().UTC().Format("20060102T150405"))
+	size := int64(buf.Len())
+	if err := j.blobs.Save(key, &buf, size, "application/gzip"); err != nil {
+		return 0, 0, fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	if err := j.storage.Tasks().CreateLogArchive(&models.LogArchive{
+		WorkspaceID: workspace.ID,
+		Key:         key,
+		Cutoff:      cutoff,
+		Logs:        len(logs),
+		Events:      len(events),
+		Size:        size,
+	}); err != nil {
+		j.logger.Warn("Failed to record log archive", zap.String("key", key), zap.Error(err))
+	}
+
+	// Delete only after the archive is safely stored
+	logIDs := make([]uint, len(logs))
+	for i, log := range logs {
+		logIDs[i] = log.ID
+	}
+	eventIDs := make([]uint, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+	if err := j.storage.Tasks().DeleteLogsByID(logIDs); err != nil {
+		return 0, 0, fmt.Errorf("failed to delete archived logs: %w", err)
+	}
+	if err := j.storage.Tasks().DeleteEventsByID(eventIDs); err != nil {
+		return 0, 0, fmt.Errorf("failed to delete archived events: %w", err)
+	}
+
+	j.logger.Info("Archived expired task logs",
+		zap.Uint("workspace_id", workspace.ID),
+		zap.Int("logs", len(logs)),
+		zap.Int("events", len(events)),
+		zap.String("key", key),
+	)
+	return len(logs), len(events), nil
+}
@@ -1,11 +1,19 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/xcode-ai/xgent-go/internal/executor"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/secrets"
+	"github.com/xcode-ai/xgent-go/internal/services/attachment"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/webhook"
 	"go.uber.org/zap"
 )
 
@@ -14,6 +22,27 @@ type Config struct {
 	Workers      int
 	QueueSize    int
 	WorkspaceDir string
+
+	// QueueBackend selects the task queue implementation: "memory"
+	// (default, single process) or "redis" (shared across workers)
+	QueueBackend      string
+	RedisAddr         string
+	RedisPassword     string
+	RedisDB           int
+	VisibilityTimeout time.Duration
+
+	// RecoveryPolicy controls what happens to tasks found in pending or
+	// running state on startup: "requeue" (default) re-enqueues them,
+	// "fail" marks them failed
+	RecoveryPolicy string
+
+	// Attachment is the blob storage backend, shared with the API server
+	// so executors can persist task artifacts
+	Attachment attachment.Config
+
+	// Embeddings is the provider used for semantic attachment retrieval;
+	// an empty provider disables it
+	Embeddings llm.Config
 }
 
 // Orchestrator manages task execution
@@ -22,30 +51,184 @@ type Orchestrator struct {
 	storage  *storage.Storage
 	logger   *zap.Logger
 	queue    *TaskQueue
+	backend  Queue // distributed backend, nil when using the in-memory queue
 	executor *executor.AgnoExecutor
+	runner   TaskExecutor // executor wrapped with the git PR flow and retry policy
+	reporter *ProgressReporter
+	webhooks *webhook.Dispatcher
+	digests  *webhook.DigestScheduler
+	janitor  *LogJanitor
+	registry *workerRegistry
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // New creates a new orchestrator
 func New(cfg *Config, storage *storage.Storage, logger *zap.Logger) *Orchestrator {
-	return &Orchestrator{
+	ctx, cancel := context.WithCancel(context.Background())
+	webhooks := webhook.NewDispatcher(storage, logger)
+	digests := webhook.NewDigestScheduler(storage, webhooks, logger)
+	exec := executor.NewAgnoExecutor(storage, logger)
+	exec.ConfigureArtifacts(cfg.Attachment)
+	exec.ConfigureEmbeddings(cfg.Embeddings)
+	runner := &gitFlowExecutor{
+		inner:        exec,
+		storage:      storage,
+		workspaceDir: cfg.WorkspaceDir,
+		logger:       logger,
+	}
+	// Workspace-level git credentials need the secret store; without an
+	// encryption key only per-user tokens work
+	if key := os.Getenv("XGENT_ENCRYPTION_KEY"); key != "" {
+		if store, err := secrets.NewStore(storage.Secrets(), key); err == nil {
+			runner.secrets = store
+		} else {
+			logger.Warn("Failed to initialize secret store for git flow", zap.Error(err))
+		}
+	}
+	o := &Orchestrator{
 		config:   cfg,
 		storage:  storage,
 		logger:   logger,
 		queue:    NewTaskQueue(cfg.Workers),
-		executor: executor.NewAgnoExecutor(storage, logger),
+		executor: exec,
+		reporter: NewProgressReporter(storage, webhooks, logger),
+		webhooks: webhooks,
+		digests:  digests,
+		janitor:  NewLogJanitor(storage, cfg.Attachment, logger),
+		ctx:      ctx,
+		cancel:   cancel,
 	}
+	o.registry = newWorkerRegistry(o, cfg.VisibilityTimeout)
+	// The retry policy wraps the git flow runner so both queue backends
+	// share the same failure handling
+	o.runner = &retryingExecutor{inner: runner, orch: o}
+	return o
 }
 
 // Start starts the orchestrator
 func (o *Orchestrator) Start() error {
-	o.logger.Info("Starting orchestrator", zap.Int("workers", o.config.Workers))
-	o.queue.Start(o.executor)
+	o.logger.Info("Starting orchestrator",
+		zap.Int("workers", o.config.Workers),
+		zap.String("queue_backend", o.queueBackendName()),
+	)
+
+	o.webhooks.Start()
+	o.digests.Start()
+	o.janitor.Start()
+	o.registry.Start()
+	o.startScheduler()
+	o.startDependencyWatcher()
+
+	if o.config.QueueBackend == "redis" {
+		hostname, _ := os.Hostname()
+		backend, err := NewRedisQueue(&RedisQueueConfig{
+			Addr:              o.config.RedisAddr,
+			Password:          o.config.RedisPassword,
+			DB:                o.config.RedisDB,
+			Consumer:          fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+			VisibilityTimeout: o.config.VisibilityTimeout,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize redis queue: %w", err)
+		}
+		o.backend = backend
+
+		for i := 0; i < o.config.Workers; i++ {
+			o.wg.Add(1)
+			go o.distributedWorker(i)
+		}
+		return o.recoverTasks()
+	}
+
+	o.queue.Start(o.runner)
+	return o.recoverTasks()
+}
+
+// recoverTasks handles tasks left in pending or running state by a
+// previous process. Depending on the configured policy they are either
+// re-enqueued or marked failed; either way a recovery event is written to
+// the task log.
+func (o *Orchestrator) recoverTasks() error {
+	tasks, err := o.storage.Tasks().ListByStatuses(models.TaskStatusPending, models.TaskStatusRunning)
+	if err != nil {
+		return fmt.Errorf("failed to scan for orphaned tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	policy := o.config.RecoveryPolicy
+	if policy == "" {
+		policy = "requeue"
+	}
+
+	o.logger.Info("Recovering orphaned tasks",
+		zap.Int("count", len(tasks)),
+		zap.String("policy", policy),
+	)
+
+	for _, task := range tasks {
+		switch policy {
+		case "fail":
+			task.Status = models.TaskStatusFailed
+			task.Error = "task orphaned by server restart"
+			if err := o.storage.Tasks().Update(task); err != nil {
+				o.logger.Error("Failed to mark orphaned task failed",
+					zap.Uint("task_id", task.ID), zap.Error(err))
+				continue
+			}
+			o.addRecoveryLog(task.ID, "marked failed after server restart")
+
+		default: // requeue
+			task.Status = models.TaskStatusPending
+			task.Progress = 0
+			if err := o.storage.Tasks().Update(task); err != nil {
+				o.logger.Error("Failed to reset orphaned task",
+					zap.Uint("task_id", task.ID), zap.Error(err))
+				continue
+			}
+			if err := o.SubmitTask(task, nil); err != nil {
+				o.logger.Error("Failed to re-enqueue orphaned task",
+					zap.Uint("task_id", task.ID), zap.Error(err))
+				continue
+			}
+			o.addRecoveryLog(task.ID, "re-enqueued after server restart")
+		}
+	}
+
 	return nil
 }
 
+// addRecoveryLog writes a recovery event to the task log
+func (o *Orchestrator) addRecoveryLog(taskID uint, message string) {
+	if err := o.storage.Tasks().AddLog(&models.TaskLog{
+		TaskID:    taskID,
+		Level:     "info",
+		Message:   message,
+		EventType: "recovery",
+	}); err != nil {
+		o.logger.Warn("Failed to write recovery log", zap.Uint("task_id", taskID), zap.Error(err))
+	}
+}
+
 // Stop stops the orchestrator
 func (o *Orchestrator) Stop() error {
 	o.logger.Info("Stopping orchestrator")
+
+	o.cancel()
+	o.registry.Stop()
+	o.janitor.Stop()
+	o.digests.Stop()
+	o.webhooks.Stop()
+	o.executor.Close()
+	if o.backend != nil {
+		o.wg.Wait()
+		return o.backend.Close()
+	}
+
 	o.queue.Stop()
 	return nil
 }
@@ -57,6 +240,26 @@ func (o *Orchestrator) SubmitTask(task *models.Task, callback ProgressCallback)
 		zap.String("title", task.Title),
 	)
 
+	// The orchestrator's own reporter handles progress persistence and
+	// event broadcast; per-request callbacks are optional extras
+	if callback == nil {
+		callback = o.reporter.Callback()
+	}
+
+	if err := o.checkRobotLimits(task); err != nil {
+		return err
+	}
+	if err := o.checkWorkspaceBudget(task); err != nil {
+		return err
+	}
+
+	if o.backend != nil {
+		if err := o.backend.Enqueue(o.ctx, task.ID); err != nil {
+			return fmt.Errorf("failed to enqueue task: %w", err)
+		}
+		return nil
+	}
+
 	if err := o.queue.Enqueue(task, callback); err != nil {
 		return fmt.Errorf("failed to enqueue task: %w", err)
 	}
@@ -64,6 +267,11 @@ func (o *Orchestrator) SubmitTask(task *models.Task, callback ProgressCallback)
 	return nil
 }
 
+// LogJanitor returns the log retention janitor, for the admin API
+func (o *Orchestrator) LogJanitor() *LogJanitor {
+	return o.janitor
+}
+
 // CancelTask cancels a running task
 func (o *Orchestrator) CancelTask(taskID uint) error {
 	return o.queue.Cancel(taskID)
@@ -73,3 +281,58 @@ func (o *Orchestrator) CancelTask(taskID uint) error {
 func (o *Orchestrator) GetActiveTasks() []*TaskItem {
 	return o.queue.GetActive()
 }
+
+// distributedWorker consumes tasks from the distributed queue backend.
+// Deliveries are acknowledged only after execution finishes, so tasks held
+// by a crashed worker are redelivered once their visibility timeout elapses.
+func (o *Orchestrator) distributedWorker(id int) {
+	defer o.wg.Done()
+
+	for {
+		delivery, err := o.backend.Dequeue(o.ctx)
+		if err != nil {
+			if o.ctx.Err() != nil {
+				return
+			}
+			o.logger.Error("Failed to dequeue task", zap.Int("worker", id), zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		task, err := o.storage.Tasks().GetByID(delivery.TaskID)
+		if err != nil {
+			o.logger.Warn("Dequeued unknown task, acknowledging",
+				zap.Uint("task_id", delivery.TaskID), zap.Error(err))
+			o.backend.Ack(o.ctx, delivery)
+			continue
+		}
+
+		// At-least-once delivery: skip tasks another worker already finished
+		if task.Status == models.TaskStatusCompleted ||
+			task.Status == models.TaskStatusFailed ||
+			task.Status == models.TaskStatusCancelled {
+			o.backend.Ack(o.ctx, delivery)
+			continue
+		}
+
+		if err := o.runner.Execute(o.ctx, task, o.reporter.Callback()); err != nil {
+			o.logger.Error("Task execution failed",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+		}
+
+		// Acknowledge regardless of execution outcome: failures are
+		// recorded on the task itself and must not be redelivered
+		if err := o.backend.Ack(context.Background(), delivery); err != nil {
+			o.logger.Error("Failed to acknowledge delivery",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+		}
+	}
+}
+
+// queueBackendName returns the configured backend name for logging
+func (o *Orchestrator) queueBackendName() string {
+	if o.config.QueueBackend == "" {
+		return "memory"
+	}
+	return o.config.QueueBackend
+}
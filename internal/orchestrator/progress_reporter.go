@@ -0,0 +1,133 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/events"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/webhook"
+	"go.uber.org/zap"
+)
+
+// ProgressReporter is the orchestrator's built-in progress sink. It
+// broadcasts execution events to WebSocket subscribers, persists progress
+// atomically and writes task logs. Because it lives in the orchestrator
+// rather than in a per-request closure, tasks recovered after a restart or
+// picked up by another worker report progress exactly like freshly submitted
+// ones.
+type ProgressReporter struct {
+	storage  *storage.Storage
+	logger   *zap.Logger
+	webhooks *webhook.Dispatcher
+
+	// workspaces caches task -> workspace lookups so workspace-wide event
+	// routing does not query the DB on every progress event
+	mu         sync.Mutex
+	workspaces map[uint]uint
+}
+
+// NewProgressReporter creates a progress reporter
+func NewProgressReporter(storage *storage.Storage, webhooks *webhook.Dispatcher, logger *zap.Logger) *ProgressReporter {
+	return &ProgressReporter{
+		storage:    storage,
+		logger:     logger,
+		webhooks:   webhooks,
+		workspaces: make(map[uint]uint),
+	}
+}
+
+// workspaceFor resolves the workspace a task belongs to, caching the result
+func (r *ProgressReporter) workspaceFor(taskID uint) uint {
+	r.mu.Lock()
+	workspaceID, ok := r.workspaces[taskID]
+	r.mu.Unlock()
+	if ok {
+		return workspaceID
+	}
+
+	task, err := r.storage.Tasks().GetByID(taskID)
+	if err != nil {
+		return 0
+	}
+
+	r.mu.Lock()
+	r.workspaces[taskID] = task.WorkspaceID
+	r.mu.Unlock()
+	return task.WorkspaceID
+}
+
+// Callback returns the reporter as a ProgressCallback
+func (r *ProgressReporter) Callback() ProgressCallback {
+	return r.Report
+}
+
+// Report handles a single progress event
+func (r *ProgressReporter) Report(taskID uint, progress int, status models.TaskStatus, message string, metadata map[string]interface{}) {
+	// Determine event type
+	eventType := "info"
+	if metadata != nil {
+		if t, ok := metadata["type"].(string); ok {
+			eventType = t
+		}
+	}
+
+	// Extract details from metadata
+	var details map[string]interface{}
+	if metadata != nil {
+		if d, ok := metadata["details"].(map[string]interface{}); ok {
+			details = d
+		}
+	}
+
+	// Broadcast event in real-time to WebSocket subscribers
+	events.GetBroadcaster().Broadcast(events.TaskEvent{
+		TaskID:      taskID,
+		WorkspaceID: r.workspaceFor(taskID),
+		Type:        "log",
+		EventType:   eventType,
+		Content:     message,
+		Details:     details,
+		Progress:    progress,
+		Status:      string(status),
+	})
+
+	// Fire workspace webhooks on status transitions
+	if r.webhooks != nil {
+		r.webhooks.NotifyStatus(taskID, status)
+	}
+
+	// Drop finished tasks from the workspace cache
+	if status == models.TaskStatusCompleted ||
+		status == models.TaskStatusFailed ||
+		status == models.TaskStatusCancelled {
+		r.mu.Lock()
+		delete(r.workspaces, taskID)
+		r.mu.Unlock()
+	}
+
+	// Persist atomically so concurrent callbacks cannot move progress backwards
+	if err := r.storage.Tasks().UpdateProgress(taskID, progress, status); err != nil {
+		r.logger.Warn("Failed to update task progress",
+			zap.Uint("task_id", taskID), zap.Error(err))
+	}
+
+	// Build JSON message with type, content, and details for frontend parsing
+	logMessage := map[string]interface{}{
+		"type":    eventType,
+		"content": message,
+	}
+	if details != nil {
+		logMessage["details"] = details
+	}
+	msgJSON, _ := json.Marshal(logMessage)
+
+	// Add log entry with full event data
+	r.storage.Tasks().AddLog(&models.TaskLog{
+		TaskID:    taskID,
+		Level:     "info",
+		Message:   string(msgJSON),
+		EventType: eventType,
+	})
+}
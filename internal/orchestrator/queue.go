@@ -4,26 +4,51 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/tracing"
 )
 
-// TaskQueue manages task queuing and distribution
+// queueCapacity bounds the total number of queued tasks across all levels
+const queueCapacity = 100
+
+// starvationEvery is the dispatch interval at which the longest-waiting task
+// from the lowest non-empty level runs regardless of priority, so a stream of
+// high-priority work cannot starve batch jobs indefinitely
+const starvationEvery = 8
+
+// TaskQueue manages task queuing and distribution across priority levels
 type TaskQueue struct {
-	tasks   chan *TaskItem
-	workers int
-	wg      sync.WaitGroup
-	ctx     context.Context
-	cancel  context.CancelFunc
-	mu      sync.RWMutex
-	active  map[uint]*TaskItem
+	levels     [3][]*TaskItem // high, normal, low
+	ready      chan struct{}  // one token per queued item
+	dispatches int
+	workers    int
+	wg         sync.WaitGroup
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mu         sync.RWMutex
+	active     map[uint]*TaskItem
+}
+
+// priorityLevel maps a task priority to its queue level index
+func priorityLevel(p models.TaskPriority) int {
+	switch p {
+	case models.TaskPriorityHigh:
+		return 0
+	case models.TaskPriorityLow:
+		return 2
+	default:
+		return 1
+	}
 }
 
 // TaskItem wraps a task with execution context
 type TaskItem struct {
-	Task     *models.Task
-	Context  context.Context
-	Callback ProgressCallback
+	Task       *models.Task
+	Context    context.Context
+	Callback   ProgressCallback
+	EnqueuedAt time.Time
 }
 
 // ProgressCallback is an alias for models.ProgressCallback
@@ -33,7 +58,7 @@ type ProgressCallback = models.ProgressCallback
 func NewTaskQueue(workers int) *TaskQueue {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &TaskQueue{
-		tasks:   make(chan *TaskItem, 100),
+		ready:   make(chan struct{}, queueCapacity),
 		workers: workers,
 		ctx:     ctx,
 		cancel:  cancel,
@@ -52,28 +77,59 @@ func (q *TaskQueue) Start(executor TaskExecutor) {
 // Stop stops the task queue
 func (q *TaskQueue) Stop() {
 	q.cancel()
-	close(q.tasks)
 	q.wg.Wait()
 }
 
-// Enqueue adds a task to the queue
+// Enqueue adds a task to the queue at its priority level
 func (q *TaskQueue) Enqueue(task *models.Task, callback ProgressCallback) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
+	queued := 0
+	for _, level := range q.levels {
+		queued += len(level)
+	}
+	if queued >= queueCapacity {
+		return fmt.Errorf("queue is full")
+	}
+
 	item := &TaskItem{
-		Task:     task,
-		Context:  q.ctx,
-		Callback: callback,
+		Task:       task,
+		Context:    q.ctx,
+		Callback:   callback,
+		EnqueuedAt: time.Now(),
 	}
 
-	select {
-	case q.tasks <- item:
-		q.active[task.ID] = item
-		return nil
-	default:
-		return fmt.Errorf("queue is full")
+	level := priorityLevel(task.Priority)
+	q.levels[level] = append(q.levels[level], item)
+	q.active[task.ID] = item
+	q.ready <- struct{}{}
+	return nil
+}
+
+// dequeue pops the next task to run: normally the longest-waiting item from
+// the highest non-empty level, but every starvationEvery-th dispatch takes
+// from the lowest non-empty level instead. Returns nil when nothing is
+// queued (e.g. the item's ready token outlived a cancellation).
+func (q *TaskQueue) dequeue() *TaskItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.dispatches++
+	order := []int{0, 1, 2}
+	if q.dispatches%starvationEvery == 0 {
+		order = []int{2, 1, 0}
 	}
+
+	for _, level := range order {
+		if len(q.levels[level]) == 0 {
+			continue
+		}
+		item := q.levels[level][0]
+		q.levels[level] = q.levels[level][1:]
+		return item
+	}
+	return nil
 }
 
 // Cancel cancels a task
@@ -92,6 +148,17 @@ func (q *TaskQueue) Cancel(taskID uint) error {
 		item.Callback(taskID, 0, models.TaskStatusCancelled, "Task cancelled by user", nil)
 	}
 
+	// Drop it from the pending levels if it has not started yet; its ready
+	// token then dequeues nothing
+	for level := range q.levels {
+		for i, queued := range q.levels[level] {
+			if queued.Task.ID == taskID {
+				q.levels[level] = append(q.levels[level][:i], q.levels[level][i+1:]...)
+				break
+			}
+		}
+	}
+
 	delete(q.active, taskID)
 	return nil
 }
@@ -116,11 +183,13 @@ func (q *TaskQueue) worker(id int, executor TaskExecutor) {
 		select {
 		case <-q.ctx.Done():
 			return
-		case item, ok := <-q.tasks:
+		case _, ok := <-q.ready:
 			if !ok {
 				return
 			}
-			q.processTask(id, item, executor)
+			if item := q.dequeue(); item != nil {
+				q.processTask(id, item, executor)
+			}
 		}
 	}
 }
@@ -134,17 +203,26 @@ func (q *TaskQueue) processTask(workerID int, item *TaskItem, executor TaskExecu
 
 		if r := recover(); r != nil {
 			if item.Callback != nil {
-				item.Callback(item.Task.ID, 0, models.TaskStatusFailed, 
+				item.Callback(item.Task.ID, 0, models.TaskStatusFailed,
 					fmt.Sprintf("panic: %v", r), nil)
 			}
 		}
 	}()
 
+	ctx, span := tracing.StartSpan(item.Context, "task.execute", tracing.KindInternal)
+	span.SetAttribute("task.id", fmt.Sprintf("%d", item.Task.ID))
+	span.SetAttribute("task.priority", string(item.Task.Priority))
+	if !item.EnqueuedAt.IsZero() {
+		span.SetAttribute("queue.wait_ms", fmt.Sprintf("%d", time.Since(item.EnqueuedAt).Milliseconds()))
+	}
+	defer span.End()
+
 	// Execute task
-	err := executor.Execute(item.Context, item.Task, item.Callback)
+	err := executor.Execute(ctx, item.Task, item.Callback)
+	span.RecordError(err)
 	if err != nil {
 		if item.Callback != nil {
-			item.Callback(item.Task.ID, 0, models.TaskStatusFailed, 
+			item.Callback(item.Task.ID, 0, models.TaskStatusFailed,
 				fmt.Sprintf("execution failed: %v", err), nil)
 		}
 	}
@@ -0,0 +1,164 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisStreamKey    = "xgent:tasks"
+	redisConsumerGrp  = "xgent-workers"
+	redisBlockTimeout = 5 * time.Second
+)
+
+// RedisQueue implements Queue on top of Redis Streams with a consumer
+// group. Unacknowledged messages whose idle time exceeds the visibility
+// timeout are claimed back and redelivered, giving at-least-once delivery
+// across worker processes.
+type RedisQueue struct {
+	client            *redis.Client
+	consumer          string
+	visibilityTimeout time.Duration
+}
+
+// RedisQueueConfig contains Redis queue configuration
+type RedisQueueConfig struct {
+	Addr              string
+	Password          string
+	DB                int
+	Consumer          string // unique per worker process
+	VisibilityTimeout time.Duration
+}
+
+// NewRedisQueue creates a Redis Streams backed task queue
+func NewRedisQueue(cfg *RedisQueueConfig) (*RedisQueue, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	// Create the consumer group, ignoring the error if it already exists
+	if err := client.XGroupCreateMkStream(ctx, redisStreamKey, redisConsumerGrp, "0").Err(); err != nil &&
+		err.Error() != "BUSYGROUP Consumer Group name already exists" {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	visibility := cfg.VisibilityTimeout
+	if visibility <= 0 {
+		visibility = 5 * time.Minute
+	}
+
+	return &RedisQueue{
+		client:            client,
+		consumer:          cfg.Consumer,
+		visibilityTimeout: visibility,
+	}, nil
+}
+
+// Enqueue adds a task to the stream
+func (q *RedisQueue) Enqueue(ctx context.Context, taskID uint) error {
+	return q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: redisStreamKey,
+		Values: map[string]interface{}{"task_id": taskID},
+	}).Err()
+}
+
+// Dequeue blocks until a task is available. Before reading new messages it
+// attempts to claim messages whose visibility timeout has elapsed.
+func (q *RedisQueue) Dequeue(ctx context.Context) (*Delivery, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Reclaim timed-out deliveries from dead workers first
+		if delivery, ok := q.claimExpired(ctx); ok {
+			return delivery, nil
+		}
+
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    redisConsumerGrp,
+			Consumer: q.consumer,
+			Streams:  []string{redisStreamKey, ">"},
+			Count:    1,
+			Block:    redisBlockTimeout,
+		}).Result()
+
+		if err == redis.Nil {
+			continue // Block timed out with no messages
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stream: %w", err)
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				if delivery := deliveryFromMessage(msg); delivery != nil {
+					return delivery, nil
+				}
+				// Malformed message: ack so it doesn't loop forever
+				q.client.XAck(ctx, redisStreamKey, redisConsumerGrp, msg.ID)
+			}
+		}
+	}
+}
+
+// claimExpired claims one message whose idle time exceeds the visibility timeout
+func (q *RedisQueue) claimExpired(ctx context.Context) (*Delivery, bool) {
+	messages, _, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   redisStreamKey,
+		Group:    redisConsumerGrp,
+		Consumer: q.consumer,
+		MinIdle:  q.visibilityTimeout,
+		Start:    "0-0",
+		Count:    1,
+	}).Result()
+	if err != nil || len(messages) == 0 {
+		return nil, false
+	}
+
+	if delivery := deliveryFromMessage(messages[0]); delivery != nil {
+		return delivery, true
+	}
+	q.client.XAck(ctx, redisStreamKey, redisConsumerGrp, messages[0].ID)
+	return nil, false
+}
+
+// Ack acknowledges a delivery
+func (q *RedisQueue) Ack(ctx context.Context, delivery *Delivery) error {
+	return q.client.XAck(ctx, redisStreamKey, redisConsumerGrp, delivery.MessageID).Err()
+}
+
+// Close closes the Redis connection
+func (q *RedisQueue) Close() error {
+	return q.client.Close()
+}
+
+// deliveryFromMessage extracts a delivery from a stream message
+func deliveryFromMessage(msg redis.XMessage) *Delivery {
+	raw, ok := msg.Values["task_id"]
+	if !ok {
+		return nil
+	}
+	str, ok := raw.(string)
+	if !ok {
+		return nil
+	}
+	taskID, err := strconv.ParseUint(str, 10, 32)
+	if err != nil {
+		return nil
+	}
+	return &Delivery{TaskID: uint(taskID), MessageID: msg.ID}
+}
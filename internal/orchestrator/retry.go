@@ -0,0 +1,96 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxTaskAttempts is how many times a task runs before it is parked
+	// in dead_letter
+	maxTaskAttempts = 3
+
+	// retryBaseDelay is the backoff before the first retry; it doubles per
+	// attempt (30s, 1m, 2m, ...)
+	retryBaseDelay = 30 * time.Second
+)
+
+// retryingExecutor wraps the task runner with a bounded retry policy.
+// Failed tasks are re-queued with exponential backoff until their attempt
+// budget is spent, then parked in dead_letter for an operator to inspect
+// and requeue. Cancellations and shutdown never retry.
+type retryingExecutor struct {
+	inner TaskExecutor
+	orch  *Orchestrator
+}
+
+// Execute counts the attempt, runs the task, and applies the retry policy
+// to failures
+func (r *retryingExecutor) Execute(ctx context.Context, task *models.Task, callback ProgressCallback) error {
+	task.Attempts++
+	if err := r.orch.storage.Tasks().Update(task); err != nil {
+		r.orch.logger.Warn("Failed to record task attempt",
+			zap.Uint("task_id", task.ID), zap.Error(err))
+	}
+
+	err := r.inner.Execute(ctx, task, callback)
+	if err == nil {
+		return nil
+	}
+
+	// A cancelled task, a spent budget or a shutting-down process is not a
+	// failure to retry
+	if ctx.Err() != nil || task.Status == models.TaskStatusCancelled ||
+		task.Status == models.TaskStatusBudgetExceeded {
+		return err
+	}
+
+	if task.Attempts >= maxTaskAttempts {
+		task.Status = models.TaskStatusDeadLetter
+		if updateErr := r.orch.storage.Tasks().Update(task); updateErr != nil {
+			r.orch.logger.Error("Failed to park task in dead letter",
+				zap.Uint("task_id", task.ID), zap.Error(updateErr))
+			return err
+		}
+		r.orch.addRecoveryLog(task.ID,
+			fmt.Sprintf("parked in dead letter after %d attempts: %s", task.Attempts, task.Error))
+		if callback != nil {
+			callback(task.ID, task.Progress, models.TaskStatusDeadLetter,
+				fmt.Sprintf("Task parked after %d failed attempts", task.Attempts), nil)
+		}
+		r.orch.logger.Warn("Task parked in dead letter",
+			zap.Uint("task_id", task.ID),
+			zap.Int("attempts", task.Attempts))
+		return err
+	}
+
+	delay := retryBaseDelay << (task.Attempts - 1)
+	task.Status = models.TaskStatusPending
+	task.Progress = 0
+	if updateErr := r.orch.storage.Tasks().Update(task); updateErr != nil {
+		r.orch.logger.Error("Failed to reset task for retry",
+			zap.Uint("task_id", task.ID), zap.Error(updateErr))
+		return err
+	}
+	r.orch.addRecoveryLog(task.ID,
+		fmt.Sprintf("retry %d/%d scheduled in %s: %s", task.Attempts+1, maxTaskAttempts, delay, task.Error))
+	r.orch.logger.Info("Scheduling task retry",
+		zap.Uint("task_id", task.ID),
+		zap.Int("attempt", task.Attempts),
+		zap.Duration("delay", delay))
+
+	time.AfterFunc(delay, func() {
+		if r.orch.ctx.Err() != nil {
+			return
+		}
+		if submitErr := r.orch.SubmitTask(task, nil); submitErr != nil {
+			r.orch.logger.Error("Failed to re-enqueue task for retry",
+				zap.Uint("task_id", task.ID), zap.Error(submitErr))
+		}
+	})
+	return err
+}
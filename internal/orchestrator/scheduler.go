@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// scheduleInterval is how often due scheduled tasks are scanned for
+	scheduleInterval = 10 * time.Second
+
+	// scheduleBatchLimit caps how many due tasks one pass enqueues
+	scheduleBatchLimit = 100
+)
+
+// startScheduler begins the loop that enqueues tasks whose run_at has
+// arrived. Scheduled tasks live only in the database, so they survive
+// restarts; the conditional claim keeps multiple processes from enqueueing
+// the same task twice.
+func (o *Orchestrator) startScheduler() {
+	o.wg.Add(1)
+	go o.scheduleLoop()
+}
+
+func (o *Orchestrator) scheduleLoop() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+		case <-ticker.C:
+			o.enqueueDueTasks()
+		}
+	}
+}
+
+// enqueueDueTasks claims and submits every scheduled task whose time has come
+func (o *Orchestrator) enqueueDueTasks() {
+	tasks, err := o.storage.Tasks().ListDueScheduled(time.Now(), scheduleBatchLimit)
+	if err != nil {
+		o.logger.Warn("Failed to scan for due scheduled tasks", zap.Error(err))
+		return
+	}
+
+	for _, task := range tasks {
+		claimed, err := o.storage.Tasks().ClaimScheduled(task.ID)
+		if err != nil {
+			o.logger.Error("Failed to claim scheduled task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue // another process got there first
+		}
+
+		task.Status = models.TaskStatusPending
+		o.logger.Info("Enqueueing scheduled task",
+			zap.Uint("task_id", task.ID),
+			zap.Timep("run_at", task.RunAt))
+
+		if err := o.SubmitTask(task, nil); err != nil {
+			o.logger.Error("Failed to submit scheduled task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+		o.addRecoveryLog(task.ID, "enqueued by scheduler at run_at")
+	}
+}
@@ -0,0 +1,163 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// heartbeatInterval is how often a worker refreshes its registry row
+	// and the leases of tasks it holds
+	heartbeatInterval = 15 * time.Second
+
+	// reaperInterval is how often expired task leases are scanned for
+	reaperInterval = time.Minute
+
+	// reaperBatchLimit caps how many expired tasks one reaper pass re-queues
+	reaperBatchLimit = 100
+
+	// defaultLeaseDuration is the task lease when no visibility timeout is
+	// configured
+	defaultLeaseDuration = 5 * time.Minute
+)
+
+// WorkerStaleAfter is how long without a heartbeat before a worker counts
+// as dead. Three missed heartbeats tolerate a slow database without
+// flapping.
+const WorkerStaleAfter = 3 * heartbeatInterval
+
+// workerRegistry records this process in the worker table, keeps its
+// heartbeat and task leases fresh, and re-queues running tasks whose lease
+// expired — so work held by a crashed worker is picked up instead of
+// hanging in running state forever.
+type workerRegistry struct {
+	orch  *Orchestrator
+	name  string
+	lease time.Duration
+
+	done chan struct{}
+}
+
+// newWorkerRegistry creates the registry for this process. The lease
+// duration follows the queue's visibility timeout so both reclamation
+// paths agree on when a worker counts as gone.
+func newWorkerRegistry(orch *Orchestrator, lease time.Duration) *workerRegistry {
+	if lease <= 0 {
+		lease = defaultLeaseDuration
+	}
+	hostname, _ := os.Hostname()
+	return &workerRegistry{
+		orch:  orch,
+		name:  fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		lease: lease,
+		done:  make(chan struct{}),
+	}
+}
+
+// Start registers the worker and begins the heartbeat and reaper loops
+func (r *workerRegistry) Start() {
+	hostname, _ := os.Hostname()
+	now := time.Now()
+	if err := r.orch.storage.Workers().Register(&models.Worker{
+		Name:          r.name,
+		Hostname:      hostname,
+		PID:           os.Getpid(),
+		Concurrency:   r.orch.config.Workers,
+		QueueBackend:  r.orch.queueBackendName(),
+		StartedAt:     now,
+		LastHeartbeat: now,
+	}); err != nil {
+		r.orch.logger.Warn("Failed to register worker", zap.Error(err))
+	}
+
+	r.orch.wg.Add(1)
+	go r.run()
+}
+
+// Stop signals the loops to exit; the orchestrator's wait group covers them
+func (r *workerRegistry) Stop() {
+	close(r.done)
+}
+
+func (r *workerRegistry) run() {
+	defer r.orch.wg.Done()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	reap := time.NewTicker(reaperInterval)
+	defer reap.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-r.orch.ctx.Done():
+			return
+		case <-heartbeat.C:
+			r.beat()
+		case <-reap.C:
+			r.reapExpired()
+		}
+	}
+}
+
+// beat refreshes the registry row and extends the lease on every running
+// task this worker holds
+func (r *workerRegistry) beat() {
+	now := time.Now()
+	if err := r.orch.storage.Workers().Heartbeat(r.name, now); err != nil {
+		r.orch.logger.Warn("Failed to record worker heartbeat", zap.Error(err))
+	}
+	if err := r.orch.storage.Tasks().RenewLeases(r.name, now.Add(r.lease)); err != nil {
+		r.orch.logger.Warn("Failed to renew task leases", zap.Error(err))
+	}
+}
+
+// assignLease marks a task as held by this worker before execution starts
+func (r *workerRegistry) assignLease(taskID uint) {
+	if err := r.orch.storage.Tasks().AssignLease(taskID, r.name, time.Now().Add(r.lease)); err != nil {
+		r.orch.logger.Warn("Failed to assign task lease",
+			zap.Uint("task_id", taskID), zap.Error(err))
+	}
+}
+
+// reapExpired re-queues running tasks whose lease expired and drops
+// registry rows of long-dead workers. Every worker reaps; the status reset
+// plus at-least-once delivery make a double re-queue harmless.
+func (r *workerRegistry) reapExpired() {
+	tasks, err := r.orch.storage.Tasks().ListExpiredLeases(time.Now(), reaperBatchLimit)
+	if err != nil {
+		r.orch.logger.Warn("Failed to scan for expired task leases", zap.Error(err))
+		return
+	}
+
+	for _, task := range tasks {
+		r.orch.logger.Warn("Re-queueing task with expired lease",
+			zap.Uint("task_id", task.ID),
+			zap.String("worker", task.WorkerName))
+
+		task.Status = models.TaskStatusPending
+		task.Progress = 0
+		task.LeaseExpiresAt = nil
+		if err := r.orch.storage.Tasks().Update(task); err != nil {
+			r.orch.logger.Error("Failed to reset expired task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+		if err := r.orch.SubmitTask(task, nil); err != nil {
+			r.orch.logger.Error("Failed to re-enqueue expired task",
+				zap.Uint("task_id", task.ID), zap.Error(err))
+			continue
+		}
+		r.orch.addRecoveryLog(task.ID, fmt.Sprintf("re-queued after worker %s lease expired", task.WorkerName))
+	}
+
+	// Registry rows a day past stale are just clutter
+	if err := r.orch.storage.Workers().DeleteStale(time.Now().Add(-24 * time.Hour)); err != nil {
+		r.orch.logger.Warn("Failed to prune stale workers", zap.Error(err))
+	}
+}
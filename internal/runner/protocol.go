@@ -0,0 +1,31 @@
+package runner
+
+import "encoding/json"
+
+// Message types exchanged between the server and a runner agent over the
+// WebSocket connection. Runners dial the server, so execution can happen
+// close to private codebases without opening database access.
+
+// Hello is the first message a runner sends after connecting
+type Hello struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Assignment asks a runner to execute a task with the given bridge config
+type Assignment struct {
+	ID     string          `json:"id"`
+	TaskID uint            `json:"task_id"`
+	Config json.RawMessage `json:"config"`
+
+	// Cancel asks the runner to abort a previously sent assignment
+	Cancel bool `json:"cancel,omitempty"`
+}
+
+// Message is sent by a runner while executing an assignment
+type Message struct {
+	Type         string `json:"type"` // event, done
+	AssignmentID string `json:"assignment_id"`
+	Line         string `json:"line,omitempty"`  // Raw bridge event line for type=event
+	Error        string `json:"error,omitempty"` // Execution error for type=done
+}
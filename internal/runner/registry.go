@@ -0,0 +1,199 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+// Registry tracks connected runner agents and dispatches assignments to them
+type Registry struct {
+	mu      sync.RWMutex
+	runners map[string]*Conn
+}
+
+var (
+	globalRegistry *Registry
+	registryOnce   sync.Once
+)
+
+// GetRegistry returns the global runner registry
+func GetRegistry() *Registry {
+	registryOnce.Do(func() {
+		globalRegistry = &Registry{
+			runners: make(map[string]*Conn),
+		}
+	})
+	return globalRegistry
+}
+
+// Conn is a connected runner agent
+type Conn struct {
+	Name string
+
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string]chan Message
+	nextID  uint64
+}
+
+// Register adds a connected runner, replacing any previous connection with
+// the same name
+func (r *Registry) Register(name string, ws *websocket.Conn) *Conn {
+	conn := &Conn{
+		Name:    name,
+		ws:      ws,
+		pending: make(map[string]chan Message),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.runners[name]; ok {
+		old.close()
+	}
+	r.runners[name] = conn
+	return conn
+}
+
+// Unregister removes a runner and fails its in-flight assignments
+func (r *Registry) Unregister(conn *Conn) {
+	r.mu.Lock()
+	if r.runners[conn.Name] == conn {
+		delete(r.runners, conn.Name)
+	}
+	r.mu.Unlock()
+
+	conn.close()
+}
+
+// Get returns a connected runner by name
+func (r *Registry) Get(name string) (*Conn, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	conn, ok := r.runners[name]
+	return conn, ok
+}
+
+// List returns the names of connected runners
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.runners))
+	for name := range r.runners {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Execute sends an assignment to the runner and returns a reader of the
+// bridge event lines it streams back. The reader is closed when the runner
+// reports completion; a non-nil error from Read (other than io.EOF) carries
+// the execution failure.
+func (c *Conn) Execute(ctx context.Context, taskID uint, config interface{}) (io.ReadCloser, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	id := fmt.Sprintf("task-%d-%d", taskID, atomic.AddUint64(&c.nextID, 1))
+	messages := make(chan Message, 64)
+
+	c.mu.Lock()
+	if c.pending == nil {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("runner %s disconnected", c.Name)
+	}
+	c.pending[id] = messages
+	c.mu.Unlock()
+
+	if err := c.send(Assignment{ID: id, TaskID: taskID, Config: payload}); err != nil {
+		c.finish(id)
+		return nil, fmt.Errorf("failed to send assignment to runner %s: %w", c.Name, err)
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		defer c.finish(id)
+		for {
+			select {
+			case <-ctx.Done():
+				c.send(Assignment{ID: id, Cancel: true})
+				writer.CloseWithError(ctx.Err())
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					writer.CloseWithError(fmt.Errorf("runner %s disconnected", c.Name))
+					return
+				}
+				switch msg.Type {
+				case "event":
+					if _, err := writer.Write([]byte(msg.Line + "\n")); err != nil {
+						return
+					}
+				case "done":
+					if msg.Error != "" {
+						writer.CloseWithError(fmt.Errorf("runner %s: %s", c.Name, msg.Error))
+					} else {
+						writer.Close()
+					}
+					return
+				}
+			}
+		}
+	}()
+
+	return reader, nil
+}
+
+// Dispatch routes a message from the runner to its in-flight assignment
+func (c *Conn) Dispatch(msg Message) {
+	c.mu.Lock()
+	messages, ok := c.pending[msg.AssignmentID]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case messages <- msg:
+	default:
+		// Slow consumer; drop the event rather than block the read loop
+	}
+}
+
+// send writes a message to the runner, serializing concurrent writers
+func (c *Conn) send(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ws.WriteJSON(v)
+}
+
+// finish removes an assignment's pending channel
+func (c *Conn) finish(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if messages, ok := c.pending[id]; ok {
+		delete(c.pending, id)
+		close(messages)
+	}
+}
+
+// close fails all in-flight assignments and closes the socket
+func (c *Conn) close() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	for _, messages := range pending {
+		close(messages)
+	}
+	c.ws.Close()
+}
@@ -0,0 +1,82 @@
+package secrets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
+)
+
+// RefPrefix marks a value as a reference to a stored secret, e.g. "secretRef:GITHUB_TOKEN"
+const RefPrefix = "secretRef:"
+
+// Store provides encrypted secret storage scoped per workspace
+type Store struct {
+	repo   *repositories.SecretRepository
+	cipher *Cipher
+}
+
+// NewStore creates a secret store backed by the database. The key is used
+// for AES-GCM encryption of stored values.
+func NewStore(repo *repositories.SecretRepository, key string) (*Store, error) {
+	cipher, err := NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{repo: repo, cipher: cipher}, nil
+}
+
+// IsRef reports whether a value is a secret reference
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, RefPrefix)
+}
+
+// RefName extracts the secret name from a reference value
+func RefName(value string) string {
+	return strings.TrimPrefix(value, RefPrefix)
+}
+
+// Set encrypts and stores a secret value, updating it if it already exists
+func (s *Store) Set(workspaceID uint, name, value string) error {
+	encrypted, err := s.cipher.Encrypt(value)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	existing, err := s.repo.GetByName(workspaceID, name)
+	if err == nil {
+		existing.Value = encrypted
+		return s.repo.Update(existing)
+	}
+
+	return s.repo.Create(&models.Secret{
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Value:       encrypted,
+	})
+}
+
+// Get retrieves and decrypts a secret value by name
+func (s *Store) Get(workspaceID uint, name string) (string, error) {
+	secret, err := s.repo.GetByName(workspaceID, name)
+	if err != nil {
+		return "", fmt.Errorf("secret not found: %s", name)
+	}
+
+	value, err := s.cipher.Decrypt(secret.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// Resolve resolves a value that may be a secret reference. Plain values are
+// returned unchanged.
+func (s *Store) Resolve(workspaceID uint, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+	return s.Get(workspaceID, RefName(value))
+}
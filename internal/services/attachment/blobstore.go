@@ -0,0 +1,87 @@
+package attachment
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BlobStore abstracts where attachment files are kept so multi-node
+// deployments can share an object store instead of local disk
+type BlobStore interface {
+	// Save writes a blob under the given key
+	Save(key string, r io.Reader, size int64, contentType string) error
+	// Open returns a reader for the blob
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the blob
+	Delete(key string) error
+	// PresignURL returns a time-limited download URL for the blob; ok is
+	// false when the backend cannot presign (e.g. local disk)
+	PresignURL(key, filename string, expires time.Duration) (url string, ok bool)
+}
+
+// Config configures the attachment service's storage backend
+type Config struct {
+	Backend   string // local or s3
+	UploadDir string // for local backend
+	S3        S3Config
+}
+
+// NewBlobStore creates the configured blob store, defaulting to local disk
+func NewBlobStore(cfg Config) (BlobStore, error) {
+	switch cfg.Backend {
+	case "", "local":
+		return newLocalStore(cfg.UploadDir), nil
+	case "s3":
+		return newS3Store(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown attachment backend: %s", cfg.Backend)
+	}
+}
+
+// localStore keeps blobs on the local filesystem
+type localStore struct {
+	dir string
+}
+
+func newLocalStore(dir string) *localStore {
+	os.MkdirAll(dir, 0755)
+	return &localStore{dir: dir}
+}
+
+// path resolves a key to a filesystem path. Absolute keys are used as-is so
+// records created before the blob store abstraction keep working.
+func (s *localStore) path(key string) string {
+	if filepath.IsAbs(key) {
+		return key
+	}
+	return filepath.Join(s.dir, key)
+}
+
+func (s *localStore) Save(key string, r io.Reader, size int64, contentType string) error {
+	dst, err := os.Create(s.path(key))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, r); err != nil {
+		os.Remove(s.path(key))
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+func (s *localStore) Open(key string) (io.ReadCloser, error) {
+	return os.Open(s.path(key))
+}
+
+func (s *localStore) Delete(key string) error {
+	return os.Remove(s.path(key))
+}
+
+func (s *localStore) PresignURL(key, filename string, expires time.Duration) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,146 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// indexChunkSize is the chunk length in characters used when embedding
+	// attachment text
+	indexChunkSize = 1200
+
+	// indexTimeout bounds one embedding pass over an attachment
+	indexTimeout = 1 * time.Minute
+)
+
+// ConfigureIndexing enables semantic indexing of processed attachments using
+// the given embeddings provider. Without it attachments keep only their raw
+// extracted text.
+func (s *Service) ConfigureIndexing(cfg llm.Config) {
+	if cfg.Provider == "" {
+		return
+	}
+	embedder, err := llm.NewEmbeddings(&cfg)
+	if err != nil {
+		s.logger.Warn("Attachment indexing disabled", zap.Error(err))
+		return
+	}
+	s.embedder = embedder
+}
+
+// VectorSource is the vector store source key for an attachment's chunks
+func VectorSource(attachmentID uint) string {
+	return fmt.Sprintf("attachment/%d", attachmentID)
+}
+
+// indexAttachment chunks an attachment's extracted text, embeds the chunks
+// and stores them in the vector store, keyed to the workspace of the task the
+// attachment belongs to. Attachments not yet linked to a task are skipped;
+// they are indexed when AttachToTask links them.
+func (s *Service) indexAttachment(attachment *models.Attachment) {
+	if s.embedder == nil || attachment.TaskID == nil || attachment.TextContent == "" {
+		return
+	}
+
+	task, err := s.storage.Tasks().GetByID(*attachment.TaskID)
+	if err != nil {
+		s.logger.Warn("Skipping attachment indexing for unknown task",
+			zap.Uint("attachment_id", attachment.ID), zap.Error(err))
+		return
+	}
+
+	texts := chunkForIndex(attachment.TextContent, indexChunkSize)
+	if len(texts) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	embeddings, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		s.logger.Warn("Failed to embed attachment chunks",
+			zap.Uint("attachment_id", attachment.ID), zap.Error(err))
+		return
+	}
+
+	source := VectorSource(attachment.ID)
+	chunks := make([]*models.VectorChunk, len(texts))
+	for i, text := range texts {
+		chunk := &models.VectorChunk{
+			WorkspaceID: task.WorkspaceID,
+			Source:      source,
+			ChunkIndex:  i,
+			Content:     text,
+		}
+		chunk.SetVector(embeddings[i])
+		chunks[i] = chunk
+	}
+
+	if err := s.storage.Vectors().ReplaceSource(task.WorkspaceID, source, chunks); err != nil {
+		s.logger.Warn("Failed to store attachment chunks",
+			zap.Uint("attachment_id", attachment.ID), zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Attachment indexed",
+		zap.Uint("attachment_id", attachment.ID),
+		zap.Int("chunks", len(chunks)),
+	)
+}
+
+// dropAttachmentIndex removes an attachment's chunks from the vector store
+func (s *Service) dropAttachmentIndex(attachment *models.Attachment) {
+	if attachment.TaskID == nil {
+		return
+	}
+	task, err := s.storage.Tasks().GetByID(*attachment.TaskID)
+	if err != nil {
+		return
+	}
+	if err := s.storage.Vectors().DeleteBySource(task.WorkspaceID, VectorSource(attachment.ID)); err != nil {
+		s.logger.Warn("Failed to drop attachment chunks",
+			zap.Uint("attachment_id", attachment.ID), zap.Error(err))
+	}
+}
+
+// chunkForIndex splits text into chunks of roughly the given size, breaking
+// at paragraph boundaries where possible
+func chunkForIndex(text string, size int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var chunks []string
+	var current strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if current.Len() > 0 && current.Len()+len(paragraph) > size {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		// Paragraphs larger than a chunk are split hard
+		for len(paragraph) > size {
+			chunks = append(chunks, paragraph[:size])
+			paragraph = paragraph[size:]
+		}
+		if paragraph == "" {
+			continue
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(paragraph)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
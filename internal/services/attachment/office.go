@@ -0,0 +1,237 @@
+package attachment
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+)
+
+// Office Open XML documents are ZIP archives of XML parts, so .docx and
+// .xlsx extraction needs only the standard library. Legacy binary formats
+// (.doc, .xls) are not parsed.
+
+// parseDocx extracts paragraph and table text from a .docx file
+func (p *DocumentParser) parseDocx(data []byte) (string, error) {
+	document, err := readZipPart(data, "word/document.xml")
+	if err != nil {
+		return "", fmt.Errorf("invalid docx file: %w", err)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	var b strings.Builder
+	inText := false
+
+	for b.Len() < models.MaxTextLength {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteByte('\t')
+			case "br":
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				// Paragraph boundary; inside tables this ends a cell line
+				b.WriteByte('\n')
+			case "tc":
+				// Table cells separate with tabs so rows read as one line
+				b.WriteByte('\t')
+			case "tr":
+				b.WriteByte('\n')
+			}
+		}
+	}
+
+	return clipText(b.String()), nil
+}
+
+// xlsxSharedStrings is the xl/sharedStrings.xml part; cells with t="s"
+// reference entries here by index
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string   `xml:"t"`
+		Runs []string `xml:"r>t"` // rich-text cells split into runs
+	} `xml:"si"`
+}
+
+// xlsxWorksheet is one xl/worksheets/sheetN.xml part
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// parseXlsx serializes the sheets of a .xlsx file as tab-separated rows,
+// one block per sheet, capped at the extraction size limit
+func (p *DocumentParser) parseXlsx(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid xlsx file: %w", err)
+	}
+
+	shared := loadSharedStrings(archive)
+
+	// Sheets serialize in part order (sheet1, sheet2, ...)
+	var sheetNames []string
+	for _, file := range archive.File {
+		if strings.HasPrefix(file.Name, "xl/worksheets/sheet") && strings.HasSuffix(file.Name, ".xml") {
+			sheetNames = append(sheetNames, file.Name)
+		}
+	}
+	sort.Strings(sheetNames)
+
+	var b strings.Builder
+	for i, name := range sheetNames {
+		if b.Len() >= models.MaxTextLength {
+			break
+		}
+
+		part, err := readZipPart(data, name)
+		if err != nil {
+			continue
+		}
+		var sheet xlsxWorksheet
+		if err := xml.Unmarshal(part, &sheet); err != nil {
+			continue
+		}
+
+		if len(sheetNames) > 1 {
+			fmt.Fprintf(&b, "# Sheet %d\n", i+1)
+		}
+		for _, row := range sheet.Rows {
+			values := make([]string, 0, len(row.Cells))
+			for _, cell := range row.Cells {
+				value := cell.Value
+				switch cell.Type {
+				case "s":
+					if index, err := strconv.Atoi(cell.Value); err == nil && index >= 0 && index < len(shared) {
+						value = shared[index]
+					}
+				case "inlineStr":
+					value = cell.Inline
+				}
+				values = append(values, value)
+			}
+			b.WriteString(strings.Join(values, "\t"))
+			b.WriteByte('\n')
+			if b.Len() >= models.MaxTextLength {
+				break
+			}
+		}
+	}
+
+	return clipText(b.String()), nil
+}
+
+// parseCSV normalizes a CSV file into tab-separated rows, tolerating
+// ragged row lengths and quoting quirks
+func (p *DocumentParser) parseCSV(data []byte) (string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var b strings.Builder
+	for b.Len() < models.MaxTextLength {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid csv file: %w", err)
+		}
+		b.WriteString(strings.Join(record, "\t"))
+		b.WriteByte('\n')
+	}
+
+	return clipText(b.String()), nil
+}
+
+// loadSharedStrings reads the shared string table; a missing or malformed
+// part just means cells resolve to their raw values
+func loadSharedStrings(archive *zip.Reader) []string {
+	for _, file := range archive.File {
+		if file.Name != "xl/sharedStrings.xml" {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil
+		}
+		part, err := io.ReadAll(io.LimitReader(reader, int64(models.MaxFileSize)))
+		reader.Close()
+		if err != nil {
+			return nil
+		}
+
+		var table xlsxSharedStrings
+		if err := xml.Unmarshal(part, &table); err != nil {
+			return nil
+		}
+		values := make([]string, len(table.Items))
+		for i, item := range table.Items {
+			if item.Text != "" {
+				values[i] = item.Text
+			} else {
+				// Rich-text cells split their value into runs
+				values[i] = strings.Join(item.Runs, "")
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// readZipPart extracts one named part from a ZIP archive
+func readZipPart(data []byte, name string) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(io.LimitReader(reader, int64(models.MaxFileSize)))
+	}
+	return nil, fmt.Errorf("part %s not found", name)
+}
+
+// clipText truncates extracted text to the storage limit
+func clipText(text string) string {
+	if len(text) > models.MaxTextLength {
+		return text[:models.MaxTextLength]
+	}
+	return strings.TrimSpace(text)
+}
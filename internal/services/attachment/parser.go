@@ -22,8 +22,14 @@ func (p *DocumentParser) Parse(data []byte, mimeType string) (string, error) {
 		return p.parseText(data)
 	case "application/pdf":
 		return p.parsePDF(data)
-	case "application/msword", "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+	case "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return p.parseDocx(data)
+	case "application/msword":
 		return p.parseWord(data)
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return p.parseXlsx(data)
+	case "text/csv":
+		return p.parseCSV(data)
 	case "image/jpeg", "image/png", "image/gif", "image/bmp", "image/webp":
 		return p.parseImage(data)
 	default:
@@ -48,12 +54,10 @@ func (p *DocumentParser) parsePDF(data []byte) (string, error) {
 	return "[PDF content - parser not implemented yet]", nil
 }
 
-// parseWord extracts text from Word documents
-// TODO: Implement actual Word parsing using a library
+// parseWord handles legacy binary .doc files, which have no stdlib-friendly
+// format; modern .docx files go through parseDocx instead
 func (p *DocumentParser) parseWord(data []byte) (string, error) {
-	// Placeholder implementation
-	// In production, use: github.com/fumiama/go-docx or similar
-	return "[Word document content - parser not implemented yet]", nil
+	return "[Legacy .doc file - convert to .docx for text extraction]", nil
 }
 
 // parseImage processes image files
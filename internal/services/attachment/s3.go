@@ -0,0 +1,251 @@
+package attachment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config configures the S3/MinIO attachment backend
+type S3Config struct {
+	Endpoint  string // e.g. http://localhost:9000 for MinIO, https://s3.amazonaws.com for AWS
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// s3Store keeps blobs in an S3-compatible object store. Requests are signed
+// with AWS Signature V4 directly so no SDK dependency is needed; path-style
+// addressing is used for MinIO compatibility.
+type s3Store struct {
+	cfg    S3Config
+	client *http.Client
+}
+
+func newS3Store(cfg S3Config) (*s3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("s3 backend requires endpoint, bucket, access_key and secret_key")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	return &s3Store{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+func (s *s3Store) objectURL(key string) string {
+	return fmt.Sprintf("%s/%s/%s", s.cfg.Endpoint, s.cfg.Bucket, uriEncode(key, false))
+}
+
+func (s *s3Store) Save(key string, r io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key), r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *s3Store) Open(key string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (s *s3Store) Delete(key string) error {
+	req, err := http.NewRequest(http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// PresignURL builds a query-signed GET URL so clients download straight from
+// the object store
+func (s *s3Store) PresignURL(key, filename string, expires time.Duration) (string, bool) {
+	now := time.Now().UTC()
+	credential := fmt.Sprintf("%s/%s/%s/s3/aws4_request",
+		s.cfg.AccessKey, now.Format("20060102"), s.cfg.Region)
+
+	base, err := url.Parse(s.objectURL(key))
+	if err != nil {
+		return "", false
+	}
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", credential)
+	query.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	query.Set("X-Amz-Expires", fmt.Sprintf("%d", int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if filename != "" {
+		query.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+
+	canonical := strings.Join([]string{
+		http.MethodGet,
+		base.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + base.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	signature := s.sign(now, canonical)
+	query.Set("X-Amz-Signature", signature)
+	base.RawQuery = canonicalQuery(query)
+
+	return base.String(), true
+}
+
+// do signs and executes a request, turning non-2xx responses into errors
+func (s *s3Store) do(req *http.Request) (*http.Response, error) {
+	now := time.Now().UTC()
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", "UNSIGNED-PAYLOAD")
+
+	// Canonical headers: host plus all x-amz-* headers, sorted
+	headerNames := []string{"host"}
+	for name := range req.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-") {
+			headerNames = append(headerNames, strings.ToLower(name))
+		}
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	signature := s.sign(now, canonical)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s/%s/s3/aws4_request, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, now.Format("20060102"), s.cfg.Region, signedHeaders, signature))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// sign computes the SigV4 signature for a canonical request
+func (s *s3Store) sign(now time.Time, canonical string) string {
+	scope := strings.Join([]string{now.Format("20060102"), s.cfg.Region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		scope,
+		hashHex([]byte(canonical)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalQuery encodes query parameters in the sorted, strictly-escaped
+// form SigV4 requires
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes per the SigV4 rules; when encodeSlash is false,
+// path separators are preserved
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-' || c == '.' || c == '_' || c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return b.String()
+}
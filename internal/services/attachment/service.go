@@ -1,13 +1,16 @@
 package attachment
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/xcode-ai/xgent-go/internal/llm"
 	"github.com/xcode-ai/xgent-go/internal/storage"
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"go.uber.org/zap"
@@ -15,27 +18,34 @@ import (
 
 // Service handles attachment business logic
 type Service struct {
-	storage    *storage.Storage
-	parser     *DocumentParser
-	uploadDir  string
-	logger     *zap.Logger
+	storage *storage.Storage
+	parser  *DocumentParser
+	store   BlobStore
+	logger  *zap.Logger
+
+	// embedder is set by ConfigureIndexing; nil disables semantic indexing
+	embedder llm.Embeddings
 }
 
-// NewService creates a new attachment service
-func NewService(storage *storage.Storage, uploadDir string, logger *zap.Logger) *Service {
-	// Ensure upload directory exists
-	os.MkdirAll(uploadDir, 0755)
-	
-	return &Service{
-		storage:   storage,
-		parser:    NewDocumentParser(),
-		uploadDir: uploadDir,
-		logger:    logger,
+// NewService creates a new attachment service backed by the configured blob
+// store
+func NewService(storage *storage.Storage, cfg Config, logger *zap.Logger) (*Service, error) {
+	store, err := NewBlobStore(cfg)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Service{
+		storage: storage,
+		parser:  NewDocumentParser(),
+		store:   store,
+		logger:  logger,
+	}, nil
 }
 
-// Upload handles file upload
-func (s *Service) Upload(file *multipart.FileHeader, userID uint) (*models.Attachment, error) {
+// Upload handles file upload; a non-nil taskID links the attachment to an
+// owned task in the same step
+func (s *Service) Upload(file *multipart.FileHeader, userID uint, taskID *uint) (*models.Attachment, error) {
 	// Validate file size
 	if file.Size > models.MaxFileSize {
 		return nil, fmt.Errorf("file size exceeds maximum allowed size of %d bytes", models.MaxFileSize)
@@ -47,10 +57,24 @@ func (s *Service) Upload(file *multipart.FileHeader, userID uint) (*models.Attac
 		return nil, fmt.Errorf("unsupported file type: %s", mimeType)
 	}
 
-	// Generate unique filename
+	// Validate task ownership and workspace storage quota before anything
+	// is stored
+	if taskID != nil {
+		task, err := s.storage.Tasks().GetByID(*taskID)
+		if err != nil {
+			return nil, fmt.Errorf("task not found")
+		}
+		if task.UserID != userID {
+			return nil, fmt.Errorf("access denied")
+		}
+		if err := s.checkStorageQuota(task.WorkspaceID, file.Size); err != nil {
+			return nil, err
+		}
+	}
+
+	// Generate unique storage key
 	ext := GetFileExtension(file.Filename)
-	uniqueFilename := uuid.New().String() + ext
-	storagePath := filepath.Join(s.uploadDir, uniqueFilename)
+	storageKey := uuid.New().String() + ext
 
 	// Open uploaded file
 	src, err := file.Open()
@@ -59,32 +83,39 @@ func (s *Service) Upload(file *multipart.FileHeader, userID uint) (*models.Attac
 	}
 	defer src.Close()
 
-	// Create destination file
-	dst, err := os.Create(storagePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create file: %w", err)
+	// Sniff the real content type from the leading bytes; the declared type
+	// alone is client-controlled
+	head := make([]byte, sniffLimit)
+	n, err := io.ReadFull(src, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	head = head[:n]
+	detectedType := sniffMimeType(head)
+	if !mimeMatches(mimeType, detectedType) {
+		return nil, fmt.Errorf("file content looks like %s, not %s", detectedType, mimeType)
 	}
-	defer dst.Close()
 
-	// Copy file content
-	if _, err := io.Copy(dst, src); err != nil {
-		os.Remove(storagePath)
-		return nil, fmt.Errorf("failed to save file: %w", err)
+	// Write to the blob store, replaying the sniffed bytes
+	if err := s.store.Save(storageKey, io.MultiReader(bytes.NewReader(head), src), file.Size, mimeType); err != nil {
+		return nil, err
 	}
 
 	// Create attachment record
 	attachment := &models.Attachment{
 		UserID:      userID,
+		TaskID:      taskID,
 		Filename:    file.Filename,
 		FileSize:    file.Size,
 		MimeType:    mimeType,
+		DetectedMimeType: detectedType,
 		FileExt:     ext,
 		Status:      models.AttachmentStatusPending,
-		StoragePath: storagePath,
+		StoragePath: storageKey,
 	}
 
 	if err := s.storage.Attachments().Create(attachment); err != nil {
-		os.Remove(storagePath)
+		s.store.Delete(storageKey)
 		return nil, fmt.Errorf("failed to create attachment record: %w", err)
 	}
 
@@ -107,7 +138,7 @@ func (s *Service) processFile(attachmentID uint) {
 	s.storage.Attachments().Update(attachment)
 
 	// Read file content
-	data, err := os.ReadFile(attachment.StoragePath)
+	data, err := s.readBlob(attachment.StoragePath)
 	if err != nil {
 		s.logger.Error("Failed to read file", zap.Error(err))
 		s.storage.Attachments().UpdateStatus(attachmentID, models.AttachmentStatusFailed, err.Error())
@@ -133,6 +164,8 @@ func (s *Service) processFile(attachmentID uint) {
 	attachment.Status = models.AttachmentStatusCompleted
 	s.storage.Attachments().Update(attachment)
 
+	s.indexAttachment(attachment)
+
 	s.logger.Info("File processed successfully",
 		zap.Uint("attachment_id", attachmentID),
 		zap.Int("text_length", len(text)),
@@ -152,7 +185,7 @@ func (s *Service) GetFile(attachmentID uint, userID uint) ([]byte, string, error
 	}
 
 	// Read file
-	data, err := os.ReadFile(attachment.StoragePath)
+	data, err := s.readBlob(attachment.StoragePath)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to read file: %w", err)
 	}
@@ -160,6 +193,94 @@ func (s *Service) GetFile(attachmentID uint, userID uint) ([]byte, string, error
 	return data, attachment.Filename, nil
 }
 
+// DownloadURL returns a presigned URL for the attachment when the backend
+// supports it; ok is false for local storage
+func (s *Service) DownloadURL(attachmentID, userID uint) (string, bool, error) {
+	attachment, err := s.storage.Attachments().GetByID(attachmentID)
+	if err != nil {
+		return "", false, fmt.Errorf("attachment not found")
+	}
+
+	// Check ownership
+	if attachment.UserID != userID {
+		return "", false, fmt.Errorf("access denied")
+	}
+
+	url, ok := s.store.PresignURL(attachment.StoragePath, attachment.Filename, 15*time.Minute)
+	return url, ok, nil
+}
+
+// checkStorageQuota rejects an addition that would cross the workspace's
+// storage quota; a near-full workspace only logs a warning
+func (s *Service) checkStorageQuota(workspaceID uint, size int64) error {
+	warning, err := s.storage.CheckWorkspaceStorageQuota(workspaceID, size)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		s.logger.Warn("Workspace storage nearly full",
+			zap.Uint("workspace_id", workspaceID),
+			zap.String("usage", warning))
+	}
+	return nil
+}
+
+// readBlob reads a blob fully into memory
+func (s *Service) readBlob(key string) ([]byte, error) {
+	reader, err := s.store.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// MigrateLocalFiles moves attachments stored on local disk into the current
+// blob store and rewrites their storage keys. Records already using relative
+// keys are skipped, so the migration is safe to re-run.
+func (s *Service) MigrateLocalFiles() (int, error) {
+	attachments, err := s.storage.Attachments().ListAll()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	migrated := 0
+	for _, attachment := range attachments {
+		if !filepath.IsAbs(attachment.StoragePath) {
+			continue
+		}
+
+		file, err := os.Open(attachment.StoragePath)
+		if err != nil {
+			s.logger.Warn("Skipping attachment with unreadable file",
+				zap.Uint("attachment_id", attachment.ID),
+				zap.Error(err))
+			continue
+		}
+
+		key := uuid.New().String() + attachment.FileExt
+		err = s.store.Save(key, file, attachment.FileSize, attachment.MimeType)
+		file.Close()
+		if err != nil {
+			return migrated, fmt.Errorf("failed to migrate attachment %d: %w", attachment.ID, err)
+		}
+
+		oldPath := attachment.StoragePath
+		attachment.StoragePath = key
+		if err := s.storage.Attachments().Update(attachment); err != nil {
+			return migrated, fmt.Errorf("failed to update attachment %d: %w", attachment.ID, err)
+		}
+		os.Remove(oldPath)
+
+		migrated++
+		s.logger.Info("Migrated attachment",
+			zap.Uint("attachment_id", attachment.ID),
+			zap.String("key", key))
+	}
+
+	return migrated, nil
+}
+
 // Delete deletes an attachment
 func (s *Service) Delete(attachmentID uint, userID uint) error {
 	attachment, err := s.storage.Attachments().GetByID(attachmentID)
@@ -172,11 +293,13 @@ func (s *Service) Delete(attachmentID uint, userID uint) error {
 		return fmt.Errorf("access denied")
 	}
 
-	// Delete file from disk
-	if err := os.Remove(attachment.StoragePath); err != nil {
-		s.logger.Warn("Failed to delete file from disk", zap.Error(err))
+	// Delete file from the blob store
+	if err := s.store.Delete(attachment.StoragePath); err != nil {
+		s.logger.Warn("Failed to delete file from storage", zap.Error(err))
 	}
 
+	s.dropAttachmentIndex(attachment)
+
 	// Delete database record
 	return s.storage.Attachments().Delete(attachmentID)
 }
@@ -193,7 +316,23 @@ func (s *Service) AttachToTask(attachmentID, taskID, userID uint) error {
 		return fmt.Errorf("access denied")
 	}
 
+	// The link pulls the attachment into the task's workspace, so its quota
+	// applies here too
+	task, err := s.storage.Tasks().GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("task not found")
+	}
+	if err := s.checkStorageQuota(task.WorkspaceID, attachment.FileSize); err != nil {
+		return err
+	}
+
 	// Update attachment
 	attachment.TaskID = &taskID
-	return s.storage.Attachments().Update(attachment)
+	if err := s.storage.Attachments().Update(attachment); err != nil {
+		return err
+	}
+
+	// The attachment's workspace is only known once a task links it
+	go s.indexAttachment(attachment)
+	return nil
 }
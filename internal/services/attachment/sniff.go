@@ -0,0 +1,55 @@
+package attachment
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// sniffLimit is how many leading bytes the sniffer looks at, matching
+// http.DetectContentType
+const sniffLimit = 512
+
+// sniffMimeType detects the content type from the first bytes of an upload,
+// stripped of media type parameters
+func sniffMimeType(head []byte) string {
+	detected := http.DetectContentType(head)
+	if media, _, err := mime.ParseMediaType(detected); err == nil {
+		return media
+	}
+	return detected
+}
+
+// mimeMatches reports whether the detected content type is plausible for the
+// declared one, so a renamed executable cannot pass as text/plain. The
+// sniffer cannot tell Office formats apart, so those only need to look like
+// the right container.
+func mimeMatches(declared, detected string) bool {
+	if detected == declared {
+		return true
+	}
+
+	// All text-family types must at least sniff as text; JSON, XML and SVG
+	// have no magic bytes and come back as text/plain or text/xml
+	if strings.HasPrefix(declared, "text/") ||
+		declared == "application/json" ||
+		declared == "application/xml" ||
+		declared == "image/svg+xml" {
+		return strings.HasPrefix(detected, "text/")
+	}
+
+	switch declared {
+	case "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation":
+		// Office Open XML documents are ZIP containers
+		return detected == "application/zip"
+	case "application/msword",
+		"application/vnd.ms-excel",
+		"application/vnd.ms-powerpoint":
+		// Legacy OLE containers are not in the sniffer's table
+		return detected == "application/octet-stream"
+	}
+
+	return false
+}
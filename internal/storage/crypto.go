@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/xcode-ai/xgent-go/internal/secrets"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+// encPrefix marks a stored value as encrypted so plaintext rows written
+// before encryption was enabled keep working
+const encPrefix = "enc:"
+
+// setupFieldEncryption installs the AES-GCM encrypt/decrypt hooks used by the
+// model layer for sensitive fields. Values already encrypted, empty values and
+// secret references are passed through unchanged, so the hooks are idempotent
+// across repeated saves.
+func setupFieldEncryption(key string, log *zap.Logger) error {
+	cipher, err := secrets.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	models.EncryptSensitive = func(value string) string {
+		if value == "" || strings.HasPrefix(value, encPrefix) || strings.HasPrefix(value, secrets.RefPrefix) {
+			return value
+		}
+		encrypted, err := cipher.Encrypt(value)
+		if err != nil {
+			log.Error("Failed to encrypt sensitive field", zap.Error(err))
+			return value
+		}
+		return encPrefix + encrypted
+	}
+
+	models.DecryptSensitive = func(value string) string {
+		if !strings.HasPrefix(value, encPrefix) {
+			return value
+		}
+		decrypted, err := cipher.Decrypt(strings.TrimPrefix(value, encPrefix))
+		if err != nil {
+			log.Error("Failed to decrypt sensitive field", zap.Error(err))
+			return value
+		}
+		return decrypted
+	}
+
+	return nil
+}
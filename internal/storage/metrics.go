@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// requestIDKey carries the originating API request ID through a query's
+// context so slow-query logs can be correlated with access logs
+type requestIDKey struct{}
+
+// WithRequestID attaches a request ID to a context for query logging
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestIDFrom extracts a request ID attached with WithRequestID
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// durationBuckets are the histogram upper bounds in seconds
+var durationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+// DBMetrics aggregates query counts and durations per operation. It is
+// exposed in Prometheus text format on /metrics.
+type DBMetrics struct {
+	mu        sync.Mutex
+	counts    map[string]uint64   // operation -> query count
+	errors    map[string]uint64   // operation -> failed query count
+	seconds   map[string]float64  // operation -> total duration
+	histogram map[string][]uint64 // operation -> cumulative bucket counts
+	slow      uint64              // queries over the slow threshold
+}
+
+// queryMetrics is the process-wide metrics instance
+var queryMetrics = &DBMetrics{
+	counts:    map[string]uint64{},
+	errors:    map[string]uint64{},
+	seconds:   map[string]float64{},
+	histogram: map[string][]uint64{},
+}
+
+// QueryMetrics returns the process-wide database metrics
+func QueryMetrics() *DBMetrics {
+	return queryMetrics
+}
+
+// observe records one finished query
+func (m *DBMetrics) observe(operation string, elapsed time.Duration, failed, slow bool) {
+	seconds := elapsed.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.counts[operation]++
+	m.seconds[operation] += seconds
+	if failed {
+		m.errors[operation]++
+	}
+	if slow {
+		m.slow++
+	}
+
+	buckets := m.histogram[operation]
+	if buckets == nil {
+		buckets = make([]uint64, len(durationBuckets))
+		m.histogram[operation] = buckets
+	}
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			buckets[i]++
+		}
+	}
+}
+
+// Render writes the metrics in Prometheus text exposition format
+func (m *DBMetrics) Render(w *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	operations := make([]string, 0, len(m.counts))
+	for operation := range m.counts {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	w.WriteString("# HELP xgent_db_queries_total Database queries by operation.\n")
+	w.WriteString("# TYPE xgent_db_queries_total counter\n")
+	for _, op := range operations {
+		fmt.Fprintf(w, "xgent_db_queries_total{operation=%q} %d\n", op, m.counts[op])
+	}
+
+	w.WriteString("# HELP xgent_db_query_errors_total Failed database queries by operation.\n")
+	w.WriteString("# TYPE xgent_db_query_errors_total counter\n")
+	for _, op := range operations {
+		fmt.Fprintf(w, "xgent_db_query_errors_total{operation=%q} %d\n", op, m.errors[op])
+	}
+
+	w.WriteString("# HELP xgent_db_query_duration_seconds Database query durations by operation.\n")
+	w.WriteString("# TYPE xgent_db_query_duration_seconds histogram\n")
+	for _, op := range operations {
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(w, "xgent_db_query_duration_seconds_bucket{operation=%q,le=%q} %d\n",
+				op, fmt.Sprintf("%g", bound), m.histogram[op][i])
+		}
+		fmt.Fprintf(w, "xgent_db_query_duration_seconds_bucket{operation=%q,le=\"+Inf\"} %d\n", op, m.counts[op])
+		fmt.Fprintf(w, "xgent_db_query_duration_seconds_sum{operation=%q} %f\n", op, m.seconds[op])
+		fmt.Fprintf(w, "xgent_db_query_duration_seconds_count{operation=%q} %d\n", op, m.counts[op])
+	}
+
+	w.WriteString("# HELP xgent_db_slow_queries_total Queries over the slow query threshold.\n")
+	w.WriteString("# TYPE xgent_db_slow_queries_total counter\n")
+	fmt.Fprintf(w, "xgent_db_slow_queries_total %d\n", m.slow)
+}
+
+// queryLogger is the gorm logger: it feeds every query into the metrics and
+// logs queries over the slow threshold, including the originating request ID
+// when the context carries one. Non-query log output stays silent, matching
+// the previous configuration.
+type queryLogger struct {
+	log       *zap.Logger
+	threshold time.Duration
+}
+
+func newQueryLogger(log *zap.Logger, threshold time.Duration) *queryLogger {
+	return &queryLogger{log: log, threshold: threshold}
+}
+
+func (l *queryLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface { return l }
+
+func (l *queryLogger) Info(ctx context.Context, msg string, args ...interface{})  {}
+func (l *queryLogger) Warn(ctx context.Context, msg string, args ...interface{})  {}
+func (l *queryLogger) Error(ctx context.Context, msg string, args ...interface{}) {}
+
+// Trace records a finished query
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.threshold > 0 && elapsed >= l.threshold
+
+	sql, rows := fc()
+	queryMetrics.observe(operationOf(sql), elapsed, err != nil, slow)
+
+	if !slow {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Duration("elapsed", elapsed),
+		zap.Int64("rows", rows),
+		zap.String("sql", sql),
+	}
+	if requestID := requestIDFrom(ctx); requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	l.log.Warn("Slow query", fields...)
+}
+
+// operationOf classifies a SQL statement by its leading keyword
+func operationOf(sql string) string {
+	trimmed := strings.TrimSpace(sql)
+	if idx := strings.IndexByte(trimmed, ' '); idx > 0 {
+		trimmed = trimmed[:idx]
+	}
+	switch strings.ToUpper(trimmed) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+		return strings.ToLower(trimmed)
+	default:
+		return "other"
+	}
+}
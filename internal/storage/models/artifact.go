@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Artifact is an output file produced by a task (patch, report, generated
+// code). The file itself lives in the blob store under StoragePath; the row
+// records where it came from inside the execution environment.
+type Artifact struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	TaskID      uint   `gorm:"not null;index" json:"task_id"`
+	Name        string `gorm:"not null" json:"name"` // file name reported by the agent
+	Path        string `json:"path,omitempty"`       // original path in the execution environment
+	FileSize    int64  `gorm:"not null" json:"file_size"`
+	MimeType    string `json:"mime_type,omitempty"`
+	StoragePath string `gorm:"not null" json:"storage_path"` // blob store key
+}
+
+// MaxArtifactSize caps individual artifact files (50MB); agents producing
+// larger outputs should upload them elsewhere and report a link instead
+const MaxArtifactSize = 50 * 1024 * 1024
@@ -23,15 +23,18 @@ type Attachment struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
-	UserID      uint             `gorm:"not null;index" json:"user_id"`
-	SubtaskID   *uint            `gorm:"index" json:"subtask_id,omitempty"`
-	TaskID      *uint            `gorm:"index" json:"task_id,omitempty"`
-	Filename    string           `gorm:"not null" json:"filename"`
-	FileSize    int64            `gorm:"not null" json:"file_size"`
-	MimeType    string           `gorm:"not null" json:"mime_type"`
-	FileExt     string           `json:"file_extension"`
-	Status      AttachmentStatus `gorm:"not null;default:'pending'" json:"status"`
-	StoragePath string           `gorm:"not null" json:"storage_path"`
+	UserID    uint   `gorm:"not null;index" json:"user_id"`
+	SubtaskID *uint  `gorm:"index" json:"subtask_id,omitempty"`
+	TaskID    *uint  `gorm:"index" json:"task_id,omitempty"`
+	Filename  string `gorm:"not null" json:"filename"`
+	FileSize  int64  `gorm:"not null" json:"file_size"`
+	MimeType  string `gorm:"not null" json:"mime_type"`
+	// DetectedMimeType is the content type sniffed from the file's leading
+	// bytes at upload; MimeType is what the client declared
+	DetectedMimeType string           `json:"detected_mime_type,omitempty"`
+	FileExt          string           `json:"file_extension"`
+	Status           AttachmentStatus `gorm:"not null;default:'pending'" json:"status"`
+	StoragePath      string           `gorm:"not null" json:"storage_path"`
 
 	// Extracted text content
 	TextContent  string `gorm:"type:longtext" json:"text_content,omitempty"`
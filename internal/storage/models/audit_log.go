@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit actions recorded by the API handlers
+const (
+	AuditActionResourceCreate = "resource.create"
+	AuditActionResourceUpdate = "resource.update"
+	AuditActionResourceDelete = "resource.delete"
+	AuditActionTaskCreate     = "task.create"
+	AuditActionTaskCancel     = "task.cancel"
+)
+
+// AuditLog is one append-only record of a security-relevant action: who did
+// what to which object. Rows are never updated or soft-deleted.
+type AuditLog struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID      uint   `gorm:"not null;index" json:"user_id"`
+	WorkspaceID uint   `gorm:"index" json:"workspace_id,omitempty"`
+	Action      string `gorm:"not null;index" json:"action"`
+	ObjectType  string `gorm:"index" json:"object_type"`
+	ObjectID    uint   `json:"object_id,omitempty"`
+	ObjectName  string `json:"object_name,omitempty"`
+
+	// Summary is a short human-readable description of what changed
+	Summary string `gorm:"type:text" json:"summary,omitempty"`
+	// RequestID links the entry to the request logs
+	RequestID string `json:"request_id,omitempty"`
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// EncryptSensitive and DecryptSensitive are installed by the storage layer
+// when an encryption key is configured. They transparently encrypt sensitive
+// fields (user Git tokens, Mind API keys) before rows are written and decrypt
+// them after rows are read. Without a key both default to the identity
+// function and values are stored in plaintext.
+var (
+	EncryptSensitive = func(value string) string { return value }
+	DecryptSensitive = func(value string) string { return value }
+)
+
+// specAPIKeyPattern matches the api_key line inside a Mind spec so the value
+// can be rewritten in place without re-marshalling the user's YAML
+var specAPIKeyPattern = regexp.MustCompile(`(?m)^(\s*api_key:\s*)(.+)$`)
+
+// BeforeSave encrypts the Git token before it is written
+func (u *User) BeforeSave(tx *gorm.DB) error {
+	u.GitToken = EncryptSensitive(u.GitToken)
+	return nil
+}
+
+// AfterFind decrypts the Git token after it is read
+func (u *User) AfterFind(tx *gorm.DB) error {
+	u.GitToken = DecryptSensitive(u.GitToken)
+	return nil
+}
+
+// BeforeSave encrypts sensitive values inside specs before they are
+// written: the api_key of Mind specs and every value in a Secret's data
+// block. The spec is deliberately NOT decrypted on read: API responses and
+// logs then only ever carry the ciphertext, and the executor decrypts the
+// values at the point of use.
+func (r *Resource) BeforeSave(tx *gorm.DB) error {
+	switch r.Type {
+	case ResourceTypeMind:
+		r.Spec = encryptSpecAPIKey(r.Spec)
+		r.PreviousSpec = encryptSpecAPIKey(r.PreviousSpec)
+	case ResourceTypeSecret:
+		r.Spec = encryptSecretData(r.Spec)
+		r.PreviousSpec = encryptSecretData(r.PreviousSpec)
+	}
+	return nil
+}
+
+// encryptSecretData rewrites every value under a Secret spec's data block
+// with its encrypted form, leaving the rest of the document untouched.
+// Secret data is a flat string map, so only the block's direct children are
+// touched.
+func encryptSecretData(spec string) string {
+	lines := strings.Split(spec, "\n")
+	inData := false
+	dataIndent := 0
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if trimmed == "data:" {
+			inData = true
+			dataIndent = indent
+			continue
+		}
+		if !inData || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if indent <= dataIndent {
+			inData = false
+			continue
+		}
+
+		colon := strings.Index(line, ":")
+		if colon < 0 {
+			continue
+		}
+		value := strings.Trim(strings.TrimSpace(line[colon+1:]), `"'`)
+		if value == "" {
+			continue
+		}
+		lines[i] = line[:colon+1] + " " + EncryptSensitive(value)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// encryptSpecAPIKey rewrites the api_key value in a YAML spec with its
+// encrypted form, leaving the rest of the document untouched
+func encryptSpecAPIKey(spec string) string {
+	return specAPIKeyPattern.ReplaceAllStringFunc(spec, func(line string) string {
+		parts := specAPIKeyPattern.FindStringSubmatch(line)
+		value := strings.Trim(strings.TrimSpace(parts[2]), `"'`)
+		if value == "" {
+			return line
+		}
+		return parts[1] + EncryptSensitive(value)
+	})
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"gorm.io/gorm"
@@ -17,6 +18,9 @@ type User struct {
 	Email    string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
 	Password string `gorm:"type:varchar(255);not null" json:"-"` // Never expose password in JSON
 
+	// Role is the platform-wide role; admins see across users
+	Role string `gorm:"type:varchar(32);default:'user'" json:"role"`
+
 	GitID     string `json:"git_id,omitempty"`
 	GitLogin  string `json:"git_login,omitempty"`
 	GitEmail  string `json:"git_email,omitempty"`
@@ -36,11 +40,130 @@ type Workspace struct {
 	Name        string `gorm:"not null" json:"name"`
 	Description string `json:"description"`
 	UserID      uint   `gorm:"not null;index" json:"user_id"`
+	Proxy       string `json:"proxy,omitempty"` // HTTP proxy for task execution in this workspace
+
+	// RedactPII scrubs emails, keys and tokens from task results and logs
+	// before they are persisted, for compliance-sensitive deployments
+	RedactPII bool `gorm:"default:false" json:"redact_pii"`
+
+	// IsDefault marks the workspace used when a request names none, via
+	// the X-Xgent-Workspace header or workspace query parameter being
+	// absent. At most one of a user's workspaces carries the flag.
+	IsDefault bool `gorm:"default:false" json:"is_default"`
+
+	// ResourceQuotas caps resources per type as a JSON object of type name
+	// to maximum count (e.g. {"Robot": 5}); empty means unlimited. Set by
+	// administrators.
+	ResourceQuotas string `gorm:"type:text" json:"-"`
+
+	// LogRetentionDays is how long task logs and event rows are kept
+	// before the janitor archives and deletes them; 0 keeps them forever.
+	// Set by administrators.
+	LogRetentionDays int `gorm:"default:0" json:"log_retention_days,omitempty"`
+
+	// TokenBudget caps the workspace's LLM tokens over a rolling 30 days;
+	// exhausted budgets reject new task submissions. 0 means unlimited.
+	// Set by administrators.
+	TokenBudget int `gorm:"default:0" json:"token_budget,omitempty"`
+
+	// StorageQuotaBytes and StorageQuotaFiles cap the combined size and
+	// count of attachments and artifacts on the workspace's tasks; 0 means
+	// unlimited. Set by administrators.
+	StorageQuotaBytes int64 `gorm:"default:0" json:"storage_quota_bytes,omitempty"`
+	StorageQuotaFiles int   `gorm:"default:0" json:"storage_quota_files,omitempty"`
+
+	// Settings holds the workspace's execution defaults as a JSON blob;
+	// read it through SettingsValues
+	Settings string `gorm:"type:text" json:"-"`
 
 	Resources []Resource `gorm:"foreignKey:WorkspaceID" json:"resources,omitempty"`
 	Tasks     []Task     `gorm:"foreignKey:WorkspaceID" json:"tasks,omitempty"`
 }
 
+// QuotaFor returns the workspace's cap for a resource type; 0 means unlimited
+func (w *Workspace) QuotaFor(resourceType ResourceType) int {
+	if w.ResourceQuotas == "" {
+		return 0
+	}
+	var quotas map[string]int
+	if err := json.Unmarshal([]byte(w.ResourceQuotas), &quotas); err != nil {
+		return 0
+	}
+	return quotas[string(resourceType)]
+}
+
+// WorkspaceSettings are workspace-level execution defaults, applied when a
+// task or robot names no value of its own
+type WorkspaceSettings struct {
+	// DefaultMind names the Mind resource used by robots whose spec
+	// declares none
+	DefaultMind string `json:"default_mind,omitempty"`
+
+	// DefaultEnvironment names the execution Environment used when
+	// neither the task nor the robot declares one
+	DefaultEnvironment string `json:"default_environment,omitempty"`
+
+	// GitTokenSecret names the workspace secret holding git credentials,
+	// used by the PR flow for users without a connected git account
+	GitTokenSecret string `json:"git_token_secret,omitempty"`
+
+	// Proxy is the HTTP proxy for task execution; it takes precedence
+	// over the workspace's legacy proxy field
+	Proxy string `json:"proxy,omitempty"`
+
+	// Timezone is the IANA zone wall-clock schedule times are
+	// interpreted in; empty means UTC
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// SettingsValues parses the workspace's settings blob; an empty or malformed
+// blob yields zero-valued defaults
+func (w *Workspace) SettingsValues() WorkspaceSettings {
+	var settings WorkspaceSettings
+	if w.Settings != "" {
+		json.Unmarshal([]byte(w.Settings), &settings)
+	}
+	return settings
+}
+
+// Platform-wide user roles
+const (
+	UserRoleAdmin = "admin"
+	UserRoleUser  = "user"
+)
+
+// WorkspaceRole represents a member's role on a shared workspace
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleEditor WorkspaceRole = "editor"
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+)
+
+// AtLeast reports whether the role grants at least the given role's access
+func (r WorkspaceRole) AtLeast(required WorkspaceRole) bool {
+	rank := map[WorkspaceRole]int{
+		WorkspaceRoleViewer: 1,
+		WorkspaceRoleEditor: 2,
+		WorkspaceRoleOwner:  3,
+	}
+	return rank[r] >= rank[required]
+}
+
+// WorkspaceMember shares a workspace with another user under a role
+type WorkspaceMember struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	WorkspaceID uint          `gorm:"not null;index:idx_workspace_member,unique" json:"workspace_id"`
+	UserID      uint          `gorm:"not null;index:idx_workspace_member,unique" json:"user_id"`
+	Role        WorkspaceRole `gorm:"not null;default:'viewer'" json:"role"`
+	InvitedBy   uint          `json:"invited_by,omitempty"`
+}
+
 // ResourceType represents the type of CRD resource
 type ResourceType string
 
@@ -51,6 +174,11 @@ const (
 	ResourceTypeRobot         ResourceType = "Robot"
 	ResourceTypeTeam          ResourceType = "Team"
 	ResourceTypeCollaboration ResourceType = "Collaboration"
+	ResourceTypeWorkflow      ResourceType = "Workflow"
+	ResourceTypeEnvironment   ResourceType = "Environment"
+	ResourceTypeKnowledge     ResourceType = "Knowledge"
+	ResourceTypeSecret        ResourceType = "Secret"
+	ResourceTypeTrigger       ResourceType = "Trigger"
 )
 
 // Resource represents a CRD resource
@@ -70,8 +198,24 @@ type Resource struct {
 	// Metadata
 	Labels      string `gorm:"type:text" json:"labels,omitempty"`      // JSON
 	Annotations string `gorm:"type:text" json:"annotations,omitempty"` // JSON
+
+	// Canary rollout state: while a canary is active the previous spec
+	// revision keeps serving CanaryPercent of new tasks
+	PreviousSpec    string     `gorm:"type:text" json:"-"`
+	CanaryPercent   int        `gorm:"default:0" json:"canary_percent,omitempty"`
+	CanaryStartedAt *time.Time `json:"canary_started_at,omitempty"`
+	CanaryExpiresAt *time.Time `json:"canary_expires_at,omitempty"`
 }
 
+// TaskPriority orders tasks in the execution queue
+type TaskPriority string
+
+const (
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityNormal TaskPriority = "normal"
+	TaskPriorityLow    TaskPriority = "low"
+)
+
 // TaskStatus represents task execution status
 type TaskStatus string
 
@@ -81,6 +225,22 @@ const (
 	TaskStatusCompleted TaskStatus = "completed"
 	TaskStatusFailed    TaskStatus = "failed"
 	TaskStatusCancelled TaskStatus = "cancelled"
+
+	// TaskStatusScheduled holds a task with a future run_at until the
+	// scheduler enqueues it
+	TaskStatusScheduled TaskStatus = "scheduled"
+
+	// TaskStatusBlocked holds a task whose depends_on tasks have not all
+	// completed yet
+	TaskStatusBlocked TaskStatus = "blocked"
+
+	// TaskStatusBudgetExceeded marks a task aborted for crossing its token
+	// budget
+	TaskStatusBudgetExceeded TaskStatus = "budget_exceeded"
+
+	// TaskStatusDeadLetter parks a task whose retry budget is spent; an
+	// operator can inspect and requeue it via the admin API
+	TaskStatusDeadLetter TaskStatus = "dead_letter"
 )
 
 // Task represents an execution task
@@ -97,27 +257,85 @@ type Task struct {
 	Title       string `gorm:"not null" json:"title"`
 	Description string `json:"description"`
 	Prompt      string `gorm:"type:text;not null" json:"prompt"`
+	Parameters  string `gorm:"type:text" json:"parameters,omitempty"` // JSON map of template variables
 
 	// Execution config
-	ResourceType string `json:"resource_type"` // bot or team
-	ResourceName string `json:"resource_name"`
-	Mode         string `json:"mode,omitempty"` // For team: coordinate, collaborate, route
+	ResourceType string `gorm:"index" json:"resource_type"` // bot or team
+	ResourceName string `gorm:"index" json:"resource_name"`
+	Mode         string `json:"mode,omitempty"`        // For team: coordinate, collaborate, route
+	Environment  string `json:"environment,omitempty"` // Environment resource override; empty uses the robot's
 
 	// Git integration
 	GitURL     string `json:"git_url,omitempty"`
 	BranchName string `json:"branch_name,omitempty"`
 
+	// AutoPR opts into the git PR flow: the orchestrator clones the repo
+	// into the task workspace before execution, commits agent changes on
+	// an xgent/task-<id> branch, pushes and opens a pull request whose URL
+	// lands in PRURL. WorkDir is the prepared clone the executor runs in.
+	AutoPR  bool   `gorm:"default:false" json:"auto_pr,omitempty"`
+	WorkDir string `json:"-"`
+	PRURL   string `json:"pr_url,omitempty"`
+
+	// Replay support: SpecSnapshot records the resource specs resolved
+	// during execution (JSON map of type/name to spec) so the run can be
+	// replayed against the same revisions; ReplayOfID links a replay to
+	// the original task
+	SpecSnapshot string `gorm:"type:longtext" json:"-"`
+	ReplayOfID   *uint  `gorm:"index" json:"replay_of_id,omitempty"`
+
+	// Execution cache: when UseCache is set the executor hashes the task's
+	// inputs into CacheKey and reuses the result of an earlier identical run
+	// instead of executing again; CachedFromID records where the result came
+	// from
+	UseCache     bool   `gorm:"default:false" json:"use_cache,omitempty"`
+	CacheKey     string `gorm:"type:varchar(64);index" json:"-"`
+	CachedFromID *uint  `json:"cached_from_id,omitempty"`
+
 	// Results
-	Result    string `gorm:"type:longtext" json:"result,omitempty"`
-	Error     string `gorm:"type:text" json:"error,omitempty"`
-	Progress  int    `gorm:"default:0" json:"progress"`
-	EventLogs string `gorm:"type:longtext" json:"event_logs,omitempty"`
+	Result   string `gorm:"type:longtext" json:"result,omitempty"`
+	Error    string `gorm:"type:text" json:"error,omitempty"`
+	Progress int    `gorm:"default:0" json:"progress"`
 
 	// Execution metadata
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	Duration    int64      `json:"duration,omitempty"` // milliseconds
 
+	// TokensUsed is the LLM token count charged to this task (estimated
+	// when the provider does not report usage), used for budget limits
+	TokensUsed int `gorm:"default:0" json:"tokens_used,omitempty"`
+
+	// MaxTokens is this task's token budget; execution aborts with
+	// budget_exceeded once crossed. 0 means unlimited.
+	MaxTokens int `gorm:"default:0" json:"max_tokens,omitempty"`
+
+	// RequestID is the API request that created the task, correlating it
+	// with server logs and traces
+	RequestID string `gorm:"type:varchar(64);index" json:"request_id,omitempty"`
+
+	// Priority orders the task in the queue; empty means normal
+	Priority TaskPriority `gorm:"type:varchar(16);default:'normal'" json:"priority,omitempty"`
+
+	// RunAt defers execution until the given time; the task waits in
+	// scheduled state and the scheduler enqueues it when due
+	RunAt *time.Time `gorm:"index" json:"run_at,omitempty"`
+
+	// DependsOn holds the parent task IDs (JSON array) this task waits
+	// for; the task stays blocked until every parent completes
+	DependsOn string `gorm:"type:text" json:"depends_on,omitempty"`
+
+	// Attempts counts execution attempts; once the retry policy's budget
+	// is spent the task is parked in dead_letter
+	Attempts int `gorm:"default:0" json:"attempts,omitempty"`
+
+	// Worker lease: which worker process holds the task and until when.
+	// The reaper re-queues running tasks whose lease expired, so work held
+	// by a dead worker is not lost. WorkerName stays set after completion
+	// as a record of who ran the task.
+	WorkerName     string     `gorm:"index" json:"worker_name,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+
 	// Relations
 	SubTasks []SubTask `gorm:"foreignKey:TaskID" json:"sub_tasks,omitempty"`
 	Logs     []TaskLog `gorm:"foreignKey:TaskID" json:"logs,omitempty"`
@@ -137,6 +355,11 @@ type SubTask struct {
 	Progress int        `gorm:"default:0" json:"progress"`
 	Result   string     `gorm:"type:text" json:"result,omitempty"`
 	Error    string     `gorm:"type:text" json:"error,omitempty"`
+
+	// Plan structure: position of the step in the agent's plan and the
+	// titles of steps it depends on (JSON array)
+	StepOrder int    `gorm:"default:0" json:"step_order"`
+	DependsOn string `gorm:"type:text" json:"depends_on,omitempty"`
 }
 
 // TaskLog represents execution logs
@@ -151,6 +374,53 @@ type TaskLog struct {
 	Metadata  string `gorm:"type:text" json:"metadata,omitempty"` // JSON
 }
 
+// TaskEvent is a single execution event persisted as a row. Events were
+// previously concatenated into a longtext column on the task; rows make
+// them pageable, replayable after the in-memory buffer expires, and
+// subject to retention. Seq is the per-task sequence number assigned by
+// the broadcaster.
+type TaskEvent struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TaskID  uint   `gorm:"not null;uniqueIndex:idx_task_events_seq,priority:1" json:"task_id"`
+	Seq     uint64 `gorm:"not null;uniqueIndex:idx_task_events_seq,priority:2" json:"seq"`
+	Type    string `gorm:"not null" json:"type"`
+	Payload string `gorm:"type:text" json:"payload,omitempty"` // raw event JSON
+}
+
+// LogArchive records one archive produced by the log retention janitor: a
+// compressed JSONL file of task logs and events that were removed from the
+// database for a workspace
+type LogArchive struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	WorkspaceID uint      `gorm:"not null;index" json:"workspace_id"`
+	Key         string    `gorm:"not null" json:"key"` // blob store key
+	Cutoff      time.Time `json:"cutoff"`
+	Logs        int       `json:"logs"`
+	Events      int       `json:"events"`
+	Size        int64     `json:"size"`
+}
+
+// Worker is one registered worker process. A row is upserted on startup
+// and refreshed by heartbeats; a heartbeat older than the stale threshold
+// marks the worker dead.
+type Worker struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name          string    `gorm:"uniqueIndex;not null" json:"name"` // hostname-pid
+	Hostname      string    `json:"hostname"`
+	PID           int       `json:"pid"`
+	Concurrency   int       `json:"concurrency"`
+	QueueBackend  string    `json:"queue_backend"`
+	StartedAt     time.Time `json:"started_at"`
+	LastHeartbeat time.Time `gorm:"index" json:"last_heartbeat"`
+}
+
 // Session represents an agent session
 type Session struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
@@ -162,6 +432,12 @@ type Session struct {
 	UserID    uint   `gorm:"not null;index" json:"user_id"`
 	AgentID   string `json:"agent_id,omitempty"`
 
+	// Summary is a running compaction of older messages; SummarizedUpTo is
+	// the ID of the newest message it covers. Chat and task execution replay
+	// the summary instead of the turns it absorbed.
+	Summary        string `gorm:"type:longtext" json:"summary,omitempty"`
+	SummarizedUpTo uint   `json:"summarized_up_to,omitempty"`
+
 	Messages []Message `gorm:"foreignKey:SessionID;references:SessionID" json:"messages,omitempty"`
 }
 
@@ -181,6 +457,29 @@ type Message struct {
 	Metadata  string `gorm:"type:text" json:"metadata,omitempty"` // JSON
 }
 
+// LoginSession tracks an issued token (device, IP, last seen) so a leaked
+// token can be revoked individually without rotating the JWT secret
+type LoginSession struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	TokenID    string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"` // JWT ID (jti)
+	UserAgent  string     `json:"user_agent,omitempty"`
+	IP         string     `gorm:"type:varchar(64)" json:"ip,omitempty"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	// RefreshHash is the SHA-256 of the session's current refresh token,
+	// rotated on every refresh; empty for sessions without one (e.g. minted
+	// integration tokens)
+	RefreshHash      string     `gorm:"type:varchar(64);index" json:"-"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+}
+
 // APIKey represents an API key for programmatic access
 type APIKey struct {
 	ID        uint           `gorm:"primarykey" json:"id"`
@@ -196,5 +495,55 @@ type APIKey struct {
 	Permissions string     `gorm:"type:text" json:"permissions,omitempty"` // JSON
 }
 
+// Secret represents a workspace-scoped secret value stored encrypted
+type Secret struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	WorkspaceID uint   `gorm:"not null;index:idx_secret_name,unique" json:"workspace_id"`
+	Name        string `gorm:"not null;index:idx_secret_name,unique" json:"name"`
+	Value       string `gorm:"type:text;not null" json:"-"` // Encrypted, never exposed in JSON
+	Description string `json:"description,omitempty"`
+}
+
+// Webhook delivers task lifecycle notifications for a workspace to an
+// external HTTP endpoint, signed with the webhook's secret
+type Webhook struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	WorkspaceID uint   `gorm:"not null;index" json:"workspace_id"`
+	URL         string `gorm:"not null" json:"url"`
+	Secret      string `gorm:"type:varchar(255)" json:"-"`        // HMAC signing key, never exposed
+	Events      string `gorm:"type:text" json:"events,omitempty"` // JSON array; empty matches all events
+	Active      bool   `gorm:"default:true" json:"active"`
+	CreatedBy   uint   `json:"created_by"`
+}
+
+// Matches reports whether the webhook's event filter includes the event.
+// An empty filter matches everything.
+func (w *Webhook) Matches(event string) bool {
+	if w.Events == "" {
+		return true
+	}
+	var events []string
+	if err := json.Unmarshal([]byte(w.Events), &events); err != nil {
+		return false
+	}
+	if len(events) == 0 {
+		return true
+	}
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
 // ProgressCallback is called to report task execution progress
 type ProgressCallback func(taskID uint, progress int, status TaskStatus, message string, metadata map[string]interface{})
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskTemplate is a reusable task definition: teams that submit the same
+// task with tiny variations capture the shape here once and instantiate it
+// with per-run variables. Fields mirror the task creation request; Parameters
+// holds default template variables that instantiation can override.
+type TaskTemplate struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	WorkspaceID uint `gorm:"not null;index" json:"workspace_id"`
+	UserID      uint `gorm:"not null;index" json:"user_id"`
+
+	// Name identifies the template in listings ("nightly-triage")
+	Name        string `gorm:"not null;index" json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Task defaults stamped onto instantiated tasks
+	Title        string `gorm:"not null" json:"title"`
+	Prompt       string `gorm:"type:text;not null" json:"prompt"`
+	Parameters   string `gorm:"type:text" json:"parameters,omitempty"` // JSON map of default template variables
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	Mode         string `json:"mode,omitempty"`
+	Environment  string `json:"environment,omitempty"`
+
+	// Git integration defaults
+	GitURL     string `json:"git_url,omitempty"`
+	BranchName string `json:"branch_name,omitempty"`
+	AutoPR     bool   `gorm:"default:false" json:"auto_pr,omitempty"`
+
+	Priority TaskPriority `gorm:"type:varchar(10);default:'normal'" json:"priority,omitempty"`
+}
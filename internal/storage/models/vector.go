@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VectorChunk is one embedded chunk of text used for similarity search.
+// The embedding is stored in pgvector's text literal format ("[0.1,0.2,...]")
+// so Postgres can cast it to a vector directly; other drivers parse it back
+// in Go for brute-force search.
+type VectorChunk struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	WorkspaceID uint   `gorm:"not null;index" json:"workspace_id"`
+	Source      string `gorm:"type:varchar(255);not null;index" json:"source"` // e.g. "knowledge/docs" or "attachment/42"
+	ChunkIndex  int    `gorm:"not null" json:"chunk_index"`
+	Content     string `gorm:"type:longtext" json:"content"`
+	Embedding   string `gorm:"type:longtext" json:"-"`
+	Dimensions  int    `gorm:"not null" json:"dimensions"`
+}
+
+// SetVector stores the embedding in the serialized format
+func (c *VectorChunk) SetVector(embedding []float32) {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	c.Embedding = b.String()
+	c.Dimensions = len(embedding)
+}
+
+// Vector parses the stored embedding; it returns nil when the value is
+// missing or malformed
+func (c *VectorChunk) Vector() []float32 {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(c.Embedding, "["), "]")
+	if trimmed == "" {
+		return nil
+	}
+	parts := strings.Split(trimmed, ",")
+	embedding := make([]float32, 0, len(parts))
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return nil
+		}
+		embedding = append(embedding, float32(v))
+	}
+	return embedding
+}
@@ -0,0 +1,61 @@
+package storage
+
+import "fmt"
+
+// StorageUsage is a workspace's combined attachment and artifact footprint
+type StorageUsage struct {
+	Bytes int64 `json:"bytes"`
+	Files int64 `json:"files"`
+}
+
+// WorkspaceStorageUsage sums the bytes and file counts of the attachments
+// and artifacts linked to the workspace's tasks
+func (s *Storage) WorkspaceStorageUsage(workspaceID uint) (*StorageUsage, error) {
+	attachmentBytes, attachmentFiles, err := s.Attachments().UsageForWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	artifactBytes, artifactFiles, err := s.Artifacts().UsageForWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageUsage{
+		Bytes: attachmentBytes + artifactBytes,
+		Files: attachmentFiles + artifactFiles,
+	}, nil
+}
+
+// CheckWorkspaceStorageQuota rejects adding one file of the given size when
+// the workspace's storage quota has no room for it. Once the addition would
+// push usage past 80% of either limit, a non-empty warning describes how
+// full the workspace is; callers surface it as a soft event.
+func (s *Storage) CheckWorkspaceStorageQuota(workspaceID uint, size int64) (warning string, err error) {
+	workspace, err := s.Workspaces().GetByID(workspaceID)
+	if err != nil || (workspace.StorageQuotaBytes <= 0 && workspace.StorageQuotaFiles <= 0) {
+		// Missing workspaces surface elsewhere; no quota means no check
+		return "", nil
+	}
+
+	usage, err := s.WorkspaceStorageUsage(workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute storage usage: %w", err)
+	}
+
+	if workspace.StorageQuotaFiles > 0 && usage.Files >= int64(workspace.StorageQuotaFiles) {
+		return "", fmt.Errorf("storage quota exceeded: workspace already holds %d of %d files",
+			usage.Files, workspace.StorageQuotaFiles)
+	}
+	if workspace.StorageQuotaBytes > 0 && usage.Bytes+size > workspace.StorageQuotaBytes {
+		return "", fmt.Errorf("storage quota exceeded: workspace uses %d of %d bytes",
+			usage.Bytes, workspace.StorageQuotaBytes)
+	}
+
+	if workspace.StorageQuotaFiles > 0 && (usage.Files+1)*10 >= int64(workspace.StorageQuotaFiles)*8 {
+		warning = fmt.Sprintf("workspace storage at %d of %d files",
+			usage.Files+1, workspace.StorageQuotaFiles)
+	} else if workspace.StorageQuotaBytes > 0 && (usage.Bytes+size)*10 >= workspace.StorageQuotaBytes*8 {
+		warning = fmt.Sprintf("workspace storage at %d of %d bytes",
+			usage.Bytes+size, workspace.StorageQuotaBytes)
+	}
+	return warning, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+// ArtifactRepository handles artifact data access
+type ArtifactRepository struct {
+	db *gorm.DB
+}
+
+// NewArtifactRepository creates a new artifact repository
+func NewArtifactRepository(db *gorm.DB) *ArtifactRepository {
+	return &ArtifactRepository{db: db}
+}
+
+// Create creates a new artifact
+func (r *ArtifactRepository) Create(artifact *models.Artifact) error {
+	return r.db.Create(artifact).Error
+}
+
+// GetByID retrieves an artifact by ID
+func (r *ArtifactRepository) GetByID(id uint) (*models.Artifact, error) {
+	var artifact models.Artifact
+	if err := r.db.First(&artifact, id).Error; err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+// ListByTask retrieves artifacts for a task
+func (r *ArtifactRepository) ListByTask(taskID uint) ([]*models.Artifact, error) {
+	var artifacts []*models.Artifact
+	err := r.db.Where("task_id = ?", taskID).
+		Order("created_at ASC").
+		Find(&artifacts).Error
+	return artifacts, err
+}
+
+// ListAll retrieves every artifact, oldest first
+func (r *ArtifactRepository) ListAll() ([]*models.Artifact, error) {
+	var artifacts []*models.Artifact
+	err := r.db.Order("id ASC").Find(&artifacts).Error
+	return artifacts, err
+}
+
+// Delete deletes an artifact
+func (r *ArtifactRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Artifact{}, id).Error
+}
+
+// UsageForWorkspace sums the size and count of artifacts registered on the
+// workspace's tasks
+func (r *ArtifactRepository) UsageForWorkspace(workspaceID uint) (int64, int64, error) {
+	var usage struct {
+		Bytes int64
+		Files int64
+	}
+	err := r.db.Model(&models.Artifact{}).
+		Joins("JOIN tasks ON tasks.id = artifacts.task_id").
+		Where("tasks.workspace_id = ?", workspaceID).
+		Select("COALESCE(SUM(artifacts.file_size), 0) AS bytes, COUNT(*) AS files").
+		Scan(&usage).Error
+	return usage.Bytes, usage.Files, err
+}
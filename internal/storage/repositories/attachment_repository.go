@@ -64,6 +64,12 @@ func (r *AttachmentRepository) ListBySubtask(subtaskID uint) ([]*models.Attachme
 }
 
 // Delete deletes an attachment
+func (r *AttachmentRepository) ListAll() ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := r.db.Order("id ASC").Find(&attachments).Error
+	return attachments, err
+}
+
 func (r *AttachmentRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Attachment{}, id).Error
 }
@@ -78,3 +84,18 @@ func (r *AttachmentRepository) UpdateStatus(id uint, status models.AttachmentSta
 	}
 	return r.db.Model(&models.Attachment{}).Where("id = ?", id).Updates(updates).Error
 }
+
+// UsageForWorkspace sums the size and count of attachments linked to the
+// workspace's tasks; unattached uploads are not counted
+func (r *AttachmentRepository) UsageForWorkspace(workspaceID uint) (int64, int64, error) {
+	var usage struct {
+		Bytes int64
+		Files int64
+	}
+	err := r.db.Model(&models.Attachment{}).
+		Joins("JOIN tasks ON tasks.id = attachments.task_id").
+		Where("tasks.workspace_id = ?", workspaceID).
+		Select("COALESCE(SUM(attachments.file_size), 0) AS bytes, COUNT(*) AS files").
+		Scan(&usage).Error
+	return usage.Bytes, usage.Files, err
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository handles audit log data access
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create appends an audit log entry
+func (r *AuditLogRepository) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// AuditLogFilter narrows an audit listing; zero values are ignored
+type AuditLogFilter struct {
+	UserID      uint
+	WorkspaceID uint
+	Action      string
+	ObjectType  string
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// List retrieves audit entries matching the filter, newest first
+func (r *AuditLogRepository) List(filter AuditLogFilter, limit, offset int) ([]*models.AuditLog, error) {
+	query := r.db.Model(&models.AuditLog{})
+
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ObjectType != "" {
+		query = query.Where("object_type = ?", filter.ObjectType)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at <= ?", filter.CreatedBefore)
+	}
+
+	var entries []*models.AuditLog
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+type LoginSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginSessionRepository(db *gorm.DB) *LoginSessionRepository {
+	return &LoginSessionRepository{db: db}
+}
+
+func (r *LoginSessionRepository) Create(session *models.LoginSession) error {
+	return r.db.Create(session).Error
+}
+
+// ListByUser returns a user's sessions that have not expired, newest activity
+// first
+func (r *LoginSessionRepository) ListByUser(userID uint) ([]*models.LoginSession, error) {
+	var sessions []*models.LoginSession
+	err := r.db.Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *LoginSessionRepository) GetByID(id uint) (*models.LoginSession, error) {
+	var session models.LoginSession
+	if err := r.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByTokenID retrieves the session backing a JWT by its jti claim
+func (r *LoginSessionRepository) GetByTokenID(tokenID string) (*models.LoginSession, error) {
+	var session models.LoginSession
+	if err := r.db.Where("token_id = ?", tokenID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByRefreshHash retrieves the session holding the given refresh token hash
+func (r *LoginSessionRepository) GetByRefreshHash(hash string) (*models.LoginSession, error) {
+	var session models.LoginSession
+	if err := r.db.Where("refresh_hash = ?", hash).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Update saves session changes (token rotation, refresh hash)
+func (r *LoginSessionRepository) Update(session *models.LoginSession) error {
+	return r.db.Save(session).Error
+}
+
+// Revoke marks a session as revoked so its token stops authenticating
+func (r *LoginSessionRepository) Revoke(id uint) error {
+	now := time.Now()
+	return r.db.Model(&models.LoginSession{}).
+		Where("id = ?", id).
+		Update("revoked_at", &now).Error
+}
+
+// ValidateToken reports whether a token's session is still valid and records
+// its activity. Tokens issued before session tracking (no session row) remain
+// valid.
+func (r *LoginSessionRepository) ValidateToken(tokenID, ip string) bool {
+	var session models.LoginSession
+	if err := r.db.Where("token_id = ?", tokenID).First(&session).Error; err != nil {
+		return true
+	}
+	if session.RevokedAt != nil {
+		return false
+	}
+
+	// Refresh last-seen at most once a minute to keep the hot path cheap
+	if time.Since(session.LastSeenAt) > time.Minute {
+		r.db.Model(&session).Updates(map[string]interface{}{
+			"last_seen_at": time.Now(),
+			"ip":           ip,
+		})
+	}
+	return true
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"gorm.io/gorm"
 )
@@ -60,6 +62,15 @@ func (r *ResourceRepository) List(workspaceID uint, resourceType models.Resource
 	return resources, err
 }
 
+// ListAllByType retrieves resources of a type across all workspaces
+func (r *ResourceRepository) ListAllByType(resourceType models.ResourceType) ([]*models.Resource, error) {
+	var resources []*models.Resource
+	err := r.db.Where("type = ?", resourceType).
+		Order("id ASC").
+		Find(&resources).Error
+	return resources, err
+}
+
 // Delete deletes a resource
 func (r *ResourceRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Resource{}, id).Error
@@ -73,3 +84,23 @@ func (r *ResourceRepository) Exists(workspaceID uint, name string, resourceType
 		Count(&count).Error
 	return count > 0, err
 }
+
+// CountByType counts a workspace's resources of one type
+func (r *ResourceRepository) CountByType(workspaceID uint, resourceType models.ResourceType) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Resource{}).
+		Where("workspace_id = ? AND type = ?", workspaceID, resourceType).
+		Count(&count).Error
+	return count, err
+}
+
+// ListChangedSince retrieves resources in a workspace modified (including
+// soft-deleted) after the given time, oldest change first
+func (r *ResourceRepository) ListChangedSince(workspaceID uint, since time.Time) ([]*models.Resource, error) {
+	var resources []*models.Resource
+	err := r.db.Unscoped().
+		Where("workspace_id = ? AND (updated_at > ? OR deleted_at > ?)", workspaceID, since, since).
+		Order("updated_at ASC").
+		Find(&resources).Error
+	return resources, err
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+// SecretRepository handles secret data access
+type SecretRepository struct {
+	db *gorm.DB
+}
+
+// NewSecretRepository creates a new secret repository
+func NewSecretRepository(db *gorm.DB) *SecretRepository {
+	return &SecretRepository{db: db}
+}
+
+// Create creates a new secret
+func (r *SecretRepository) Create(secret *models.Secret) error {
+	return r.db.Create(secret).Error
+}
+
+// Update updates a secret
+func (r *SecretRepository) Update(secret *models.Secret) error {
+	return r.db.Save(secret).Error
+}
+
+// GetByName retrieves a secret by name within a workspace
+func (r *SecretRepository) GetByName(workspaceID uint, name string) (*models.Secret, error) {
+	var secret models.Secret
+	if err := r.db.Where("workspace_id = ? AND name = ?", workspaceID, name).
+		First(&secret).Error; err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// List retrieves all secrets for a workspace
+func (r *SecretRepository) List(workspaceID uint) ([]*models.Secret, error) {
+	var secrets []*models.Secret
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("name").Find(&secrets).Error
+	return secrets, err
+}
+
+// Delete deletes a secret
+func (r *SecretRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Secret{}, id).Error
+}
@@ -35,6 +35,10 @@ func (r *SessionRepository) ListByUser(userID uint, limit, offset int) ([]*model
 	return sessions, err
 }
 
+func (r *SessionRepository) Update(session *models.Session) error {
+	return r.db.Save(session).Error
+}
+
 func (r *SessionRepository) Delete(sessionID string) error {
 	return r.db.Where("session_id = ?", sessionID).Delete(&models.Session{}).Error
 }
@@ -53,6 +57,36 @@ func (r *SessionRepository) GetMessages(sessionID string, limit int) ([]*models.
 	return messages, err
 }
 
+// GetMessagesAfter retrieves a session's messages newer than a message ID
+// in chronological order; the compactor and replay paths use it to skip
+// turns already absorbed into the running summary
+func (r *SessionRepository) GetMessagesAfter(sessionID string, afterID uint, limit int) ([]*models.Message, error) {
+	var messages []*models.Message
+	query := r.db.Where("session_id = ? AND id > ?", sessionID, afterID).Order("created_at ASC, id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&messages).Error
+	return messages, err
+}
+
+// GetRecentMessages retrieves the latest messages of a session in
+// chronological order
+func (r *SessionRepository) GetRecentMessages(sessionID string, limit int) ([]*models.Message, error) {
+	var messages []*models.Message
+	query := r.db.Where("session_id = ?", sessionID).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
 func (r *SessionRepository) ClearMessages(sessionID string) error {
 	return r.db.Where("session_id = ?", sessionID).Delete(&models.Message{}).Error
 }
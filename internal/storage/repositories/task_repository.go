@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"gorm.io/gorm"
 )
@@ -45,6 +47,59 @@ func (r *TaskRepository) List(workspaceID uint, limit, offset int) ([]*models.Ta
 	return tasks, err
 }
 
+// TaskFilter narrows a task listing; zero values are ignored
+type TaskFilter struct {
+	UserID       uint
+	WorkspaceID  uint
+	Status       models.TaskStatus
+	ResourceType string
+	ResourceName string
+
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Search matches free text against title and prompt
+	Search string
+}
+
+// Search retrieves tasks matching the filter, newest first
+func (r *TaskRepository) Search(filter TaskFilter, limit, offset int) ([]*models.Task, error) {
+	query := r.db.Model(&models.Task{})
+
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceName != "" {
+		query = query.Where("resource_name = ?", filter.ResourceName)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at <= ?", filter.CreatedBefore)
+	}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		query = query.Where("title LIKE ? OR prompt LIKE ?", pattern, pattern)
+	}
+
+	var tasks []*models.Task
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&tasks).Error
+	return tasks, err
+}
+
 // ListByUser retrieves tasks for a user
 func (r *TaskRepository) ListByUser(userID uint, limit, offset int) ([]*models.Task, error) {
 	var tasks []*models.Task
@@ -56,6 +111,85 @@ func (r *TaskRepository) ListByUser(userID uint, limit, offset int) ([]*models.T
 	return tasks, err
 }
 
+// ListByStatuses retrieves all tasks in any of the given statuses across
+// workspaces, oldest first
+func (r *TaskRepository) ListByStatuses(statuses ...models.TaskStatus) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.Where("status IN ?", statuses).
+		Order("created_at ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ListDueScheduled retrieves scheduled tasks whose run_at has arrived
+func (r *TaskRepository) ListDueScheduled(now time.Time, limit int) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.Where("status = ? AND run_at IS NOT NULL AND run_at <= ?",
+		models.TaskStatusScheduled, now).
+		Order("run_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ClaimScheduled atomically moves a scheduled task to pending, returning
+// false when another process claimed it first
+func (r *TaskRepository) ClaimScheduled(taskID uint) (bool, error) {
+	result := r.db.Model(&models.Task{}).
+		Where("id = ? AND status = ?", taskID, models.TaskStatusScheduled).
+		Update("status", models.TaskStatusPending)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListBlocked retrieves tasks waiting on dependencies, oldest first
+func (r *TaskRepository) ListBlocked(limit int) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.Where("status = ?", models.TaskStatusBlocked).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ClaimBlocked atomically moves a blocked task to pending, returning false
+// when another process claimed it first
+func (r *TaskRepository) ClaimBlocked(taskID uint) (bool, error) {
+	result := r.db.Model(&models.Task{}).
+		Where("id = ? AND status = ?", taskID, models.TaskStatusBlocked).
+		Update("status", models.TaskStatusPending)
+	return result.RowsAffected > 0, result.Error
+}
+
+// AssignLease records which worker holds a task and when its lease expires
+func (r *TaskRepository) AssignLease(taskID uint, worker string, expires time.Time) error {
+	return r.db.Model(&models.Task{}).
+		Where("id = ?", taskID).
+		Updates(map[string]interface{}{
+			"worker_name":      worker,
+			"lease_expires_at": expires,
+		}).Error
+}
+
+// RenewLeases extends the lease on every running task a worker holds,
+// called from the worker's heartbeat loop
+func (r *TaskRepository) RenewLeases(worker string, expires time.Time) error {
+	return r.db.Model(&models.Task{}).
+		Where("worker_name = ? AND status = ?", worker, models.TaskStatusRunning).
+		Update("lease_expires_at", expires).Error
+}
+
+// ListExpiredLeases retrieves running tasks whose worker lease has expired,
+// candidates for re-queueing by the reaper
+func (r *TaskRepository) ListExpiredLeases(now time.Time, limit int) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.Where("status = ? AND lease_expires_at IS NOT NULL AND lease_expires_at < ?",
+		models.TaskStatusRunning, now).
+		Order("lease_expires_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
 // ListByStatus retrieves tasks by status
 func (r *TaskRepository) ListByStatus(workspaceID uint, status models.TaskStatus) ([]*models.Task, error) {
 	var tasks []*models.Task
@@ -65,11 +199,99 @@ func (r *TaskRepository) ListByStatus(workspaceID uint, status models.TaskStatus
 	return tasks, err
 }
 
+// CountForResource counts total and failed tasks for a resource within a
+// time window
+func (r *TaskRepository) CountForResource(workspaceID uint, resourceName string, since, until time.Time) (total, failed int64, err error) {
+	base := r.db.Model(&models.Task{}).
+		Where("workspace_id = ? AND resource_name = ? AND created_at >= ? AND created_at < ?",
+			workspaceID, resourceName, since, until)
+
+	if err = base.Count(&total).Error; err != nil {
+		return 0, 0, err
+	}
+	err = base.Where("status = ?", models.TaskStatusFailed).Count(&failed).Error
+	return total, failed, err
+}
+
+// SumTokensForResource totals the tokens used by a resource's tasks created
+// since the given time
+func (r *TaskRepository) SumTokensForResource(workspaceID uint, resourceName string, since time.Time) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.Task{}).
+		Where("workspace_id = ? AND resource_name = ? AND created_at >= ?", workspaceID, resourceName, since).
+		Select("COALESCE(SUM(tokens_used), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// SumTokensForWorkspace totals the tokens tasks in a workspace consumed
+// since the given time, for workspace budget enforcement
+func (r *TaskRepository) SumTokensForWorkspace(workspaceID uint, since time.Time) (int64, error) {
+	var total int64
+	err := r.db.Model(&models.Task{}).
+		Where("workspace_id = ? AND created_at >= ?", workspaceID, since).
+		Select("COALESCE(SUM(tokens_used), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// UpdateProgress atomically updates a task's status and raises its progress,
+// never lowering it, so concurrent callbacks cannot move progress backwards
+func (r *TaskRepository) UpdateProgress(taskID uint, progress int, status models.TaskStatus) error {
+	return r.db.Model(&models.Task{}).Where("id = ?", taskID).
+		Updates(map[string]interface{}{
+			"progress": gorm.Expr("GREATEST(progress, ?)", progress),
+			"status":   status,
+		}).Error
+}
+
+// FindCachedResult finds the most recent successfully completed task in the
+// workspace with the same cache key, excluding the task being executed
+func (r *TaskRepository) FindCachedResult(workspaceID uint, cacheKey string, excludeID uint) (*models.Task, error) {
+	var task models.Task
+	err := r.db.Where("workspace_id = ? AND cache_key = ? AND id <> ? AND status = ? AND result <> ''",
+		workspaceID, cacheKey, excludeID, models.TaskStatusCompleted).
+		Order("completed_at DESC").
+		First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
 // Delete deletes a task
 func (r *TaskRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Task{}, id).Error
 }
 
+// ListSubTasks retrieves a task's subtasks in plan order
+func (r *TaskRepository) ListSubTasks(taskID uint) ([]*models.SubTask, error) {
+	var subtasks []*models.SubTask
+	err := r.db.Where("task_id = ?", taskID).
+		Order("step_order ASC, id ASC").
+		Find(&subtasks).Error
+	return subtasks, err
+}
+
+// GetSubTask retrieves a subtask by ID
+func (r *TaskRepository) GetSubTask(id uint) (*models.SubTask, error) {
+	var subtask models.SubTask
+	if err := r.db.First(&subtask, id).Error; err != nil {
+		return nil, err
+	}
+	return &subtask, nil
+}
+
+// CreateSubTask creates a subtask
+func (r *TaskRepository) CreateSubTask(subtask *models.SubTask) error {
+	return r.db.Create(subtask).Error
+}
+
+// UpdateSubTask updates a subtask
+func (r *TaskRepository) UpdateSubTask(subtask *models.SubTask) error {
+	return r.db.Save(subtask).Error
+}
+
 // AddLog adds a log entry to a task
 func (r *TaskRepository) AddLog(log *models.TaskLog) error {
 	return r.db.Create(log).Error
@@ -85,3 +307,97 @@ func (r *TaskRepository) GetLogs(taskID uint, limit int) ([]*models.TaskLog, err
 	err := query.Find(&logs).Error
 	return logs, err
 }
+
+// AddEvents persists a batch of event rows for a task
+func (r *TaskRepository) AddEvents(events []*models.TaskEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Create(events).Error
+}
+
+// GetEvents retrieves event rows for a task ordered by sequence number.
+// afterSeq is a pagination cursor: only events with a higher sequence
+// number are returned
+func (r *TaskRepository) GetEvents(taskID uint, afterSeq uint64, limit int) ([]*models.TaskEvent, error) {
+	var events []*models.TaskEvent
+	query := r.db.Where("task_id = ? AND seq > ?", taskID, afterSeq).Order("seq ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&events).Error
+	return events, err
+}
+
+// LastEventSeq returns the highest sequence number stored for a task, or
+// zero when it has no events
+func (r *TaskRepository) LastEventSeq(taskID uint) (uint64, error) {
+	var seq uint64
+	err := r.db.Model(&models.TaskEvent{}).
+		Where("task_id = ?", taskID).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).Error
+	return seq, err
+}
+
+// ListLogsBefore returns a workspace's task logs created before the cutoff,
+// oldest first, for archival
+func (r *TaskRepository) ListLogsBefore(workspaceID uint, cutoff time.Time, limit int) ([]*models.TaskLog, error) {
+	var logs []*models.TaskLog
+	err := r.db.
+		Joins("JOIN tasks ON tasks.id = task_logs.task_id").
+		Where("tasks.workspace_id = ? AND task_logs.created_at < ?", workspaceID, cutoff).
+		Order("task_logs.id ASC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
+
+// ListEventsBefore returns a workspace's event rows created before the
+// cutoff, oldest first, for archival
+func (r *TaskRepository) ListEventsBefore(workspaceID uint, cutoff time.Time, limit int) ([]*models.TaskEvent, error) {
+	var events []*models.TaskEvent
+	err := r.db.
+		Joins("JOIN tasks ON tasks.id = task_events.task_id").
+		Where("tasks.workspace_id = ? AND task_events.created_at < ?", workspaceID, cutoff).
+		Order("task_events.id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// DeleteLogsByID removes task log rows by primary key, after archival
+func (r *TaskRepository) DeleteLogsByID(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Delete(&models.TaskLog{}, ids).Error
+}
+
+// DeleteEventsByID removes event rows by primary key, after archival
+func (r *TaskRepository) DeleteEventsByID(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Delete(&models.TaskEvent{}, ids).Error
+}
+
+// CreateLogArchive records an archive produced by the retention janitor
+func (r *TaskRepository) CreateLogArchive(archive *models.LogArchive) error {
+	return r.db.Create(archive).Error
+}
+
+// ListLogArchives returns recent archives, newest first; workspaceID 0
+// lists archives across all workspaces
+func (r *TaskRepository) ListLogArchives(workspaceID uint, limit int) ([]*models.LogArchive, error) {
+	var archives []*models.LogArchive
+	query := r.db.Order("id DESC")
+	if workspaceID != 0 {
+		query = query.Where("workspace_id = ?", workspaceID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&archives).Error
+	return archives, err
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+type TaskTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskTemplateRepository(db *gorm.DB) *TaskTemplateRepository {
+	return &TaskTemplateRepository{db: db}
+}
+
+func (r *TaskTemplateRepository) Create(template *models.TaskTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *TaskTemplateRepository) GetByID(id uint) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetByName looks a template up by its workspace-scoped name
+func (r *TaskTemplateRepository) GetByName(workspaceID uint, name string) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	if err := r.db.Where("workspace_id = ? AND name = ?", workspaceID, name).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *TaskTemplateRepository) Update(template *models.TaskTemplate) error {
+	return r.db.Save(template).Error
+}
+
+func (r *TaskTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TaskTemplate{}, id).Error
+}
+
+func (r *TaskTemplateRepository) ListByWorkspace(workspaceID uint) ([]*models.TaskTemplate, error) {
+	var templates []*models.TaskTemplate
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("name").Find(&templates).Error
+	return templates, err
+}
@@ -45,6 +45,34 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// GetByGitID retrieves a user by GitHub account ID
+func (r *UserRepository) GetByGitID(gitID string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("git_id = ?", gitID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// List retrieves all users, newest first
+func (r *UserRepository) List(limit, offset int) ([]*models.User, error) {
+	var users []*models.User
+	err := r.db.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+	return users, err
+}
+
+// GetRole retrieves a user's platform-wide role
+func (r *UserRepository) GetRole(userID uint) (string, error) {
+	var role string
+	err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Pluck("role", &role).Error
+	return role, err
+}
+
 func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
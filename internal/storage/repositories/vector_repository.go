@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+// VectorRepository stores embedded text chunks and runs similarity search.
+// On Postgres with the pgvector extension the search happens in the
+// database; on MySQL/SQLite it falls back to brute-force cosine similarity
+// in Go, which is fine for the corpus sizes a single workspace holds.
+type VectorRepository struct {
+	db *gorm.DB
+
+	pgvectorOnce sync.Once
+	pgvector     bool
+}
+
+func NewVectorRepository(db *gorm.DB) *VectorRepository {
+	return &VectorRepository{db: db}
+}
+
+// ReplaceSource atomically swaps a source's chunks for a fresh set, used
+// when a document is re-indexed
+func (r *VectorRepository) ReplaceSource(workspaceID uint, source string, chunks []*models.VectorChunk) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ? AND source = ?", workspaceID, source).
+			Delete(&models.VectorChunk{}).Error; err != nil {
+			return err
+		}
+		if len(chunks) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(chunks, 100).Error
+	})
+}
+
+// DeleteBySource removes all chunks indexed from the given source
+func (r *VectorRepository) DeleteBySource(workspaceID uint, source string) error {
+	return r.db.Where("workspace_id = ? AND source = ?", workspaceID, source).
+		Delete(&models.VectorChunk{}).Error
+}
+
+// Search returns the workspace's chunks most similar to the query embedding
+func (r *VectorRepository) Search(workspaceID uint, embedding []float32, limit int) ([]*models.VectorChunk, error) {
+	return r.SearchSources(workspaceID, nil, embedding, limit)
+}
+
+// SearchSources restricts Search to chunks indexed from the given sources;
+// nil sources searches the whole workspace
+func (r *VectorRepository) SearchSources(workspaceID uint, sources []string, embedding []float32, limit int) ([]*models.VectorChunk, error) {
+	if len(embedding) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 5
+	}
+
+	if r.pgvectorAvailable() {
+		return r.searchPgvector(workspaceID, sources, embedding, limit)
+	}
+	return r.searchBruteForce(workspaceID, sources, embedding, limit)
+}
+
+// pgvectorAvailable reports whether the database can run vector queries;
+// the check runs once per process
+func (r *VectorRepository) pgvectorAvailable() bool {
+	r.pgvectorOnce.Do(func() {
+		if r.db.Dialector.Name() != "postgres" {
+			return
+		}
+		var count int64
+		if err := r.db.Raw("SELECT COUNT(*) FROM pg_extension WHERE extname = 'vector'").
+			Scan(&count).Error; err == nil && count > 0 {
+			r.pgvector = true
+		}
+	})
+	return r.pgvector
+}
+
+// searchPgvector orders by cosine distance in the database. The stored text
+// literal casts straight to pgvector's vector type.
+func (r *VectorRepository) searchPgvector(workspaceID uint, sources []string, embedding []float32, limit int) ([]*models.VectorChunk, error) {
+	query := &models.VectorChunk{}
+	query.SetVector(embedding)
+
+	sql := `SELECT * FROM vector_chunks
+		 WHERE workspace_id = ? AND dimensions = ?`
+	args := []interface{}{workspaceID, len(embedding)}
+	if len(sources) > 0 {
+		sql += ` AND source IN ?`
+		args = append(args, sources)
+	}
+	sql += `
+		 ORDER BY embedding::vector <=> ?::vector
+		 LIMIT ?`
+	args = append(args, query.Embedding, limit)
+
+	var chunks []*models.VectorChunk
+	err := r.db.Raw(sql, args...).Scan(&chunks).Error
+	return chunks, err
+}
+
+// searchBruteForce loads the workspace's chunks and ranks them in Go
+func (r *VectorRepository) searchBruteForce(workspaceID uint, sources []string, embedding []float32, limit int) ([]*models.VectorChunk, error) {
+	query := r.db.Where("workspace_id = ? AND dimensions = ?", workspaceID, len(embedding))
+	if len(sources) > 0 {
+		query = query.Where("source IN ?", sources)
+	}
+	var chunks []*models.VectorChunk
+	if err := query.Find(&chunks).Error; err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		chunk *models.VectorChunk
+		score float64
+	}
+	ranked := make([]scored, 0, len(chunks))
+	for _, chunk := range chunks {
+		vector := chunk.Vector()
+		if vector == nil {
+			continue
+		}
+		ranked = append(ranked, scored{chunk: chunk, score: cosineSimilarity(embedding, vector)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	result := make([]*models.VectorChunk, len(ranked))
+	for i, item := range ranked {
+		result[i] = item.chunk
+	}
+	return result, nil
+}
+
+// cosineSimilarity computes the cosine of the angle between two vectors of
+// equal length
+func cosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *WebhookRepository) GetByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := r.db.First(&webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) Update(webhook *models.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *WebhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Webhook{}, id).Error
+}
+
+func (r *WebhookRepository) ListByWorkspace(workspaceID uint) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.Where("workspace_id = ?", workspaceID).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// ListActive returns all active webhooks across workspaces
+func (r *WebhookRepository) ListActive() ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.Where("active = ?", true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// ListActiveByWorkspace returns the workspace's webhooks that should
+// receive deliveries
+func (r *WebhookRepository) ListActiveByWorkspace(workspaceID uint) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.Where("workspace_id = ? AND active = ?", workspaceID, true).Find(&webhooks).Error
+	return webhooks, err
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+// WorkerRepository handles worker registry data access
+type WorkerRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkerRepository creates a new worker repository
+func NewWorkerRepository(db *gorm.DB) *WorkerRepository {
+	return &WorkerRepository{db: db}
+}
+
+// Register upserts a worker's registry row by name, so a restarted process
+// reuses its existing row instead of accumulating duplicates
+func (r *WorkerRepository) Register(worker *models.Worker) error {
+	var existing models.Worker
+	if err := r.db.Where("name = ?", worker.Name).First(&existing).Error; err == nil {
+		worker.ID = existing.ID
+		worker.CreatedAt = existing.CreatedAt
+	}
+	return r.db.Save(worker).Error
+}
+
+// Heartbeat refreshes a worker's last heartbeat timestamp
+func (r *WorkerRepository) Heartbeat(name string, at time.Time) error {
+	return r.db.Model(&models.Worker{}).
+		Where("name = ?", name).
+		Update("last_heartbeat", at).Error
+}
+
+// List retrieves all registered workers, most recent heartbeat first
+func (r *WorkerRepository) List() ([]*models.Worker, error) {
+	var workers []*models.Worker
+	err := r.db.Order("last_heartbeat DESC").Find(&workers).Error
+	return workers, err
+}
+
+// DeleteStale removes registry rows whose heartbeat predates the cutoff,
+// keeping the table from collecting long-dead processes
+func (r *WorkerRepository) DeleteStale(cutoff time.Time) error {
+	return r.db.Where("last_heartbeat < ?", cutoff).Delete(&models.Worker{}).Error
+}
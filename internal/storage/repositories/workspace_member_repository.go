@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"gorm.io/gorm"
+)
+
+type WorkspaceMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkspaceMemberRepository(db *gorm.DB) *WorkspaceMemberRepository {
+	return &WorkspaceMemberRepository{db: db}
+}
+
+func (r *WorkspaceMemberRepository) Create(member *models.WorkspaceMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *WorkspaceMemberRepository) Update(member *models.WorkspaceMember) error {
+	return r.db.Save(member).Error
+}
+
+func (r *WorkspaceMemberRepository) Get(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	if err := r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *WorkspaceMemberRepository) ListByWorkspace(workspaceID uint) ([]*models.WorkspaceMember, error) {
+	var members []*models.WorkspaceMember
+	err := r.db.Where("workspace_id = ?", workspaceID).Order("created_at ASC").Find(&members).Error
+	return members, err
+}
+
+func (r *WorkspaceMemberRepository) ListWorkspaceIDsByUser(userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.WorkspaceMember{}).
+		Where("user_id = ?", userID).
+		Pluck("workspace_id", &ids).Error
+	return ids, err
+}
+
+func (r *WorkspaceMemberRepository) Delete(workspaceID, userID uint) error {
+	return r.db.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Delete(&models.WorkspaceMember{}).Error
+}
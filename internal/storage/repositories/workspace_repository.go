@@ -35,6 +35,54 @@ func (r *WorkspaceRepository) ListByUser(userID uint) ([]*models.Workspace, erro
 	return workspaces, err
 }
 
+// ListAccessible returns workspaces the user owns or has been invited to
+func (r *WorkspaceRepository) ListAccessible(userID uint) ([]*models.Workspace, error) {
+	var workspaces []*models.Workspace
+	err := r.db.
+		Joins("LEFT JOIN workspace_members ON workspace_members.workspace_id = workspaces.id AND workspace_members.user_id = ? AND workspace_members.deleted_at IS NULL", userID).
+		Where("workspaces.user_id = ? OR workspace_members.id IS NOT NULL", userID).
+		Order("workspaces.created_at DESC").
+		Find(&workspaces).Error
+	return workspaces, err
+}
+
+// GetDefault returns the user's default workspace: the one flagged
+// is_default, falling back to their oldest workspace so the choice is
+// deterministic
+func (r *WorkspaceRepository) GetDefault(userID uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	err := r.db.Where("user_id = ?", userID).
+		Order("is_default DESC, created_at ASC").
+		First(&workspace).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// SetDefault flags one of the user's workspaces as their default, clearing
+// the flag from the others
+func (r *WorkspaceRepository) SetDefault(userID, workspaceID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Workspace{}).
+			Where("user_id = ? AND is_default", userID).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Workspace{}).
+			Where("id = ? AND user_id = ?", workspaceID, userID).
+			Update("is_default", true).Error
+	})
+}
+
+// ListWithLogRetention returns workspaces that have a retention window
+// configured, for the log archival janitor
+func (r *WorkspaceRepository) ListWithLogRetention() ([]*models.Workspace, error) {
+	var workspaces []*models.Workspace
+	err := r.db.Where("log_retention_days > 0").Find(&workspaces).Error
+	return workspaces, err
+}
+
 func (r *WorkspaceRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Workspace{}, id).Error
 }
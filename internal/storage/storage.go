@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/xcode-ai/xgent-go/internal/storage/models"
 	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
@@ -9,7 +10,6 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 // Config contains storage configuration
@@ -20,6 +20,14 @@ type Config struct {
 	Database string
 	Username string
 	Password string
+
+	// EncryptionKey enables AES-GCM encryption of sensitive fields (Git
+	// tokens, Mind API keys) at rest. Empty disables encryption.
+	EncryptionKey string
+
+	// SlowQueryThreshold logs queries slower than this; 0 uses the default
+	// of 200ms
+	SlowQueryThreshold time.Duration
 }
 
 // Storage manages database access
@@ -29,10 +37,19 @@ type Storage struct {
 
 	users       *repositories.UserRepository
 	workspaces  *repositories.WorkspaceRepository
+	members     *repositories.WorkspaceMemberRepository
 	resources   *repositories.ResourceRepository
 	tasks       *repositories.TaskRepository
 	sessions    *repositories.SessionRepository
+	logins      *repositories.LoginSessionRepository
 	attachments *repositories.AttachmentRepository
+	artifacts   *repositories.ArtifactRepository
+	secrets     *repositories.SecretRepository
+	webhooks    *repositories.WebhookRepository
+	vectors     *repositories.VectorRepository
+	audits      *repositories.AuditLogRepository
+	templates   *repositories.TaskTemplateRepository
+	workers     *repositories.WorkerRepository
 }
 
 // New creates a new storage instance
@@ -55,41 +72,83 @@ func New(cfg *Config, log *zap.Logger) (*Storage, error) {
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
 	}
 
+	threshold := cfg.SlowQueryThreshold
+	if threshold == 0 {
+		threshold = 200 * time.Millisecond
+	}
+
 	db, err := gorm.Open(dialector, &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
+		Logger: newQueryLogger(log, threshold),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if cfg.EncryptionKey != "" {
+		if err := setupFieldEncryption(cfg.EncryptionKey, log); err != nil {
+			return nil, fmt.Errorf("failed to initialize field encryption: %w", err)
+		}
+	} else {
+		log.Warn("No encryption key configured, sensitive fields are stored in plaintext")
+	}
+
+	if err := registerTenantScoping(db); err != nil {
+		return nil, fmt.Errorf("failed to register tenant scoping: %w", err)
+	}
+
 	storage := &Storage{
 		db:     db,
 		logger: log,
 	}
-
-	storage.users = repositories.NewUserRepository(db)
-	storage.workspaces = repositories.NewWorkspaceRepository(db)
-	storage.resources = repositories.NewResourceRepository(db)
-	storage.tasks = repositories.NewTaskRepository(db)
-	storage.sessions = repositories.NewSessionRepository(db)
-	storage.attachments = repositories.NewAttachmentRepository(db)
+	storage.attachRepositories(db)
 
 	return storage, nil
 }
 
+// attachRepositories wires every repository against the given database
+// handle; Scoped reuses it to rebuild repositories on a context-bound session
+func (s *Storage) attachRepositories(db *gorm.DB) {
+	s.users = repositories.NewUserRepository(db)
+	s.workspaces = repositories.NewWorkspaceRepository(db)
+	s.members = repositories.NewWorkspaceMemberRepository(db)
+	s.resources = repositories.NewResourceRepository(db)
+	s.tasks = repositories.NewTaskRepository(db)
+	s.sessions = repositories.NewSessionRepository(db)
+	s.logins = repositories.NewLoginSessionRepository(db)
+	s.attachments = repositories.NewAttachmentRepository(db)
+	s.artifacts = repositories.NewArtifactRepository(db)
+	s.secrets = repositories.NewSecretRepository(db)
+	s.webhooks = repositories.NewWebhookRepository(db)
+	s.vectors = repositories.NewVectorRepository(db)
+	s.audits = repositories.NewAuditLogRepository(db)
+	s.templates = repositories.NewTaskTemplateRepository(db)
+	s.workers = repositories.NewWorkerRepository(db)
+}
+
 // AutoMigrate runs database migrations
 func (s *Storage) AutoMigrate() error {
 	return s.db.AutoMigrate(
 		&models.User{},
 		&models.Workspace{},
+		&models.WorkspaceMember{},
 		&models.Resource{},
 		&models.Task{},
 		&models.SubTask{},
 		&models.TaskLog{},
+		&models.TaskEvent{},
+		&models.LogArchive{},
+		&models.Worker{},
 		&models.Session{},
+		&models.LoginSession{},
 		&models.Message{},
 		&models.APIKey{},
 		&models.Attachment{},
+		&models.Artifact{},
+		&models.Secret{},
+		&models.Webhook{},
+		&models.VectorChunk{},
+		&models.AuditLog{},
+		&models.TaskTemplate{},
 	)
 }
 
@@ -108,6 +167,11 @@ func (s *Storage) Workspaces() *repositories.WorkspaceRepository {
 	return s.workspaces
 }
 
+// WorkspaceMembers returns the workspace member repository
+func (s *Storage) WorkspaceMembers() *repositories.WorkspaceMemberRepository {
+	return s.members
+}
+
 // Resources returns the resource repository
 func (s *Storage) Resources() *repositories.ResourceRepository {
 	return s.resources
@@ -118,6 +182,11 @@ func (s *Storage) Tasks() *repositories.TaskRepository {
 	return s.tasks
 }
 
+// LoginSessions returns the login session repository
+func (s *Storage) LoginSessions() *repositories.LoginSessionRepository {
+	return s.logins
+}
+
 // Sessions returns the session repository
 func (s *Storage) Sessions() *repositories.SessionRepository {
 	return s.sessions
@@ -128,6 +197,41 @@ func (s *Storage) Attachments() *repositories.AttachmentRepository {
 	return s.attachments
 }
 
+// Artifacts returns the artifact repository
+func (s *Storage) Artifacts() *repositories.ArtifactRepository {
+	return s.artifacts
+}
+
+// Secrets returns the secret repository
+func (s *Storage) Secrets() *repositories.SecretRepository {
+	return s.secrets
+}
+
+// Webhooks returns the webhook repository
+func (s *Storage) Webhooks() *repositories.WebhookRepository {
+	return s.webhooks
+}
+
+// Vectors returns the vector chunk repository
+func (s *Storage) Vectors() *repositories.VectorRepository {
+	return s.vectors
+}
+
+// Audits returns the audit log repository
+func (s *Storage) Audits() *repositories.AuditLogRepository {
+	return s.audits
+}
+
+// TaskTemplates returns the task template repository
+func (s *Storage) TaskTemplates() *repositories.TaskTemplateRepository {
+	return s.templates
+}
+
+// Workers returns the worker registry repository
+func (s *Storage) Workers() *repositories.WorkerRepository {
+	return s.workers
+}
+
 // Close closes the database connection
 func (s *Storage) Close() error {
 	sqlDB, err := s.db.DB()
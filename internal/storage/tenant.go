@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Tenant describes the rows a request may touch: the authenticated user and
+// the workspaces they own or were invited to. A query issued under a tenant
+// context is automatically constrained to those rows.
+type Tenant struct {
+	UserID       uint
+	WorkspaceIDs []uint
+}
+
+type tenantContextKey struct{}
+
+// WithTenant attaches a tenant to the context for Scoped queries
+func WithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFrom extracts the tenant attached by WithTenant, if any
+func TenantFrom(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(*Tenant)
+	return tenant, ok && tenant != nil
+}
+
+// Scoped returns a storage view whose queries run under ctx. When the context
+// carries a tenant, every query against a tenant-owned table is constrained
+// to that tenant's rows, so a handler using the scoped view cannot read or
+// modify another tenant's data by omitting a filter.
+func (s *Storage) Scoped(ctx context.Context) *Storage {
+	scoped := &Storage{
+		db:     s.db.WithContext(ctx),
+		logger: s.logger,
+	}
+	scoped.attachRepositories(scoped.db)
+	return scoped
+}
+
+// registerTenantScoping installs the callback that enforces tenant
+// constraints on every query, update and delete
+func registerTenantScoping(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("xgent:tenant_scope", applyTenantScope); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("xgent:tenant_scope", applyTenantScope); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("xgent:tenant_scope", applyTenantScope); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("xgent:tenant_scope", applyTenantScope)
+}
+
+// applyTenantScope narrows the pending statement to the tenant in its
+// context. Workspace-owned tables are restricted to the tenant's workspaces,
+// user-owned tables to the user; tables carrying neither column (users,
+// workers, login sessions) are left alone. Raw SQL has no schema and is not
+// rewritten, so repositories using it must filter explicitly.
+func applyTenantScope(db *gorm.DB) {
+	tenant, ok := TenantFrom(db.Statement.Context)
+	if !ok {
+		return
+	}
+	schema := db.Statement.Schema
+	if schema == nil {
+		return
+	}
+
+	switch {
+	case db.Statement.Table == "workspaces":
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: "id"}, Values: idValues(tenant.WorkspaceIDs)},
+		}})
+	case schema.FieldsByDBName["workspace_id"] != nil:
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: "workspace_id"}, Values: idValues(tenant.WorkspaceIDs)},
+		}})
+	case schema.FieldsByDBName["user_id"] != nil:
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "user_id"}, Value: tenant.UserID},
+		}})
+	}
+}
+
+// idValues converts IDs into the values an IN clause expects; an empty list
+// builds IN (NULL), which matches nothing
+func idValues(ids []uint) []interface{} {
+	values := make([]interface{}, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return values
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+)
+
+// fileReadLimit caps what a single read feeds back into the model
+const fileReadLimit = 256 << 10
+
+// FileReadTool reads files confined to the task workspace
+type FileReadTool struct {
+	workDir string
+}
+
+// NewFileReadTool creates a read tool rooted at the task workspace
+func NewFileReadTool(workDir string) *FileReadTool {
+	return &FileReadTool{workDir: workDir}
+}
+
+func (t *FileReadTool) Definition() llm.Tool {
+	return llm.Tool{
+		Name:        "file_read",
+		Description: "Read a file from the task workspace. Paths are relative to the workspace root.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Workspace-relative path of the file to read",
+				},
+			},
+			"required": []string{"path"},
+		},
+	}
+}
+
+func (t *FileReadTool) Execute(ctx context.Context, args map[string]interface{}) (string, error) {
+	path, err := stringArg(args, "path")
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := workspacePath(t.workDir, path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return "", err
+	}
+	if len(data) > fileReadLimit {
+		data = data[:fileReadLimit]
+	}
+	return string(data), nil
+}
+
+// FileWriteTool writes files confined to the task workspace
+type FileWriteTool struct {
+	workDir string
+}
+
+// NewFileWriteTool creates a write tool rooted at the task workspace
+func NewFileWriteTool(workDir string) *FileWriteTool {
+	return &FileWriteTool{workDir: workDir}
+}
+
+func (t *FileWriteTool) Definition() llm.Tool {
+	return llm.Tool{
+		Name:        "file_write",
+		Description: "Write content to a file in the task workspace, creating parent directories as needed. Paths are relative to the workspace root.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Workspace-relative path of the file to write",
+				},
+				"content": map[string]interface{}{
+					"type":        "string",
+					"description": "The full file content",
+				},
+			},
+			"required": []string{"path", "content"},
+		},
+	}
+}
+
+func (t *FileWriteTool) Execute(ctx context.Context, args map[string]interface{}) (string, error) {
+	path, err := stringArg(args, "path")
+	if err != nil {
+		return "", err
+	}
+	content, ok := args["content"].(string)
+	if !ok {
+		return "", fmt.Errorf("missing required argument: content")
+	}
+
+	resolved, err := workspacePath(t.workDir, path)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(resolved), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(resolved, []byte(content), 0o644); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("wrote %d bytes to %s", len(content), path), nil
+}
+
+// workspacePath resolves a model-supplied path against the workspace root,
+// rejecting escapes via .. or absolute paths
+func workspacePath(workDir, path string) (string, error) {
+	if workDir == "" {
+		return "", fmt.Errorf("no task workspace prepared")
+	}
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("absolute paths are not allowed: %s", path)
+	}
+
+	resolved := filepath.Join(workDir, path)
+	rel, err := filepath.Rel(workDir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path escapes the workspace: %s", path)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+)
+
+const (
+	// httpFetchTimeout bounds one fetch
+	httpFetchTimeout = 30 * time.Second
+
+	// httpFetchLimit caps what a response may feed back into the model
+	httpFetchLimit = 256 << 10
+)
+
+// HTTPFetchTool fetches a URL and returns the response body. Crafts may
+// restrict reachable hosts via the `allow_hosts` config key.
+type HTTPFetchTool struct {
+	allowHosts map[string]bool
+}
+
+// NewHTTPFetchTool creates the fetch tool from a craft's tool config
+func NewHTTPFetchTool(config map[string]interface{}) *HTTPFetchTool {
+	tool := &HTTPFetchTool{}
+	if hosts, ok := config["allow_hosts"].([]interface{}); ok {
+		tool.allowHosts = make(map[string]bool)
+		for _, entry := range hosts {
+			if host, ok := entry.(string); ok {
+				tool.allowHosts[host] = true
+			}
+		}
+	}
+	return tool
+}
+
+func (t *HTTPFetchTool) Definition() llm.Tool {
+	return llm.Tool{
+		Name:        "http_fetch",
+		Description: "Fetch a URL with an HTTP GET request and return the response body.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "The http(s) URL to fetch",
+				},
+			},
+			"required": []string{"url"},
+		},
+	}
+}
+
+func (t *HTTPFetchTool) Execute(ctx context.Context, args map[string]interface{}) (string, error) {
+	url, err := stringArg(args, "url")
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "", fmt.Errorf("only http(s) URLs are supported")
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, httpFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if t.allowHosts != nil && !t.allowHosts[req.URL.Hostname()] {
+		return "", fmt.Errorf("host not allowed: %s", req.URL.Hostname())
+	}
+
+	// Redirects are re-validated per hop: an allowed host must not be able
+	// to bounce the agent to an internal address
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if t.allowHosts != nil && !t.allowHosts[req.URL.Hostname()] {
+				return fmt.Errorf("redirect to disallowed host: %s", req.URL.Hostname())
+			}
+			if len(via) >= 10 {
+				return fmt.Errorf("too many redirects")
+			}
+			return nil
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, httpFetchLimit))
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return string(data), nil
+}
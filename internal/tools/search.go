@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+)
+
+// webSearchTimeout bounds one search
+const webSearchTimeout = 15 * time.Second
+
+// WebSearchTool answers queries through the DuckDuckGo instant answer API,
+// which needs no API key
+type WebSearchTool struct{}
+
+// NewWebSearchTool creates the web search tool
+func NewWebSearchTool() *WebSearchTool {
+	return &WebSearchTool{}
+}
+
+func (t *WebSearchTool) Definition() llm.Tool {
+	return llm.Tool{
+		Name:        "web_search",
+		Description: "Search the web and return a short summary with related results.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "The search query",
+				},
+			},
+			"required": []string{"query"},
+		},
+	}
+}
+
+func (t *WebSearchTool) Execute(ctx context.Context, args map[string]interface{}) (string, error) {
+	query, err := stringArg(args, "query")
+	if err != nil {
+		return "", err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, webSearchTimeout)
+	defer cancel()
+
+	endpoint := "https://api.duckduckgo.com/?format=json&no_html=1&q=" + url.QueryEscape(query)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, httpFetchLimit))
+	if err != nil {
+		return "", err
+	}
+
+	var answer struct {
+		AbstractText  string `json:"AbstractText"`
+		AbstractURL   string `json:"AbstractURL"`
+		Answer        string `json:"Answer"`
+		RelatedTopics []struct {
+			Text     string `json:"Text"`
+			FirstURL string `json:"FirstURL"`
+		} `json:"RelatedTopics"`
+	}
+	if err := json.Unmarshal(data, &answer); err != nil {
+		return "", fmt.Errorf("unexpected search response: %w", err)
+	}
+
+	var b strings.Builder
+	if answer.Answer != "" {
+		fmt.Fprintf(&b, "%s\n", answer.Answer)
+	}
+	if answer.AbstractText != "" {
+		fmt.Fprintf(&b, "%s (%s)\n", answer.AbstractText, answer.AbstractURL)
+	}
+	for i, topic := range answer.RelatedTopics {
+		if i >= 5 {
+			break
+		}
+		if topic.Text != "" {
+			fmt.Fprintf(&b, "- %s (%s)\n", topic.Text, topic.FirstURL)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "no results", nil
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/llm"
+)
+
+const (
+	// shellDefaultTimeout bounds one command; overridable per craft via the
+	// timeout_seconds config key
+	shellDefaultTimeout = 2 * time.Minute
+
+	// shellOutputLimit caps what a command may feed back into the model
+	shellOutputLimit = 64 << 10
+)
+
+// ShellTool runs commands through `sh -c` in the task workspace. Without an
+// allowlist every command is refused: crafts must opt binaries in via the
+// `allow` config key ("allow": ["git", "go", "ls"]).
+type ShellTool struct {
+	workDir string
+	allow   map[string]bool
+	timeout time.Duration
+}
+
+// NewShellTool creates the shell tool from a craft's tool config
+func NewShellTool(workDir string, config map[string]interface{}) *ShellTool {
+	tool := &ShellTool{
+		workDir: workDir,
+		allow:   make(map[string]bool),
+		timeout: shellDefaultTimeout,
+	}
+
+	if allowed, ok := config["allow"].([]interface{}); ok {
+		for _, entry := range allowed {
+			if name, ok := entry.(string); ok {
+				tool.allow[name] = true
+			}
+		}
+	}
+	if seconds, ok := config["timeout_seconds"].(int); ok && seconds > 0 {
+		tool.timeout = time.Duration(seconds) * time.Second
+	}
+
+	return tool
+}
+
+func (t *ShellTool) Definition() llm.Tool {
+	return llm.Tool{
+		Name:        "shell",
+		Description: "Run a shell command in the task workspace and return its output. Only allowlisted programs may be invoked.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"command": map[string]interface{}{
+					"type":        "string",
+					"description": "The command to run",
+				},
+			},
+			"required": []string{"command"},
+		},
+	}
+}
+
+func (t *ShellTool) Execute(ctx context.Context, args map[string]interface{}) (string, error) {
+	command, err := stringArg(args, "command")
+	if err != nil {
+		return "", err
+	}
+
+	// Command and parameter substitution run programs the segment scan
+	// below never sees (`ls $(curl ...)`), so they are refused outright
+	if strings.Contains(command, "$(") || strings.Contains(command, "${") || strings.ContainsRune(command, '`') {
+		return "", fmt.Errorf("command substitution is not allowed")
+	}
+
+	// Every pipeline segment's program must be allowlisted, so `ls; curl ...`
+	// can't smuggle a forbidden binary past a permissive first token
+	for _, program := range commandPrograms(command) {
+		if !t.allow[program] {
+			return "", fmt.Errorf("command not allowed: %s", program)
+		}
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, "sh", "-c", command)
+	if t.workDir != "" {
+		cmd.Dir = t.workDir
+	}
+
+	output, err := cmd.CombinedOutput()
+	if len(output) > shellOutputLimit {
+		output = output[:shellOutputLimit]
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w\n%s", err, output)
+	}
+	return string(output), nil
+}
+
+// commandPrograms extracts the first token of every segment of a compound
+// command (split on ;, &&, ||, | and newlines)
+func commandPrograms(command string) []string {
+	segments := strings.FieldsFunc(command, func(r rune) bool {
+		return r == ';' || r == '&' || r == '|' || r == '\n'
+	})
+
+	var programs []string
+	for _, segment := range segments {
+		fields := strings.Fields(segment)
+		if len(fields) > 0 {
+			programs = append(programs, fields[0])
+		}
+	}
+	return programs
+}
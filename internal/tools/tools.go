@@ -0,0 +1,99 @@
+// Package tools is the built-in tool library for the native executor.
+// Crafts enable individual tools with `type: builtin` entries; the executor
+// exposes their definitions through the LLM tool-calling interface and
+// dispatches the model's calls back here.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xcode-ai/xgent-go/internal/crd"
+	"github.com/xcode-ai/xgent-go/internal/llm"
+)
+
+// Tool is one capability the model may call
+type Tool interface {
+	// Definition describes the tool to the model
+	Definition() llm.Tool
+	// Execute runs the tool with the model's decoded arguments
+	Execute(ctx context.Context, args map[string]interface{}) (string, error)
+}
+
+// Registry holds the tools enabled for one execution
+type Registry struct {
+	tools map[string]Tool
+	order []string
+}
+
+// NewRegistry creates an empty tool registry
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]Tool)}
+}
+
+// Register adds a tool, replacing any previous tool with the same name
+func (r *Registry) Register(tool Tool) {
+	name := tool.Definition().Name
+	if _, exists := r.tools[name]; !exists {
+		r.order = append(r.order, name)
+	}
+	r.tools[name] = tool
+}
+
+// Get looks a tool up by name
+func (r *Registry) Get(name string) (Tool, bool) {
+	tool, ok := r.tools[name]
+	return tool, ok
+}
+
+// Len reports how many tools are registered
+func (r *Registry) Len() int {
+	return len(r.tools)
+}
+
+// Definitions returns the tool definitions in registration order, ready to
+// pass to the LLM client
+func (r *Registry) Definitions() []llm.Tool {
+	defs := make([]llm.Tool, 0, len(r.order))
+	for _, name := range r.order {
+		defs = append(defs, r.tools[name].Definition())
+	}
+	return defs
+}
+
+// FromCraft builds a registry from a Craft's enabled `type: builtin` tool
+// configs. workDir confines the shell and file tools; unknown tool names are
+// reported so a typo in a spec doesn't silently drop a capability.
+func FromCraft(configs []crd.ToolConfig, workDir string) (*Registry, error) {
+	registry := NewRegistry()
+	for _, config := range configs {
+		if config.Type != "builtin" || !config.Enabled {
+			continue
+		}
+
+		switch config.Name {
+		case "shell":
+			registry.Register(NewShellTool(workDir, config.Config))
+		case "file_read":
+			registry.Register(NewFileReadTool(workDir))
+		case "file_write":
+			registry.Register(NewFileWriteTool(workDir))
+		case "http_fetch":
+			registry.Register(NewHTTPFetchTool(config.Config))
+		case "web_search":
+			registry.Register(NewWebSearchTool())
+		default:
+			return nil, fmt.Errorf("unknown builtin tool: %s", config.Name)
+		}
+	}
+	return registry, nil
+}
+
+// stringArg extracts a required string argument
+func stringArg(args map[string]interface{}, key string) (string, error) {
+	value, ok := args[key].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("missing required argument: %s", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	exportBatchSize = 64
+	exportInterval  = 5 * time.Second
+	exportQueueSize = 1024
+)
+
+// otlpExporter batches finished spans and ships them to an OTLP/HTTP
+// collector as JSON-encoded trace requests
+type otlpExporter struct {
+	endpoint    string
+	serviceName string
+	client      *http.Client
+	logger      *zap.Logger
+
+	queue chan *Span
+	done  chan struct{}
+}
+
+func newOTLPExporter(cfg Config, logger *zap.Logger) *otlpExporter {
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+
+	e := &otlpExporter{
+		endpoint:    endpoint,
+		serviceName: cfg.ServiceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		logger:      logger,
+		queue:       make(chan *Span, exportQueueSize),
+		done:        make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// enqueue queues a finished span, dropping it when the buffer is full so
+// tracing never blocks request handling
+func (e *otlpExporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+	}
+}
+
+// run drains the queue, flushing on batch size or interval
+func (e *otlpExporter) run() {
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			e.logger.Warn("Failed to export trace spans",
+				zap.Int("spans", len(batch)), zap.Error(err))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span, ok := <-e.queue:
+			if !ok {
+				flush()
+				close(e.done)
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// shutdown stops accepting spans and flushes what is buffered, bounded by
+// the context deadline
+func (e *otlpExporter) shutdown(ctx context.Context) {
+	close(e.queue)
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+	}
+}
+
+// export posts one batch as an OTLP/HTTP JSON ExportTraceServiceRequest
+func (e *otlpExporter) export(spans []*Span) error {
+	otlpSpans := make([]map[string]interface{}, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, encodeSpan(span))
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{{
+			"resource": map[string]interface{}{
+				"attributes": []map[string]interface{}{
+					{"key": "service.name", "value": map[string]string{"stringValue": e.serviceName}},
+				},
+			},
+			"scopeSpans": []map[string]interface{}{{
+				"scope": map[string]string{"name": "xgent"},
+				"spans": otlpSpans,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// encodeSpan renders one span in OTLP JSON form
+func encodeSpan(span *Span) map[string]interface{} {
+	attrs := make([]map[string]interface{}, 0, len(span.attrs))
+	for _, attr := range span.attrs {
+		attrs = append(attrs, map[string]interface{}{
+			"key":   attr.key,
+			"value": map[string]string{"stringValue": attr.value},
+		})
+	}
+
+	encoded := map[string]interface{}{
+		"traceId":           span.TraceID,
+		"spanId":            span.SpanID,
+		"name":              span.name,
+		"kind":              span.kind,
+		"startTimeUnixNano": fmt.Sprintf("%d", span.start.UnixNano()),
+		"endTimeUnixNano":   fmt.Sprintf("%d", span.end.UnixNano()),
+		"attributes":        attrs,
+	}
+	if span.ParentID != "" {
+		encoded["parentSpanId"] = span.ParentID
+	}
+	if span.failed {
+		encoded["status"] = map[string]interface{}{"code": 2, "message": span.errMsg}
+	}
+	return encoded
+}
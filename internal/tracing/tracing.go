@@ -0,0 +1,193 @@
+// Package tracing provides lightweight distributed tracing with OTLP/HTTP
+// export. Spans propagate through context and follow the W3C traceparent
+// format on the wire, so they line up with OpenTelemetry collectors and
+// upstream callers without pulling in the full OTel SDK.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config for the tracing subsystem
+type Config struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Endpoint    string `mapstructure:"endpoint"`     // OTLP/HTTP collector base URL, e.g. http://localhost:4318
+	ServiceName string `mapstructure:"service_name"` // resource service.name; defaults to "xgent"
+}
+
+// Span kinds, matching the OTLP enum values
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+// Span is one traced operation. Spans are not safe for concurrent use; the
+// goroutine that starts a span ends it.
+type Span struct {
+	TraceID  string
+	SpanID   string
+	ParentID string
+
+	name    string
+	kind    int
+	start   time.Time
+	end     time.Time
+	attrs   []attribute
+	errMsg  string
+	failed  bool
+	noop    bool
+	endOnce sync.Once
+}
+
+type attribute struct {
+	key   string
+	value string
+}
+
+type contextKey struct{}
+
+var (
+	mu       sync.RWMutex
+	exporter *otlpExporter
+)
+
+// Init starts the global exporter. Call once at process startup; tracing is
+// a no-op when disabled or unconfigured.
+func Init(cfg Config, logger *zap.Logger) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("tracing enabled but no endpoint configured")
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "xgent"
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	exporter = newOTLPExporter(cfg, logger)
+	return nil
+}
+
+// Shutdown flushes buffered spans and stops the exporter
+func Shutdown(ctx context.Context) {
+	mu.Lock()
+	e := exporter
+	exporter = nil
+	mu.Unlock()
+
+	if e != nil {
+		e.shutdown(ctx)
+	}
+}
+
+// StartSpan begins a span as a child of the span in ctx (or a new root) and
+// returns a context carrying it. Always call End on the returned span.
+func StartSpan(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	mu.RLock()
+	enabled := exporter != nil
+	mu.RUnlock()
+
+	if !enabled {
+		span := &Span{noop: true}
+		return ctx, span
+	}
+
+	span := &Span{
+		name:  name,
+		kind:  kind,
+		start: time.Now(),
+	}
+	if parent := FromContext(ctx); parent != nil && !parent.noop {
+		span.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+	} else {
+		span.TraceID = randomHex(16)
+	}
+	span.SpanID = randomHex(8)
+
+	return context.WithValue(ctx, contextKey{}, span), span
+}
+
+// FromContext returns the current span, or nil when none is active
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(contextKey{}).(*Span)
+	return span
+}
+
+// SetAttribute records a key/value on the span
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil || s.noop {
+		return
+	}
+	s.attrs = append(s.attrs, attribute{key: key, value: value})
+}
+
+// RecordError marks the span failed; nil errors are ignored
+func (s *Span) RecordError(err error) {
+	if s == nil || s.noop || err == nil {
+		return
+	}
+	s.failed = true
+	s.errMsg = err.Error()
+}
+
+// End finishes the span and queues it for export. Safe to call more than
+// once; only the first call takes effect.
+func (s *Span) End() {
+	if s == nil || s.noop {
+		return
+	}
+	s.endOnce.Do(func() {
+		s.end = time.Now()
+
+		mu.RLock()
+		e := exporter
+		mu.RUnlock()
+		if e != nil {
+			e.enqueue(s)
+		}
+	})
+}
+
+// Traceparent renders the span as a W3C traceparent header value for
+// propagation to downstream services
+func (s *Span) Traceparent() string {
+	if s == nil || s.noop {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", s.TraceID, s.SpanID)
+}
+
+// ContextFromTraceparent returns a context whose spans continue the trace
+// described by a W3C traceparent header. Malformed values start a new trace.
+func ContextFromTraceparent(ctx context.Context, traceparent string) context.Context {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ctx
+	}
+	// A synthetic remote parent: never exported, only linked to
+	parent := &Span{TraceID: parts[1], SpanID: parts[2], noop: false}
+	parent.endOnce.Do(func() {}) // already ended; never enqueued
+	return context.WithValue(ctx, contextKey{}, parent)
+}
+
+// randomHex returns n random bytes hex-encoded
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand failing is unrecoverable; fall back to a zero ID
+		return strings.Repeat("0", n*2)
+	}
+	return hex.EncodeToString(buf)
+}
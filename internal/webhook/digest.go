@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"github.com/xcode-ai/xgent-go/internal/storage/repositories"
+	"go.uber.org/zap"
+)
+
+const (
+	// EventDigestDaily and EventDigestWeekly are the report digest events
+	// webhooks can subscribe to
+	EventDigestDaily  = "digest.daily"
+	EventDigestWeekly = "digest.weekly"
+
+	// digestTaskLimit bounds how many tasks one digest aggregates
+	digestTaskLimit = 5000
+)
+
+// DigestPayload is the report posted to subscribed webhook endpoints
+type DigestPayload struct {
+	Event       string    `json:"event"`
+	WorkspaceID uint      `json:"workspace_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+
+	TasksRun    int     `json:"tasks_run"`
+	Succeeded   int     `json:"succeeded"`
+	Failed      int     `json:"failed"`
+	SuccessRate float64 `json:"success_rate"` // percent of finished tasks
+	TokensUsed  int64   `json:"tokens_used"`
+
+	TopFailingRobots []RobotFailures `json:"top_failing_robots,omitempty"`
+}
+
+// RobotFailures summarizes one robot's failures within the digest period
+type RobotFailures struct {
+	Name   string `json:"name"`
+	Failed int    `json:"failed"`
+	Total  int    `json:"total"`
+}
+
+// DigestScheduler periodically builds per-workspace report digests and posts
+// them through the webhook dispatcher. Daily digests go out after UTC
+// midnight, weekly ones after midnight on Mondays.
+type DigestScheduler struct {
+	storage    *storage.Storage
+	dispatcher *Dispatcher
+	logger     *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	lastDaily  string // UTC date of the last daily run
+	lastWeekly string // UTC date of the last weekly run
+}
+
+// NewDigestScheduler creates a digest scheduler posting through the given
+// dispatcher
+func NewDigestScheduler(storage *storage.Storage, dispatcher *Dispatcher, logger *zap.Logger) *DigestScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DigestScheduler{
+		storage:    storage,
+		dispatcher: dispatcher,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start launches the scheduling loop
+func (s *DigestScheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the scheduling loop
+func (s *DigestScheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *DigestScheduler) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(time.Now().UTC())
+		}
+	}
+}
+
+// tick fires any digests due at the given time, at most once per period
+func (s *DigestScheduler) tick(now time.Time) {
+	today := now.Format("2006-01-02")
+
+	if s.lastDaily != today {
+		s.lastDaily = today
+		s.sendDigests(EventDigestDaily, now.Truncate(24*time.Hour).Add(-24*time.Hour), now.Truncate(24*time.Hour))
+	}
+
+	if now.Weekday() == time.Monday && s.lastWeekly != today {
+		s.lastWeekly = today
+		s.sendDigests(EventDigestWeekly, now.Truncate(24*time.Hour).Add(-7*24*time.Hour), now.Truncate(24*time.Hour))
+	}
+}
+
+// sendDigests builds and posts one digest per workspace that has an active
+// webhook subscribed to the event
+func (s *DigestScheduler) sendDigests(event string, start, end time.Time) {
+	webhooks, err := s.storage.Webhooks().ListActive()
+	if err != nil {
+		s.logger.Warn("Failed to list webhooks for digests", zap.Error(err))
+		return
+	}
+
+	// Group subscribed hooks by workspace so each digest is built once
+	byWorkspace := make(map[uint][]*models.Webhook)
+	for _, hook := range webhooks {
+		if hook.Matches(event) {
+			byWorkspace[hook.WorkspaceID] = append(byWorkspace[hook.WorkspaceID], hook)
+		}
+	}
+
+	for workspaceID, hooks := range byWorkspace {
+		payload, err := s.buildDigest(event, workspaceID, start, end)
+		if err != nil {
+			s.logger.Warn("Failed to build digest",
+				zap.Uint("workspace_id", workspaceID),
+				zap.Error(err))
+			continue
+		}
+		if payload.TasksRun == 0 {
+			continue // nothing to report
+		}
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			continue
+		}
+		for _, hook := range hooks {
+			s.dispatcher.enqueue(hook, event, body)
+		}
+	}
+}
+
+// buildDigest aggregates a workspace's tasks within the period
+func (s *DigestScheduler) buildDigest(event string, workspaceID uint, start, end time.Time) (*DigestPayload, error) {
+	tasks, err := s.storage.Tasks().Search(repositories.TaskFilter{
+		WorkspaceID:   workspaceID,
+		CreatedAfter:  start,
+		CreatedBefore: end,
+	}, digestTaskLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &DigestPayload{
+		Event:       event,
+		WorkspaceID: workspaceID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		TasksRun:    len(tasks),
+	}
+
+	perRobot := make(map[string]*RobotFailures)
+	for _, task := range tasks {
+		payload.TokensUsed += int64(task.TokensUsed)
+		switch task.Status {
+		case models.TaskStatusCompleted:
+			payload.Succeeded++
+		case models.TaskStatusFailed:
+			payload.Failed++
+		}
+
+		if task.ResourceName == "" {
+			continue
+		}
+		robot := perRobot[task.ResourceName]
+		if robot == nil {
+			robot = &RobotFailures{Name: task.ResourceName}
+			perRobot[task.ResourceName] = robot
+		}
+		robot.Total++
+		if task.Status == models.TaskStatusFailed {
+			robot.Failed++
+		}
+	}
+
+	if finished := payload.Succeeded + payload.Failed; finished > 0 {
+		payload.SuccessRate = float64(payload.Succeeded) / float64(finished) * 100
+	}
+
+	for _, robot := range perRobot {
+		if robot.Failed > 0 {
+			payload.TopFailingRobots = append(payload.TopFailingRobots, *robot)
+		}
+	}
+	sort.Slice(payload.TopFailingRobots, func(i, j int) bool {
+		return payload.TopFailingRobots[i].Failed > payload.TopFailingRobots[j].Failed
+	})
+	if len(payload.TopFailingRobots) > 5 {
+		payload.TopFailingRobots = payload.TopFailingRobots[:5]
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/xcode-ai/xgent-go/internal/storage"
+	"github.com/xcode-ai/xgent-go/internal/storage/models"
+	"go.uber.org/zap"
+)
+
+const (
+	maxAttempts = 3
+	queueSize   = 256
+)
+
+// Payload is the JSON body delivered to webhook endpoints
+type Payload struct {
+	Event       string            `json:"event"` // task.started, task.completed, ...
+	TaskID      uint              `json:"task_id"`
+	WorkspaceID uint              `json:"workspace_id"`
+	Status      models.TaskStatus `json:"status"`
+	Title       string            `json:"title"`
+	Error       string            `json:"error,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+// delivery is one webhook endpoint receiving one serialized payload
+type delivery struct {
+	webhook *models.Webhook
+	event   string
+	body    []byte
+}
+
+// Dispatcher watches task status transitions and delivers webhook
+// notifications with retries and HMAC signatures
+type Dispatcher struct {
+	storage *storage.Storage
+	logger  *zap.Logger
+	client  *http.Client
+
+	queue  chan delivery
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu         sync.Mutex
+	lastStatus map[uint]models.TaskStatus
+}
+
+// NewDispatcher creates a webhook dispatcher
+func NewDispatcher(storage *storage.Storage, logger *zap.Logger) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		storage:    storage,
+		logger:     logger,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		queue:      make(chan delivery, queueSize),
+		ctx:        ctx,
+		cancel:     cancel,
+		lastStatus: make(map[uint]models.TaskStatus),
+	}
+}
+
+// Start launches the delivery workers
+func (d *Dispatcher) Start() {
+	for i := 0; i < 2; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+}
+
+// Stop drains the workers
+func (d *Dispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// NotifyStatus records a progress callback's status and fires webhooks when
+// a task transitions into a new state worth notifying about
+func (d *Dispatcher) NotifyStatus(taskID uint, status models.TaskStatus) {
+	event := ""
+	switch status {
+	case models.TaskStatusRunning:
+		event = "task.started"
+	case models.TaskStatusCompleted:
+		event = "task.completed"
+	case models.TaskStatusFailed:
+		event = "task.failed"
+	case models.TaskStatusCancelled:
+		event = "task.cancelled"
+	default:
+		return
+	}
+
+	// Only fire on transitions, not on every progress event
+	d.mu.Lock()
+	if d.lastStatus[taskID] == status {
+		d.mu.Unlock()
+		return
+	}
+	d.lastStatus[taskID] = status
+	if status != models.TaskStatusRunning {
+		// Terminal state; no more transitions to track
+		defer func() {
+			d.mu.Lock()
+			delete(d.lastStatus, taskID)
+			d.mu.Unlock()
+		}()
+	}
+	d.mu.Unlock()
+
+	task, err := d.storage.Tasks().GetByID(taskID)
+	if err != nil {
+		return
+	}
+
+	webhooks, err := d.storage.Webhooks().ListActiveByWorkspace(task.WorkspaceID)
+	if err != nil || len(webhooks) == 0 {
+		return
+	}
+
+	payload := Payload{
+		Event:       event,
+		TaskID:      task.ID,
+		WorkspaceID: task.WorkspaceID,
+		Status:      status,
+		Title:       task.Title,
+		Error:       task.Error,
+		Timestamp:   time.Now(),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	for _, hook := range webhooks {
+		if !hook.Matches(event) {
+			continue
+		}
+		d.enqueue(hook, event, body)
+	}
+}
+
+// enqueue hands a serialized payload to the delivery workers, dropping it
+// with a warning when the queue is full
+func (d *Dispatcher) enqueue(hook *models.Webhook, event string, body []byte) {
+	select {
+	case d.queue <- delivery{webhook: hook, event: event, body: body}:
+	default:
+		d.logger.Warn("Webhook queue full, dropping delivery",
+			zap.Uint("webhook_id", hook.ID),
+			zap.String("event", event))
+	}
+}
+
+// worker delivers queued payloads, retrying failures with backoff
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case item := <-d.queue:
+			d.deliver(item)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(item delivery) {
+	deliveryID := uuid.New().String()
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := d.send(item.webhook, item.event, deliveryID, item.body); err != nil {
+			lastErr = err
+			// Exponential backoff: 5s, 25s
+			if attempt < maxAttempts {
+				select {
+				case <-d.ctx.Done():
+					return
+				case <-time.After(time.Duration(attempt*attempt) * 5 * time.Second):
+				}
+			}
+			continue
+		}
+
+		d.logger.Debug("Webhook delivered",
+			zap.Uint("webhook_id", item.webhook.ID),
+			zap.String("event", item.event),
+			zap.Int("attempt", attempt))
+		return
+	}
+
+	d.logger.Warn("Webhook delivery failed",
+		zap.Uint("webhook_id", item.webhook.ID),
+		zap.String("url", item.webhook.URL),
+		zap.String("event", item.event),
+		zap.Error(lastErr))
+}
+
+func (d *Dispatcher) send(hook *models.Webhook, event, deliveryID string, body []byte) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Xgent-Event", event)
+	req.Header.Set("X-Xgent-Delivery", deliveryID)
+	if hook.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(hook.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Xgent-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
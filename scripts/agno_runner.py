@@ -492,6 +492,10 @@ async def run_bot(data: Dict[str, Any]):
     session_id = data.get("session_id", "default")
     mcp_config = data.get("mcp_tools", [])
     
+    # Export the craft environment so tools inherit it
+    for key, value in data.get("tool_env", {}).items():
+        os.environ[key] = str(value)
+    
     # New options
     enable_streaming = data.get("stream", True)
     debug_mode = data.get("debug", False)
@@ -604,6 +608,10 @@ async def run_team(data: Dict[str, Any]):
     session_id = data.get("session_id", "default")
     mcp_config = data.get("mcp_tools", [])
     
+    # Export the craft environment so tools inherit it
+    for key, value in data.get("tool_env", {}).items():
+        os.environ[key] = str(value)
+    
     # New options
     enable_streaming = data.get("stream", True)
     debug_mode = data.get("debug", False)
@@ -786,13 +794,57 @@ async def main(data: Dict[str, Any]):
         emit_event("error", str(e), {"traceback": traceback.format_exc()})
 
 
+def serve():
+    """Persistent daemon mode (--serve): handle line-delimited JSON-RPC
+    requests over stdio until stdin closes. One request runs at a time; the
+    Go side pools several daemons for parallelism. Event lines keep the
+    plain bridge format, so only the response frames carry jsonrpc."""
+    global cancelled, current_run_id
+
+    for line in sys.stdin:
+        line = line.strip()
+        if not line:
+            continue
+
+        try:
+            request = json.loads(line)
+        except json.JSONDecodeError:
+            continue
+
+        rid = request.get("id")
+        method = request.get("method")
+
+        if method == "ping":
+            print(json.dumps({"jsonrpc": "2.0", "id": rid, "result": "pong"}), flush=True)
+            continue
+
+        if method == "run":
+            # Reset per-run state left over from the previous task
+            cancelled = False
+            current_run_id = None
+            try:
+                asyncio.run(main(request.get("params") or {}))
+                print(json.dumps({"jsonrpc": "2.0", "id": rid, "result": {"status": "done"}}), flush=True)
+            except Exception as e:
+                print(json.dumps({"jsonrpc": "2.0", "id": rid,
+                                  "error": {"code": -32000, "message": str(e)}}), flush=True)
+            continue
+
+        print(json.dumps({"jsonrpc": "2.0", "id": rid,
+                          "error": {"code": -32601, "message": f"unknown method: {method}"}}), flush=True)
+
+
 if __name__ == "__main__":
+    if "--serve" in sys.argv:
+        serve()
+        sys.exit(0)
+
     try:
         input_data = sys.stdin.read()
         if not input_data:
             emit_event("error", "No input data received")
             sys.exit(1)
-            
+
         data = json.loads(input_data)
         asyncio.run(main(data))
     except json.JSONDecodeError as e: